@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -31,6 +33,7 @@ type EthTx struct {
 	// NOTE: FromAddress is deprecated and kept for backwards compatibility, new job specs should use fromAddresses
 	FromAddress      common.Address          `json:"fromAddress,omitempty"`
 	FromAddresses    []common.Address        `json:"fromAddresses,omitempty"`
+	FromGroup        string                  `json:"fromGroup,omitempty"`
 	FunctionSelector models.FunctionSelector `json:"functionSelector"`
 	DataPrefix       hexutil.Bytes           `json:"dataPrefix"`
 	DataFormat       string                  `json:"format"`
@@ -41,6 +44,9 @@ type EthTx struct {
 
 	// MinRequiredOutgoingConfirmations only works with bulletprooftxmanager
 	MinRequiredOutgoingConfirmations uint64 `json:"minRequiredOutgoingConfirmations,omitempty"`
+
+	// EthTxStrategy only works with bulletprooftxmanager
+	models.EthTxStrategy
 }
 
 // TaskType returns the type of Adapter.
@@ -76,6 +82,18 @@ func (e *EthTx) checkForConfirmation(trtx models.EthTaskRunTx,
 	input models.RunInput, store *strpkg.Store) models.RunOutput {
 	switch trtx.EthTx.State {
 	case models.EthTxConfirmed:
+		if trtx.EthTx.CancelledAt != nil {
+			// A cancelled transaction may still have been mined with its
+			// original payload before the empty replacement
+			cancelled, err := minedCancellation(trtx.EthTx.ID, store.DB)
+			if err != nil {
+				logger.Error(err)
+				return models.NewRunOutputError(err)
+			}
+			if cancelled {
+				return models.NewRunOutputError(errors.Errorf("transaction %v was cancelled at %s", trtx.EthTx.ID, trtx.EthTx.CancelledAt.Format(time.RFC3339)))
+			}
+		}
 		return e.checkEthTxForReceipt(trtx.EthTx.ID, input, store)
 	case models.EthTxFatalError:
 		return models.NewRunOutputError(trtx.EthTx.GetError())
@@ -84,6 +102,18 @@ func (e *EthTx) checkForConfirmation(trtx models.EthTaskRunTx,
 	}
 }
 
+// minedCancellation reports whether the receipt of the transaction is that of
+// an empty replacement sent to cancel it
+func minedCancellation(ethTxID int64, db *gorm.DB) (bool, error) {
+	var mined struct{ Exists bool }
+	err := db.Raw(`SELECT EXISTS (
+		SELECT 1 FROM eth_receipts
+		INNER JOIN eth_tx_attempts ON eth_tx_attempts.hash = eth_receipts.tx_hash
+		WHERE eth_tx_attempts.eth_tx_id = ? AND eth_tx_attempts.cancellation
+	)`, ethTxID).Scan(&mined).Error
+	return mined.Exists, errors.Wrap(err, "minedCancellation failed")
+}
+
 func (e *EthTx) pickFromAddress(input models.RunInput, store *strpkg.Store) (common.Address, error) {
 	if len(e.FromAddresses) > 0 {
 		if e.FromAddress != utils.ZeroAddress {
@@ -91,10 +121,10 @@ func (e *EthTx) pickFromAddress(input models.RunInput, store *strpkg.Store) (com
 				" fromAddress is deprecated, it will be ignored and fromAddresses used instead. "+
 				"Specifying both of these keys in a job spec may result in an error in future versions of Chainlink", input.TaskRunID())
 		}
-		return store.GetRoundRobinAddress(e.FromAddresses...)
+		return store.PickKeyAddress(store.Config.EthKeySelectionPolicy(), e.FromGroup, e.FromAddresses...)
 	}
 	if e.FromAddress == utils.ZeroAddress {
-		return store.GetRoundRobinAddress()
+		return store.PickKeyAddress(store.Config.EthKeySelectionPolicy(), e.FromGroup)
 	}
 	logger.Warnf(`DEPRECATION WARNING: task spec for task run %s specified a fromAddress of %s. fromAddress has been deprecated and will be removed in a future version of Chainlink. Please use fromAddresses instead. You can pin a job to one address simply by using only one element, like so:
 {
@@ -122,6 +152,11 @@ func (e *EthTx) insertEthTx(input models.RunInput, store *strpkg.Store) models.R
 	}
 	encodedPayload := utils.ConcatBytes(e.FunctionSelector.Bytes(), e.DataPrefix, txData)
 
+	if err := e.EthTxStrategy.Validate(); err != nil {
+		err = errors.Wrap(err, "insertEthTx failed")
+		return models.NewRunOutputError(err)
+	}
+
 	var gasLimit uint64
 	if e.GasLimit == 0 {
 		gasLimit = store.Config.EthGasLimitDefault()
@@ -129,7 +164,7 @@ func (e *EthTx) insertEthTx(input models.RunInput, store *strpkg.Store) models.R
 		gasLimit = e.GasLimit
 	}
 
-	if err := store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, gasLimit); err != nil {
+	if err := store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, gasLimit, e.EthTxStrategy); err != nil {
 		err = errors.Wrap(err, "insertEthTx failed")
 		logger.Error(err)
 		return models.NewRunOutputError(err)
@@ -146,7 +181,7 @@ func (e *EthTx) checkEthTxForReceipt(ethTxID int64, input models.RunInput, s *st
 		minRequiredOutgoingConfirmations = e.MinRequiredOutgoingConfirmations
 	}
 
-	hash, err := getConfirmedTxHash(ethTxID, s.DB, minRequiredOutgoingConfirmations)
+	hash, err := getConfirmedTxHash(ethTxID, s.DB, minRequiredOutgoingConfirmations, s.HeadChain)
 
 	if err != nil {
 		logger.Error(err)
@@ -173,12 +208,18 @@ func (e *EthTx) checkEthTxForReceipt(ethTxID int64, input models.RunInput, s *st
 	return models.NewRunOutputComplete(output)
 }
 
-func getConfirmedTxHash(ethTxID int64, db *gorm.DB, minRequiredOutgoingConfirmations uint64) (*common.Hash, error) {
+// getConfirmedTxHash returns the hash of the transaction if its receipt has
+// the required confirmations, or is in a block the node reported as finalized
+func getConfirmedTxHash(ethTxID int64, db *gorm.DB, minRequiredOutgoingConfirmations uint64, finality eth.Finality) (*common.Hash, error) {
+	finalized, known := finality.FinalizedBlockNumber()
+	if !known || !finality.TaggedFinality() {
+		finalized = -1
+	}
 	receipt := models.EthReceipt{}
 	err := db.
 		Joins("INNER JOIN eth_tx_attempts ON eth_tx_attempts.hash = eth_receipts.tx_hash AND eth_tx_attempts.eth_tx_id = ?", ethTxID).
 		Joins("INNER JOIN eth_txes ON eth_txes.id = eth_tx_attempts.eth_tx_id AND eth_txes.state = 'confirmed'").
-		Where("eth_receipts.block_number <= (SELECT max(number) - ? FROM heads)", minRequiredOutgoingConfirmations).
+		Where("eth_receipts.block_number <= GREATEST((SELECT max(number) - ? FROM heads), ?)", minRequiredOutgoingConfirmations, finalized).
 		First(&receipt).
 		Error
 
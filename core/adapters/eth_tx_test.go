@@ -806,4 +806,70 @@ func TestEthTxAdapter_Perform_BPTXM(t *testing.T) {
 		assert.Equal(t, models.RunStatusErrored, runOutput.Status())
 		assert.Equal(t, "", runOutput.Result().String())
 	})
+
+	t.Run("with transaction that was dropped and replaced by an empty transaction returns job run error", func(t *testing.T) {
+		adapter := adapters.EthTx{
+			ToAddress:        toAddress,
+			GasLimit:         gasLimit,
+			FunctionSelector: functionSelector,
+			DataPrefix:       dataPrefix,
+		}
+		jobRunID := models.NewID()
+		taskRunID := cltest.MustInsertTaskRun(t, store)
+		etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 6, 1)
+		require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET cancelled_at = NOW() WHERE id = ?`, etx.ID).Error)
+
+		// The empty replacement was mined and confirmed
+		require.NoError(t, store.DB.Exec(`UPDATE eth_tx_attempts SET cancellation = true WHERE id = ?`, etx.EthTxAttempts[0].ID).Error)
+		cltest.MustInsertEthReceipt(t, store, 1, cltest.NewHash(), etx.EthTxAttempts[0].Hash)
+		require.NoError(t, store.IdempotentInsertHead(models.Head{
+			Hash:   cltest.NewHash(),
+			Number: int64(store.Config.MinRequiredOutgoingConfirmations()) + 2,
+		}))
+		require.NoError(t, store.DB.Exec(`INSERT INTO eth_task_run_txes (task_run_id, eth_tx_id) VALUES ($1, $2)`, taskRunID.UUID(), etx.ID).Error)
+		input := models.NewRunInputWithResult(jobRunID, taskRunID, "0x9786856756", models.RunStatusUnstarted)
+
+		// Do the thing
+		runOutput := adapter.Perform(*input, store)
+
+		require.Error(t, runOutput.Error())
+		assert.Contains(t, runOutput.Error().Error(), "was cancelled")
+		assert.Equal(t, models.RunStatusErrored, runOutput.Status())
+		assert.Equal(t, "", runOutput.Result().String())
+	})
+
+	t.Run("with transaction that was cancelled but mined with its original payload returns the transaction hash", func(t *testing.T) {
+		adapter := adapters.EthTx{
+			ToAddress:        toAddress,
+			GasLimit:         gasLimit,
+			FunctionSelector: functionSelector,
+			DataPrefix:       dataPrefix,
+		}
+		jobRunID := models.NewID()
+		taskRunID := cltest.MustInsertTaskRun(t, store)
+		etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 7, 1)
+		require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET cancelled_at = NOW() WHERE id = ?`, etx.ID).Error)
+
+		// The empty replacement was sent, but the original attempt was mined
+		cancellation := cltest.NewEthTxAttempt(t, etx.ID)
+		cancellation.GasPrice = *utils.NewBigI(30000)
+		cancellation.Cancellation = true
+		cancellation.State = models.EthTxAttemptBroadcast
+		require.NoError(t, store.DB.Save(&cancellation).Error)
+		originalAttemptHash := etx.EthTxAttempts[0].Hash
+		cltest.MustInsertEthReceipt(t, store, 1, cltest.NewHash(), originalAttemptHash)
+		require.NoError(t, store.IdempotentInsertHead(models.Head{
+			Hash:   cltest.NewHash(),
+			Number: int64(store.Config.MinRequiredOutgoingConfirmations()) + 2,
+		}))
+		require.NoError(t, store.DB.Exec(`INSERT INTO eth_task_run_txes (task_run_id, eth_tx_id) VALUES ($1, $2)`, taskRunID.UUID(), etx.ID).Error)
+		input := models.NewRunInputWithResult(jobRunID, taskRunID, "0x9786856756", models.RunStatusUnstarted)
+
+		// Do the thing
+		runOutput := adapter.Perform(*input, store)
+
+		require.NoError(t, runOutput.Error())
+		assert.Equal(t, models.RunStatusCompleted, runOutput.Status())
+		assert.Equal(t, originalAttemptHash.Hex(), runOutput.Result().String())
+	})
 }
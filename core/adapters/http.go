@@ -19,12 +19,13 @@ import (
 
 // HTTPGet requires a URL which is used for a GET request when the adapter is called.
 type HTTPGet struct {
-	URL                            models.WebURL   `json:"url"`
-	GET                            models.WebURL   `json:"get"`
-	Headers                        http.Header     `json:"headers"`
-	QueryParams                    QueryParameters `json:"queryParams"`
-	ExtendedPath                   ExtendedPath    `json:"extPath"`
-	AllowUnrestrictedNetworkAccess bool            `json:"-"`
+	URL                            models.WebURL    `json:"url"`
+	GET                            models.WebURL    `json:"get"`
+	Headers                        http.Header      `json:"headers"`
+	QueryParams                    QueryParameters  `json:"queryParams"`
+	ExtendedPath                   ExtendedPath     `json:"extPath"`
+	Auth                           *models.HTTPAuth `json:"auth,omitempty"`
+	AllowUnrestrictedNetworkAccess bool             `json:"-"`
 }
 
 // TaskType returns the type of Adapter.
@@ -39,6 +40,9 @@ func (hga *HTTPGet) Perform(input models.RunInput, store *store.Store) models.Ru
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
+	if err = authenticateRequest(request, nil, hga.Auth, store); err != nil {
+		return models.NewRunOutputError(err)
+	}
 	httpConfig := defaultHTTPConfig(store.Config)
 	httpConfig.AllowUnrestrictedNetworkAccess = hga.AllowUnrestrictedNetworkAccess
 	return sendRequest(input, request, httpConfig)
@@ -66,13 +70,14 @@ func (hga *HTTPGet) GetRequest() (*http.Request, error) {
 
 // HTTPPost requires a URL which is used for a POST request when the adapter is called.
 type HTTPPost struct {
-	URL                            models.WebURL   `json:"url"`
-	POST                           models.WebURL   `json:"post"`
-	Headers                        http.Header     `json:"headers"`
-	QueryParams                    QueryParameters `json:"queryParams"`
-	Body                           *string         `json:"body,omitempty"`
-	ExtendedPath                   ExtendedPath    `json:"extPath"`
-	AllowUnrestrictedNetworkAccess bool            `json:"-"`
+	URL                            models.WebURL    `json:"url"`
+	POST                           models.WebURL    `json:"post"`
+	Headers                        http.Header      `json:"headers"`
+	QueryParams                    QueryParameters  `json:"queryParams"`
+	Body                           *string          `json:"body,omitempty"`
+	ExtendedPath                   ExtendedPath     `json:"extPath"`
+	Auth                           *models.HTTPAuth `json:"auth,omitempty"`
+	AllowUnrestrictedNetworkAccess bool             `json:"-"`
 }
 
 // TaskType returns the type of Adapter.
@@ -83,10 +88,17 @@ func (hpa *HTTPPost) TaskType() models.TaskType {
 // Perform ensures that the adapter's URL responds to a POST request without
 // errors and returns the response body as the "value" field of the result.
 func (hpa *HTTPPost) Perform(input models.RunInput, store *store.Store) models.RunOutput {
-	request, err := hpa.GetRequest(input.Data().String())
+	body := input.Data().String()
+	if hpa.Body != nil {
+		body = *hpa.Body
+	}
+	request, err := hpa.GetRequest(body)
 	if err != nil {
 		return models.NewRunOutputError(err)
 	}
+	if err = authenticateRequest(request, []byte(body), hpa.Auth, store); err != nil {
+		return models.NewRunOutputError(err)
+	}
 	httpConfig := defaultHTTPConfig(store.Config)
 	httpConfig.AllowUnrestrictedNetworkAccess = hpa.AllowUnrestrictedNetworkAccess
 	return sendRequest(input, request, httpConfig)
@@ -148,6 +160,20 @@ func setHeaders(request *http.Request, headers http.Header, contentType string)
 	}
 }
 
+// authenticateRequest injects any secrets required by auth into the request.
+// Secrets are resolved from the store's secret store at execution time so
+// that they never need to appear in the job spec.
+func authenticateRequest(request *http.Request, body []byte, auth *models.HTTPAuth, store *store.Store) error {
+	if auth == nil {
+		return nil
+	}
+	var secrets models.SecretResolver
+	if store.SecretStore != nil {
+		secrets = store.SecretStore
+	}
+	return errors.Wrap(auth.Apply(request, body, secrets), "could not authenticate request")
+}
+
 func sendRequest(input models.RunInput, request *http.Request, config utils.HTTPRequestConfig) models.RunOutput {
 	httpRequest := utils.HTTPRequest{
 		Request: request,
@@ -219,6 +219,16 @@ func NewApp(client *Client) *cli.App {
 							Usage:  "Display the Account's address with its ETH & LINK balances",
 							Action: client.ListETHKeys,
 						},
+						{
+							Name:   "set-group",
+							Usage:  "Assign an Ethereum key to a group, or remove it from its group by passing an empty group name",
+							Action: client.SetETHKeyGroup,
+						},
+						{
+							Name:   "set-watermarks",
+							Usage:  "Set the balances in ETH below which, and up to which, a key is topped up from the treasury key; watermarks of 0 fall back to the node's configuration",
+							Action: client.SetETHKeyWatermarks,
+						},
 					},
 				},
 				cli.Command{
@@ -253,6 +263,13 @@ func NewApp(client *Client) *cli.App {
 							Usage:  format(`List available P2P keys`),
 							Action: client.ListP2PKeys,
 						},
+						{
+							Name: "rotate",
+							Usage: format(`Create a successor to the P2P key matching the given ID. Jobs
+               using the key switch to it along with their OCR key bundle, if
+               that is rotated as well, and otherwise when the node next starts`),
+							Action: client.RotateP2PKey,
+						},
 					},
 				},
 				cli.Command{
@@ -287,6 +304,14 @@ func NewApp(client *Client) *cli.App {
 							Usage:  format(`List available OCR key bundles`),
 							Action: client.ListOCRKeyBundles,
 						},
+						{
+							Name: "rotate",
+							Usage: format(`Create a successor to the OCR key bundle matching the given ID,
+               and list the jobs using the bundle. Each job keeps signing with
+               the bundle until the on-chain config of its contract lists the
+               signing address of the successor`),
+							Action: client.RotateOCRKeyBundle,
+						},
 					},
 				},
 				cli.Command{
@@ -353,6 +378,36 @@ func NewApp(client *Client) *cli.App {
 						},
 					},
 				},
+				cli.Command{
+					Name: "export-all",
+					Usage: format(`Local command to export every ETH, OCR, P2P and VRF key of the
+           node to a single file. Keys are decrypted with the password from
+           the password file, and encrypted with the password from the backup
+           password file.`),
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "password, p", Usage: "file containing the password of the node"},
+						cli.StringFlag{Name: "backuppassword, bp", Usage: "file containing the password to encrypt the backup with"},
+						cli.StringFlag{Name: "file, f", Usage: "file to write the backup to, which must not already exist"},
+					},
+					Action: client.ExportAllKeys,
+				},
+				cli.Command{
+					Name: "import-all",
+					Usage: format(`Local command to import the keys from a file written by
+           export-all. Keys are decrypted with the password from the backup
+           password file, and encrypted with the password from the password
+           file. Keys the node already has are skipped.`),
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "password, p", Usage: "file containing the password of the node"},
+						cli.StringFlag{Name: "backuppassword, bp", Usage: "file containing the password the backup was encrypted with"},
+						cli.StringFlag{Name: "file, f", Usage: "file to read the backup from"},
+						cli.BoolFlag{
+							Name:  "dryrun",
+							Usage: "only check that every key in the backup decrypts, without importing them",
+						},
+					},
+					Action: client.ImportAllKeys,
+				},
 			},
 		},
 
@@ -374,6 +429,19 @@ func NewApp(client *Client) *cli.App {
 					Usage:   "Import a key file to use with the node",
 					Action:  client.ImportKey,
 				},
+				{
+					Name: "hd-init",
+					Usage: format(`Save the BIP-39 seed that new ETH keys of the node are derived
+           from, on the path m/44'/60'/0'/0. Without a mnemonic file, a new
+           mnemonic is generated and printed once. To recover a node, pass the
+           mnemonic and the number of keys it had.`),
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "password, p", Usage: "file containing the password of the node"},
+						cli.StringFlag{Name: "mnemonic, m", Usage: "file containing the BIP-39 mnemonic to derive keys from"},
+						cli.IntFlag{Name: "keys", Value: 1, Usage: "number of ETH keys to derive"},
+					},
+					Action: client.InitHDSeed,
+				},
 				{
 					Name:   "setnextnonce",
 					Usage:  "Manually set the next nonce for a key. This should NEVER be necessary during normal operation. USE WITH CAUTION: Setting this incorrectly can break your node.",
@@ -538,6 +606,44 @@ func NewApp(client *Client) *cli.App {
 			},
 		},
 
+		{
+			Name:  "secrets",
+			Usage: "Commands for managing encrypted secrets used to authenticate HTTP tasks",
+			Subcommands: []cli.Command{
+				{
+					Name:   "create",
+					Usage:  "Create or replace a secret, encrypted with the node's password",
+					Action: client.CreateSecret,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "value",
+							Usage: "the secret's value",
+						},
+						cli.StringFlag{
+							Name:  "file, f",
+							Usage: "text file holding the secret's value",
+						},
+					},
+				},
+				{
+					Name:   "delete",
+					Usage:  "Delete a secret by name",
+					Action: client.DeleteSecret,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "yes, y",
+							Usage: "skip the confirmation prompt",
+						},
+					},
+				},
+				{
+					Name:   "list",
+					Usage:  "List the names of all secrets",
+					Action: client.ListSecrets,
+				},
+			},
+		},
+
 		{
 			Name:  "txs",
 			Usage: "Commands for handling Ethereum transactions",
@@ -563,6 +669,22 @@ func NewApp(client *Client) *cli.App {
 					Usage:  "get information on a specific Ethereum Transaction",
 					Action: client.ShowTransaction,
 				},
+				{
+					Name:   "cancel",
+					Usage:  "Cancel the Ethereum Transaction with the given ID or attempt hash. A transaction that was already sent is replaced by an empty transaction to self",
+					Action: client.CancelTransaction,
+				},
+				{
+					Name:   "reprice",
+					Usage:  "Send the Ethereum Transaction with the given ID or attempt hash again at a higher gas price, in Wei",
+					Action: client.RepriceTransaction,
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "gwei",
+							Usage: "Specify gas price in gwei",
+						},
+					},
+				},
 			},
 		},
 	}...)
@@ -74,7 +74,12 @@ func (n ChainlinkAppFactory) NewApplication(config *orm.Config, onConnectCallbac
 		ethClient = &eth.NullClient{}
 	} else {
 		var err error
-		ethClient, err = eth.NewClient(config.EthereumURL(), config.EthereumSecondaryURL())
+		ethClient, err = eth.NewPool(config.EthereumPrimaryURLs(), config.EthereumSecondaryURLs(), eth.PoolConfig{
+			ChainID:             config.ChainID(),
+			HealthCheckInterval: config.EthNodeHealthCheckInterval(),
+			MaxHeadAge:          config.EthNodeMaxHeadAge(),
+			PollingInterval:     config.EthNodePollingInterval(),
+		})
 		if err != nil {
 			logger.Fatal(fmt.Sprintf("Unable to create ETH client: %+v", err))
 		}
@@ -22,6 +22,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/migrations"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -71,6 +72,14 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 		return cli.errorOut(errors.Wrapf(authErr, "while authenticating with OCR password"))
 	}
 
+	if unlockErr := store.SecretStore.Unlock(keyStorePwd); unlockErr != nil {
+		return cli.errorOut(errors.Wrapf(unlockErr, "while unlocking secrets"))
+	}
+
+	if unlockErr := store.UnlockHDSeed(keyStorePwd); unlockErr != nil {
+		return cli.errorOut(errors.Wrap(unlockErr, "while unlocking HD seed"))
+	}
+
 	if len(c.String("vrfpassword")) != 0 {
 		vrfpwd, fileErr := passwordFromFile(c.String("vrfpassword"))
 		if fileErr != nil {
@@ -104,7 +113,9 @@ func (cli *Client) RunNode(c *clipkg.Context) error {
 		return err
 	}
 
-	if !store.Config.EthereumDisabled() {
+	if store.Config.EthRemoteSignerURL() != "" {
+		logger.Info("Not setting up a funding address, since keys are held by the remote signer")
+	} else if !store.Config.EthereumDisabled() {
 		fundingKey, currentBalance, err := setupFundingKey(context.TODO(), app.GetStore(), keyStorePwd)
 		if err != nil {
 			return cli.errorOut(errors.Wrap(err, "failed to generate a funding address"))
@@ -268,6 +279,7 @@ func setupFundingKey(ctx context.Context, str *strpkg.Store, pwd string) (*model
 			Result: gjson.ParseBytes(exportedJSON),
 		},
 		NextNonce: &firstNonce,
+		HDIndex:   str.KeyHDIndex(ethAccount.Address),
 	}
 	// The key does not exist at this point, so we're only creating it here.
 	if err = str.CreateKeyIfNotExists(key); err != nil {
@@ -326,7 +338,7 @@ func (cli *Client) RebroadcastTransactions(c *clipkg.Context) (err error) {
 	if store.Config.EnableBulletproofTxManager() {
 		logger.Infof("Rebroadcasting transactions from %v to %v", beginningNonce, endingNonce)
 
-		ec := bulletprooftxmanager.NewEthConfirmer(store, cli.Config)
+		ec := bulletprooftxmanager.NewEthConfirmer(store, cli.Config, gas.NewFixedEstimator(cli.Config))
 		err = ec.ForceRebroadcast(beginningNonce, endingNonce, gasPriceWei, address, overrideGasLimit)
 	} else {
 		logger.Infof("Rebroadcasting legacy transactions from %v to %v", beginningNonce, endingNonce)
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	clipkg "github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// getBackupPassword retrieves the password of a key backup from the file
+// specified on the CL, or errors
+func getBackupPassword(c *clipkg.Context) (string, error) {
+	if c.String("backuppassword") == "" {
+		return "", fmt.Errorf("must specify backup password file")
+	}
+	password, err := passwordFromFile(c.String("backuppassword"))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not read backup password from file %s",
+			c.String("backuppassword"))
+	}
+	if password == "" {
+		return "", fmt.Errorf("backup password must not be empty")
+	}
+	return password, nil
+}
+
+// ExportAllKeys writes every ETH, OCR, P2P and VRF key of the node to a file,
+// encrypted with the password in the backup password file
+func (cli *Client) ExportAllKeys(c *clipkg.Context) error {
+	cli.Config.Dialect = orm.DialectPostgresWithoutLock
+	password, err := getPassword(c)
+	if err != nil {
+		return err
+	}
+	backupPassword, err := getBackupPassword(c)
+	if err != nil {
+		return err
+	}
+	if !c.IsSet("file") || !noFileToOverwrite(c.String("file")) {
+		return fmt.Errorf("must specify path to backup file which does not already exist")
+	}
+
+	str := cli.AppFactory.NewApplication(cli.Config).GetStore()
+	if str.Config.EthRemoteSignerURL() != "" {
+		fmt.Println("ETH keys are held by the remote signer, and will not be exported")
+	}
+	backup, err := str.ExportKeyBackup(string(password), backupPassword)
+	if err != nil {
+		return errors.Wrap(err, "while exporting keys")
+	}
+	backupJSON, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "while exporting keys")
+	}
+	if err := utils.WriteFileWithMaxPerms(c.String("file"), backupJSON, 0600); err != nil {
+		return errors.Wrapf(err, "could not write backup to %s", c.String("file"))
+	}
+	fmt.Printf("Exported %d ETH keys, %d OCR key bundles, %d P2P keys and %d VRF keys to %s\n",
+		len(backup.ETHKeys), len(backup.OCRKeyBundles), len(backup.P2PKeys), len(backup.VRFKeys), c.String("file"))
+	return nil
+}
+
+// ImportAllKeys imports the keys from a file written by ExportAllKeys,
+// encrypted with the password in the password file. With the dryrun flag set,
+// it only checks that every key in the file decrypts.
+func (cli *Client) ImportAllKeys(c *clipkg.Context) error {
+	cli.Config.Dialect = orm.DialectPostgresWithoutLock
+	password, err := getPassword(c)
+	if err != nil {
+		return err
+	}
+	backupPassword, err := getBackupPassword(c)
+	if err != nil {
+		return err
+	}
+	if !c.IsSet("file") {
+		return fmt.Errorf("must specify backup file")
+	}
+	backupJSON, err := ioutil.ReadFile(c.String("file"))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file %s", c.String("file"))
+	}
+	var backup store.KeyBackup
+	if err := json.Unmarshal(backupJSON, &backup); err != nil {
+		return errors.Wrapf(err, "could not parse backup file %s", c.String("file"))
+	}
+
+	str := cli.AppFactory.NewApplication(cli.Config).GetStore()
+	dryRun := c.Bool("dryrun")
+	summary, err := str.ImportKeyBackup(&backup, backupPassword, string(password), dryRun)
+	if err != nil {
+		return errors.Wrap(err, "while importing keys")
+	}
+	verb := "Imported"
+	if dryRun {
+		verb = "Every key decrypted. Would import"
+	}
+	fmt.Printf(`Backup of chainlink version %s, created at %s.
+%s %d ETH keys, %d OCR key bundles, %d P2P keys and %d VRF keys.
+Skipped %d ETH keys, %d OCR key bundles, %d P2P keys and %d VRF keys the node already has.
+`,
+		backup.ChainlinkVersion, backup.CreatedAt,
+		verb, summary.ETHKeys.Imported, summary.OCRKeyBundles.Imported, summary.P2PKeys.Imported, summary.VRFKeys.Imported,
+		summary.ETHKeys.Skipped, summary.OCRKeyBundles.Skipped, summary.P2PKeys.Skipped, summary.VRFKeys.Skipped)
+	if !dryRun && summary.ETHKeys.Imported > 0 {
+		fmt.Println("Restart the node to unlock the imported ETH keys")
+	}
+	return nil
+}
+
+// InitHDSeed saves the BIP-39 seed that new ETH keys of the node are derived
+// from, encrypted with the password in the password file. Without a mnemonic
+// file, a new mnemonic is generated and printed once, before the seed is
+// saved, so that it is never lost to a later failure.
+func (cli *Client) InitHDSeed(c *clipkg.Context) error {
+	cli.Config.Dialect = orm.DialectPostgresWithoutLock
+	password, err := getPassword(c)
+	if err != nil {
+		return err
+	}
+	numKeys := c.Int("keys")
+	if numKeys < 0 {
+		return fmt.Errorf("keys must not be negative")
+	}
+
+	var mnemonic string
+	if c.IsSet("mnemonic") {
+		mnemonicBytes, fileErr := ioutil.ReadFile(c.String("mnemonic"))
+		if fileErr != nil {
+			return errors.Wrapf(fileErr, "failed to read file %s", c.String("mnemonic"))
+		}
+		mnemonic = string(mnemonicBytes)
+	}
+
+	str := cli.AppFactory.NewApplication(cli.Config).GetStore()
+	if err = str.KeyStore.Unlock(string(password)); err != nil {
+		return errors.Wrap(err, "could not unlock existing ETH keys with password")
+	}
+	if existing, findErr := str.FindEncryptedHDSeed(); findErr != nil {
+		return errors.Wrap(findErr, "while checking for existing seed")
+	} else if existing != nil {
+		return errors.New("the node already has a seed")
+	}
+
+	if !c.IsSet("mnemonic") {
+		if mnemonic, err = store.NewMnemonic(); err != nil {
+			return errors.Wrap(err, "while generating mnemonic")
+		}
+		fmt.Printf(`New ETH keys of the node will be derived from the seed of this mnemonic.
+Write it down and keep it safe, it will not be shown again:
+
+%s
+
+`, mnemonic)
+	}
+
+	if err = str.InitHDSeed(mnemonic, string(password)); err != nil {
+		return errors.Wrap(err, "while saving seed")
+	}
+	if err = str.UnlockHDSeed(string(password)); err != nil {
+		return errors.Wrap(err, "while unlocking seed")
+	}
+	for i := 0; i < numKeys; i++ {
+		account, accountErr := str.KeyStore.NewAccount(string(password))
+		if accountErr != nil {
+			return errors.Wrap(accountErr, "while deriving ETH key")
+		}
+		fmt.Println("Derived ETH key", account.Address.Hex())
+	}
+	return errors.Wrap(str.SyncDiskKeyStoreToDB(), "while saving derived ETH keys")
+}
@@ -2,6 +2,7 @@ package cmd_test
 
 import (
 	"flag"
+	"io/ioutil"
 	"math/big"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 
+	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -321,6 +323,133 @@ func TestClient_ImportKey(t *testing.T) {
 	require.Equal(t, expectation, addresses)
 }
 
+func TestClient_ExportImportAllKeys(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t,
+		cltest.AllowUnstarted,
+		cltest.LenientEthMock,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	client, _ := app.NewClientAndRenderer()
+	store := app.GetStore()
+
+	require.NoError(t, store.OCRKeyStore.Unlock(cltest.Password))
+	_, ocrKey, err := store.OCRKeyStore.GenerateEncryptedOCRKeyBundle()
+	require.NoError(t, err)
+	vrfKey, err := store.VRFKeyStore.CreateKey(cltest.Password)
+	require.NoError(t, err)
+
+	backupPasswordFile := filepath.Join(app.Config.RootDir(), "backup_password.txt")
+	require.NoError(t, ioutil.WriteFile(backupPasswordFile, []byte("backup password"), 0600))
+	backupFile := filepath.Join(app.Config.RootDir(), "backup.json")
+
+	newContext := func(backupPasswordFile string, dryRun bool) *cli.Context {
+		set := flag.NewFlagSet("keys", 0)
+		set.String("password", "../internal/fixtures/correct_password.txt", "")
+		set.String("backuppassword", backupPasswordFile, "")
+		set.String("file", backupFile, "")
+		set.Bool("dryrun", dryRun, "")
+		return cli.NewContext(nil, set, nil)
+	}
+
+	require.NoError(t, client.ExportAllKeys(newContext(backupPasswordFile, false)))
+	require.FileExists(t, backupFile)
+	// An existing backup is never overwritten
+	require.Error(t, client.ExportAllKeys(newContext(backupPasswordFile, false)))
+
+	require.NoError(t, store.OCRKeyStore.DeleteEncryptedOCRKeyBundle(&ocrKey))
+	require.NoError(t, store.VRFKeyStore.Delete(vrfKey))
+
+	t.Run("with the wrong backup password imports nothing", func(t *testing.T) {
+		err := client.ImportAllKeys(newContext("../internal/fixtures/incorrect_password.txt", false))
+		require.Error(t, err)
+
+		ocrKeys, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		assert.Len(t, ocrKeys, 0)
+	})
+
+	t.Run("dry run imports nothing", func(t *testing.T) {
+		require.NoError(t, client.ImportAllKeys(newContext(backupPasswordFile, true)))
+
+		ocrKeys, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		assert.Len(t, ocrKeys, 0)
+	})
+
+	t.Run("imports the deleted keys", func(t *testing.T) {
+		require.NoError(t, client.ImportAllKeys(newContext(backupPasswordFile, false)))
+
+		ocrKeys, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		require.Len(t, ocrKeys, 1)
+		assert.Equal(t, ocrKey.ID, ocrKeys[0].ID)
+
+		vrfKeys, err := store.VRFKeyStore.Get(vrfKey)
+		require.NoError(t, err)
+		assert.Len(t, vrfKeys, 1)
+	})
+}
+
+func TestClient_InitHDSeed(t *testing.T) {
+	t.Parallel()
+
+	newApp := func(t *testing.T) (*cltest.TestApplication, func()) {
+		return cltest.NewApplication(t,
+			cltest.AllowUnstarted,
+			cltest.LenientEthMock,
+			cltest.EthMockRegisterChainID,
+			cltest.EthMockRegisterGetBalance,
+		)
+	}
+	newContext := func(mnemonicFile string) *cli.Context {
+		set := flag.NewFlagSet("hd-init", 0)
+		set.String("password", "../internal/fixtures/correct_password.txt", "")
+		set.Int("keys", 1, "")
+		if mnemonicFile != "" {
+			set.String("mnemonic", mnemonicFile, "")
+		}
+		return cli.NewContext(nil, set, nil)
+	}
+
+	t.Run("with a generated mnemonic", func(t *testing.T) {
+		app, cleanup := newApp(t)
+		defer cleanup()
+		client, _ := app.NewClientAndRenderer()
+
+		require.NoError(t, client.InitHDSeed(newContext("")))
+
+		seed, err := app.GetStore().FindEncryptedHDSeed()
+		require.NoError(t, err)
+		assert.NotNil(t, seed)
+		keys, err := app.GetStore().SendKeys()
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+
+		err = client.InitHDSeed(newContext(""))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the node already has a seed")
+	})
+
+	t.Run("with a mnemonic file", func(t *testing.T) {
+		app, cleanup := newApp(t)
+		defer cleanup()
+		client, _ := app.NewClientAndRenderer()
+
+		mnemonicFile := filepath.Join(app.Config.RootDir(), "mnemonic.txt")
+		mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		require.NoError(t, ioutil.WriteFile(mnemonicFile, []byte(mnemonic), 0600))
+
+		require.NoError(t, client.InitHDSeed(newContext(mnemonicFile)))
+
+		_, err := app.GetStore().KeyByAddress(common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"))
+		require.NoError(t, err)
+	})
+}
+
 func TestClient_LogToDiskOptionDisablesAsExpected(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -10,8 +10,10 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/manyminds/api2go/jsonapi"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -51,6 +53,64 @@ func (cli *Client) ListETHKeys(c *clipkg.Context) (err error) {
 	return err
 }
 
+// SetETHKeyGroup assigns the key with the given address to a group, which
+// EthTx tasks can select with fromGroup
+func (cli *Client) SetETHKeyGroup(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass the address of the key and the group name"))
+	}
+	address := c.Args().Get(0)
+	if !common.IsHexAddress(address) {
+		return cli.errorOut(fmt.Errorf("invalid address %s", address))
+	}
+
+	group := c.Args().Get(1)
+	return cli.patchKey(address, models.UpdateKeyRequest{Group: &group})
+}
+
+// SetETHKeyWatermarks sets the balances below which, and up to which, the key
+// with the given address is topped up from the treasury key
+func (cli *Client) SetETHKeyWatermarks(c *clipkg.Context) (err error) {
+	if c.NArg() != 3 {
+		return cli.errorOut(errors.New("Must pass the address of the key, and the low and high watermarks in ETH"))
+	}
+	address := c.Args().Get(0)
+	if !common.IsHexAddress(address) {
+		return cli.errorOut(fmt.Errorf("invalid address %s", address))
+	}
+	low, err := assets.NewEthValueS(c.Args().Get(1))
+	if err != nil {
+		return cli.errorOut(multierr.Combine(errors.New("while parsing low watermark"), err))
+	}
+	high, err := assets.NewEthValueS(c.Args().Get(2))
+	if err != nil {
+		return cli.errorOut(multierr.Combine(errors.New("while parsing high watermark"), err))
+	}
+
+	return cli.patchKey(address, models.UpdateKeyRequest{LowWatermark: &low, HighWatermark: &high})
+}
+
+func (cli *Client) patchKey(address string, request models.UpdateKeyRequest) (err error) {
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	buf := bytes.NewBuffer(requestData)
+	resp, err := cli.HTTP.Patch("/v2/keys/"+address, buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = cli.printResponseBody(resp)
+	return err
+}
+
 // CreateServiceAgreement creates a ServiceAgreement based on JSON input
 func (cli *Client) CreateServiceAgreement(c *clipkg.Context) (err error) {
 	if !c.Args().Present() {
@@ -570,6 +630,57 @@ func (cli *Client) ShowTransaction(c *clipkg.Context) (err error) {
 	return err
 }
 
+// CancelTransaction cancels the eth_tx with the given ID or attempt hash. An
+// unsent transaction will never be sent, and a sent one is replaced by an
+// empty transaction to self.
+func (cli *Client) CancelTransaction(c *clipkg.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.errorOut(errors.New("Must pass the ID or hash of the transaction"))
+	}
+	return cli.patchEthTx(c.Args().First(), models.EthTxPatchRequest{Cancel: true})
+}
+
+// RepriceTransaction sends the eth_tx with the given ID or attempt hash again
+// at a higher gas price
+func (cli *Client) RepriceTransaction(c *clipkg.Context) (err error) {
+	if c.NArg() != 2 {
+		return cli.errorOut(errors.New("Must pass the ID or hash of the transaction, and the new gas price"))
+	}
+
+	value := c.Args().Get(1)
+	amount, ok := new(big.Float).SetString(value)
+	if !ok {
+		return cli.errorOut(fmt.Errorf("invalid gas price %s", value))
+	}
+	if c.IsSet("gwei") {
+		amount.Mul(amount, big.NewFloat(1000000000))
+	}
+	gasPriceWei, _ := amount.Int(nil)
+
+	return cli.patchEthTx(c.Args().First(), models.EthTxPatchRequest{GasPriceWei: utils.NewBig(gasPriceWei)})
+}
+
+func (cli *Client) patchEthTx(id string, request models.EthTxPatchRequest) (err error) {
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	buf := bytes.NewBuffer(requestData)
+	resp, err := cli.HTTP.Patch("/v2/eth_txes/"+id, buf)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	err = cli.printResponseBody(resp)
+	return err
+}
+
 // IndexTxAttempts returns the list of transactions in descending order,
 // taking an optional page parameter
 func (cli *Client) IndexTxAttempts(c *clipkg.Context) error {
@@ -927,3 +1038,146 @@ func (cli *Client) DeleteOCRKeyBundle(c *clipkg.Context) error {
 	var key ocrkey.EncryptedKeyBundle
 	return cli.renderAPIResponse(resp, &key)
 }
+
+// CreateSecret encrypts and stores a named secret on the node, replacing any
+// existing secret with the same name
+func (cli *Client) CreateSecret(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the secret to be created"))
+	}
+
+	value := c.String("value")
+	if file := c.String("file"); file != "" {
+		dat, rerr := ioutil.ReadFile(file)
+		if rerr != nil {
+			return cli.errorOut(rerr)
+		}
+		value = strings.TrimSpace(string(dat))
+	}
+	if value == "" {
+		return cli.errorOut(errors.New("Must pass the secret's value with --value or --file"))
+	}
+
+	request := web.SecretRequest{
+		Name:  c.Args().First(),
+		Value: value,
+	}
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post("/v2/secrets", bytes.NewBuffer(requestData))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var secret models.EncryptedSecret
+	return cli.renderAPIResponse(resp, &secret)
+}
+
+// ListSecrets lists the names of the node's secrets
+func (cli *Client) ListSecrets(c *clipkg.Context) (err error) {
+	resp, err := cli.HTTP.Get("/v2/secrets")
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var secrets []models.EncryptedSecret
+	return cli.renderAPIResponse(resp, &secrets)
+}
+
+// DeleteSecret deletes a named secret from the node
+func (cli *Client) DeleteSecret(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the name of the secret to be deleted"))
+	}
+
+	if !confirmAction(c) {
+		return nil
+	}
+
+	resp, err := cli.HTTP.Delete("/v2/secrets/" + url.PathEscape(c.Args().First()))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	_, err = cli.parseResponse(resp)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	fmt.Printf("Secret %s deleted.\n", c.Args().First())
+	return nil
+}
+
+// RotateOCRKeyBundle creates a successor to an OCR key bundle, and lists the
+// jobs that will switch to it once the on-chain config of their contract
+// lists it
+func (cli *Client) RotateOCRKeyBundle(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the key ID to be rotated"))
+	}
+	id, err := models.Sha256HashFromHex(c.Args().Get(0))
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post(fmt.Sprintf("/v2/off_chain_reporting_keys/%s/rotate", id), nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	if resp.StatusCode == 200 {
+		fmt.Printf("Created successor to OCR key bundle. Update the config of each contract below with its signing address and public keys.\n\n")
+	}
+	var rotation presenters.OCRKeyBundleRotation
+	return cli.renderAPIResponse(resp, &rotation)
+}
+
+// RotateP2PKey creates a successor to a P2P key, and lists the jobs that will
+// switch to it
+func (cli *Client) RotateP2PKey(c *clipkg.Context) (err error) {
+	if !c.Args().Present() {
+		return cli.errorOut(errors.New("Must pass the key ID to be rotated"))
+	}
+	id, err := strconv.ParseUint(c.Args().Get(0), 10, 32)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+
+	resp, err := cli.HTTP.Post(fmt.Sprintf("/v2/p2p_keys/%d/rotate", id), nil)
+	if err != nil {
+		return cli.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	if resp.StatusCode == 200 {
+		fmt.Printf("Created successor to P2P key. Jobs that also rotate their OCR key bundle switch to it along with the bundle, others when the node next starts.\n\n")
+	}
+	var rotation presenters.P2PKeyRotation
+	return cli.renderAPIResponse(resp, &rotation)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
@@ -86,6 +87,20 @@ func (rt RendererTable) Render(v interface{}) error {
 		return rt.renderOCRKeys([]ocrkey.EncryptedKeyBundle{*typed})
 	case *[]ocrkey.EncryptedKeyBundle:
 		return rt.renderOCRKeys(*typed)
+	case *presenters.OCRKeyBundleRotation:
+		if err := rt.renderOCRKeys([]ocrkey.EncryptedKeyBundle{typed.EncryptedKeyBundle}); err != nil {
+			return err
+		}
+		return rt.renderKeyRotationJobs(typed.Jobs)
+	case *presenters.P2PKeyRotation:
+		if err := rt.renderP2PKeys([]p2pkey.EncryptedP2PKey{typed.EncryptedP2PKey}); err != nil {
+			return err
+		}
+		return rt.renderKeyRotationJobs(typed.Jobs)
+	case *models.EncryptedSecret:
+		return rt.renderSecrets([]models.EncryptedSecret{*typed})
+	case *[]models.EncryptedSecret:
+		return rt.renderSecrets(*typed)
 	default:
 		return fmt.Errorf("unable to render object of type %T: %v", typed, typed)
 	}
@@ -413,13 +428,15 @@ func (rt RendererTable) renderETHKeys(keys []presenters.ETHKey) error {
 			nextNonce,
 			lastUsed,
 			fmt.Sprintf("%v", key.IsFunding),
+			key.Group,
+			fmt.Sprintf("%d", key.PendingTxs),
 			key.CreatedAt.String(),
 			key.UpdatedAt.String(),
 			deletedAt,
 		})
 	}
 	fmt.Println("\n🔑 ETH Keys")
-	renderList([]string{"Address", "ETH", "LINK", "Next nonce", "Last used", "Is funding", "Created", "Updated", "Deleted"}, rows)
+	renderList([]string{"Address", "ETH", "LINK", "Next nonce", "Last used", "Is funding", "Group", "Pending txs", "Created", "Updated", "Deleted"}, rows)
 	return nil
 }
 
@@ -465,3 +482,30 @@ func (rt RendererTable) renderOCRKeys(ocrKeys []ocrkey.EncryptedKeyBundle) error
 	renderList([]string{"ID", "On-chain signing addr", "Off-chain pubkey", "Config pubkey", "Created", "Updated", "Deleted"}, rows)
 	return nil
 }
+
+func (rt RendererTable) renderKeyRotationJobs(jobs []offchainreporting.KeyRotationJob) error {
+	var rows [][]string
+	for _, job := range jobs {
+		rows = append(rows, []string{
+			fmt.Sprintf("%v", job.JobID),
+			job.ContractAddress.String(),
+		})
+	}
+	fmt.Println("\n💼 Jobs using the rotated key, which switch to the new key once the contract config lists it")
+	renderList([]string{"Job ID", "Contract address"}, rows)
+	return nil
+}
+
+func (rt RendererTable) renderSecrets(secrets []models.EncryptedSecret) error {
+	var rows [][]string
+	for _, secret := range secrets {
+		rows = append(rows, []string{
+			secret.Name,
+			secret.CreatedAt.String(),
+			secret.UpdatedAt.String(),
+		})
+	}
+	fmt.Println("\n🔒 Secrets")
+	renderList([]string{"Name", "Created", "Updated"}, rows)
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
@@ -284,6 +285,40 @@ func (c *SimulatedBackendClient) CallContext(ctx context.Context, result interfa
 	return c.Call(result, method, args)
 }
 
+// BatchCallContext answers each request from the simulated backend
+func (c *SimulatedBackendClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i, elem := range b {
+		switch elem.Method {
+		case "eth_getTransactionReceipt":
+			receipt, err := c.TransactionReceipt(ctx, elem.Args[0].(common.Hash))
+			if err == nil {
+				*elem.Result.(**types.Receipt) = receipt
+			}
+			b[i].Error = err
+		case "eth_getBlockByNumber":
+			n, err := hexutil.DecodeBig(elem.Args[0].(string))
+			if err != nil {
+				b[i].Error = err
+				continue
+			}
+			head, err := c.HeaderByNumber(ctx, n)
+			if err == nil {
+				*elem.Result.(**models.Head) = head
+			}
+			b[i].Error = err
+		case "eth_getBalance":
+			balance, err := c.BalanceAt(ctx, elem.Args[0].(common.Address), nil)
+			if err == nil {
+				*elem.Result.(*hexutil.Big) = hexutil.Big(*balance)
+			}
+			b[i].Error = err
+		default:
+			b[i].Error = c.CallContext(ctx, elem.Result, elem.Method, elem.Args...)
+		}
+	}
+	return nil
+}
+
 func (c *SimulatedBackendClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
 	return c.b.CallContract(ctx, msg, blockNumber)
 }
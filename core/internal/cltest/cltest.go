@@ -32,6 +32,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
@@ -42,10 +43,13 @@ import (
 	"github.com/smartcontractkit/chainlink/core/web"
 
 	"github.com/DATA-DOG/go-txdb"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/gin-gonic/gin"
 	"github.com/gobuffalo/packr"
@@ -56,6 +60,7 @@ import (
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 	"go.uber.org/zap/zapcore"
@@ -254,7 +259,7 @@ func NewEthBroadcaster(t testing.TB, store *strpkg.Store, config *TestConfig) (b
 	t.Helper()
 	eventBroadcaster := postgres.NewEventBroadcaster(config.DatabaseURL(), 0, 0)
 	eventBroadcaster.Start()
-	return bulletprooftxmanager.NewEthBroadcaster(store, config, eventBroadcaster), func() {
+	return bulletprooftxmanager.NewEthBroadcaster(store, config, eventBroadcaster, gas.NewFixedEstimator(config)), func() {
 		eventBroadcaster.Stop()
 	}
 }
@@ -351,6 +356,7 @@ func NewApplicationWithConfigAndKey(t testing.TB, tc *TestConfig, flagsAndDeps .
 
 	app, cleanup := NewApplicationWithConfig(t, tc, flagsAndDeps...)
 	require.NoError(t, app.Store.KeyStore.Unlock(Password))
+	require.NoError(t, app.Store.SecretStore.Unlock(Password))
 
 	return app, cleanup
 }
@@ -1541,3 +1547,33 @@ func MustInsertInProgressEthTxWithAttempt(t *testing.T, store *strpkg.Store, non
 	require.NoError(t, err)
 	return etx
 }
+
+// MockBatchCallContext answers batched JSON-RPC requests made through
+// rpcClient by making the equivalent individual call on gethClient or
+// rpcClient, so that tests can keep mocking single calls
+func MockBatchCallContext(rpcClient *mocks.RPCClient, gethClient *mocks.GethClient) {
+	rpcClient.On("BatchCallContext", mock.Anything, mock.Anything).Maybe().Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		b := args.Get(1).([]rpc.BatchElem)
+		for i, elem := range b {
+			switch elem.Method {
+			case "eth_getTransactionReceipt":
+				receipt, err := gethClient.TransactionReceipt(ctx, elem.Args[0].(common.Hash))
+				if err != nil && err != ethereum.NotFound {
+					b[i].Error = err
+				} else {
+					*elem.Result.(**types.Receipt) = receipt
+				}
+			case "eth_getBalance":
+				balance, err := gethClient.BalanceAt(ctx, elem.Args[0].(common.Address), nil)
+				if err != nil {
+					b[i].Error = err
+				} else {
+					*elem.Result.(*hexutil.Big) = hexutil.Big(*balance)
+				}
+			default:
+				b[i].Error = rpcClient.CallContext(ctx, elem.Result, elem.Method, elem.Args...)
+			}
+		}
+	}).Return(nil)
+}
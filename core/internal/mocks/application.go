@@ -6,8 +6,13 @@ import (
 	context "context"
 	big "math/big"
 
+	bulletprooftxmanager "github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+
 	packr "github.com/gobuffalo/packr"
+
+	gas "github.com/smartcontractkit/chainlink/core/services/gas"
 	job "github.com/smartcontractkit/chainlink/core/services/job"
+	pipeline "github.com/smartcontractkit/chainlink/core/services/pipeline"
 	synchronization "github.com/smartcontractkit/chainlink/core/services/synchronization"
 	store "github.com/smartcontractkit/chainlink/core/store"
 	models "github.com/smartcontractkit/chainlink/core/store/models"
@@ -62,6 +67,20 @@ func (_m *Application) AwaitRun(ctx context.Context, runID int64) error {
 	return nil
 }
 
+// ResumeJobV2 provides a mock function with given fields: ctx, taskRunID, result
+func (_m *Application) ResumeJobV2(ctx context.Context, taskRunID int64, result pipeline.Result) error {
+	ret := _m.Called(ctx, taskRunID, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.Result) error); ok {
+		r0 = rf(ctx, taskRunID, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddServiceAgreement provides a mock function with given fields: _a0
 func (_m *Application) AddServiceAgreement(_a0 *models.ServiceAgreement) error {
 	ret := _m.Called(_a0)
@@ -173,6 +192,38 @@ func (_m *Application) DeleteJobV2(ctx context.Context, jobID int32) error {
 	return r0
 }
 
+// GetEthConfirmer provides a mock function with given fields:
+func (_m *Application) GetEthConfirmer() bulletprooftxmanager.EthConfirmer {
+	ret := _m.Called()
+
+	var r0 bulletprooftxmanager.EthConfirmer
+	if rf, ok := ret.Get(0).(func() bulletprooftxmanager.EthConfirmer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bulletprooftxmanager.EthConfirmer)
+		}
+	}
+
+	return r0
+}
+
+// GetGasEstimator provides a mock function with given fields:
+func (_m *Application) GetGasEstimator() gas.Estimator {
+	ret := _m.Called()
+
+	var r0 gas.Estimator
+	if rf, ok := ret.Get(0).(func() gas.Estimator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(gas.Estimator)
+		}
+	}
+
+	return r0
+}
+
 // GetStatsPusher provides a mock function with given fields:
 func (_m *Application) GetStatsPusher() synchronization.StatsPusher {
 	ret := _m.Called()
@@ -303,6 +354,29 @@ func (_m *Application) Stop() error {
 	return r0
 }
 
+// SubscribeRunEventsV2 provides a mock function with given fields: jobID
+func (_m *Application) SubscribeRunEventsV2(jobID int32) (pipeline.RunEventSubscription, error) {
+	ret := _m.Called(jobID)
+
+	var r0 pipeline.RunEventSubscription
+	if rf, ok := ret.Get(0).(func(int32) pipeline.RunEventSubscription); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pipeline.RunEventSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WakeSessionReaper provides a mock function with given fields:
 func (_m *Application) WakeSessionReaper() {
 	_m.Called()
@@ -146,6 +146,29 @@ func (_m *FluxAggregator) SubscribeToLogs(listener eth.LogListener) (bool, eth.U
 	return r0, r1
 }
 
+// SubscribeToLogsWithTopics provides a mock function with given fields: topics, listener
+func (_m *FluxAggregator) SubscribeToLogsWithTopics(topics [][]common.Hash, listener eth.LogListener) (bool, eth.UnsubscribeFunc) {
+	ret := _m.Called(topics, listener)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func([][]common.Hash, eth.LogListener) bool); ok {
+		r0 = rf(topics, listener)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 eth.UnsubscribeFunc
+	if rf, ok := ret.Get(1).(func([][]common.Hash, eth.LogListener) eth.UnsubscribeFunc); ok {
+		r1 = rf(topics, listener)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(eth.UnsubscribeFunc)
+		}
+	}
+
+	return r0, r1
+}
+
 // UnpackLog provides a mock function with given fields: out, event, log
 func (_m *FluxAggregator) UnpackLog(out interface{}, event string, log types.Log) error {
 	ret := _m.Called(out, event, log)
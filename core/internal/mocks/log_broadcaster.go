@@ -3,9 +3,15 @@
 package mocks
 
 import (
+	context "context"
+
 	common "github.com/ethereum/go-ethereum/common"
+
 	eth "github.com/smartcontractkit/chainlink/core/services/eth"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/smartcontractkit/chainlink/core/store/models"
 )
 
 // LogBroadcaster is an autogenerated mock type for the LogBroadcaster type
@@ -34,11 +40,35 @@ func (_m *LogBroadcaster) AwaitDependents() <-chan struct{} {
 	return r0
 }
 
+// Connect provides a mock function with given fields: head
+func (_m *LogBroadcaster) Connect(head *models.Head) error {
+	ret := _m.Called(head)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Head) error); ok {
+		r0 = rf(head)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DependentReady provides a mock function with given fields:
 func (_m *LogBroadcaster) DependentReady() {
 	_m.Called()
 }
 
+// Disconnect provides a mock function with given fields:
+func (_m *LogBroadcaster) Disconnect() {
+	_m.Called()
+}
+
+// OnNewLongestChain provides a mock function with given fields: ctx, head
+func (_m *LogBroadcaster) OnNewLongestChain(ctx context.Context, head models.Head) {
+	_m.Called(ctx, head)
+}
+
 // Register provides a mock function with given fields: address, listener
 func (_m *LogBroadcaster) Register(address common.Address, listener eth.LogListener) bool {
 	ret := _m.Called(address, listener)
@@ -53,6 +83,20 @@ func (_m *LogBroadcaster) Register(address common.Address, listener eth.LogListe
 	return r0
 }
 
+// RegisterWithTopics provides a mock function with given fields: address, topics, listener
+func (_m *LogBroadcaster) RegisterWithTopics(address common.Address, topics [][]common.Hash, listener eth.LogListener) bool {
+	ret := _m.Called(address, topics, listener)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Address, [][]common.Hash, eth.LogListener) bool); ok {
+		r0 = rf(address, topics, listener)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
 func (_m *LogBroadcaster) Start() error {
 	ret := _m.Called()
@@ -13,6 +13,8 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type (
@@ -71,6 +73,9 @@ func (bm *balanceMonitor) checkBalance(head *models.Head) {
 
 func (bm *balanceMonitor) updateBalance(ethBal assets.Eth, address gethCommon.Address) {
 	store.PromUpdateEthBalance(&ethBal, address)
+	if err := bm.store.UpdateKeyEthBalance(address, ethBal); err != nil {
+		logger.Errorw("BalanceMonitor: error saving balance", "address", address.Hex(), "error", err)
+	}
 
 	bm.ethBalancesMtx.Lock()
 	oldBal := bm.ethBalances[address]
@@ -110,38 +115,45 @@ func (w *worker) Work() {
 		logger.Error("BalanceMonitor: error getting keys", err)
 	}
 
-	var wg sync.WaitGroup
-
-	wg.Add(len(keys))
-	for _, key := range keys {
-		go func(k models.Key) {
-			w.checkAccountBalance(k)
-			wg.Done()
-		}(key)
-	}
-	wg.Wait()
+	w.checkAccountBalances(keys)
+	w.bm.topUpKeys(keys)
 }
 
 // Approximately ETH block time
 const ethFetchTimeout = 15 * time.Second
 
-func (w *worker) checkAccountBalance(k models.Key) {
+// checkAccountBalances fetches the balances of all of the keys in a single
+// batch request
+func (w *worker) checkAccountBalances(keys []models.Key) {
+	if len(keys) == 0 {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), ethFetchTimeout)
 	defer cancel()
 
-	bal, err := w.bm.store.EthClient.BalanceAt(ctx, k.Address.Address(), nil)
-	if err != nil {
-		logger.Errorw(fmt.Sprintf("BalanceMonitor: error getting balance for key %s", k.Address.Hex()),
-			"error", err,
-			"address", k.Address,
-		)
-	} else if bal == nil {
-		logger.Errorw(fmt.Sprintf("BalanceMonitor: error getting balance for key %s: invariant violation, bal may not be nil", k.Address.Hex()),
-			"error", err,
-			"address", k.Address,
-		)
-	} else {
-		ethBal := assets.Eth(*bal)
+	reqs := make([]rpc.BatchElem, len(keys))
+	for i, k := range keys {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{k.Address.Address(), "latest"},
+			Result: new(hexutil.Big),
+		}
+	}
+	if err := w.bm.store.EthClient.BatchCallContext(ctx, reqs); err != nil {
+		logger.Errorw("BalanceMonitor: error getting balances", "error", err)
+		return
+	}
+
+	for i, k := range keys {
+		if err := reqs[i].Error; err != nil {
+			logger.Errorw(fmt.Sprintf("BalanceMonitor: error getting balance for key %s", k.Address.Hex()),
+				"error", err,
+				"address", k.Address,
+			)
+			continue
+		}
+		ethBal := assets.Eth(*reqs[i].Result.(*hexutil.Big))
 		w.bm.updateBalance(ethBal, k.Address.Address())
 	}
 }
@@ -8,28 +8,72 @@ import (
 	"time"
 
 	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 
+	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
-	// "github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/pkg/errors"
 )
 
-var nilBigInt *big.Int
+// balanceResponse is the eth node's answer to a request for the balance of
+// the given address
+type balanceResponse struct {
+	address gethCommon.Address
+	balance *big.Int
+	err     error
+}
+
+// mockBalances expects a single batched request for the balances of exactly
+// the given addresses
+func mockBalances(rpcClient *mocks.RPCClient, responses []balanceResponse) {
+	indexOf := func(b []rpc.BatchElem, address gethCommon.Address) int {
+		for i, req := range b {
+			if req.Method == "eth_getBalance" && req.Args[0] == address {
+				return i
+			}
+		}
+		return -1
+	}
+	rpcClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		if len(b) != len(responses) {
+			return false
+		}
+		for _, r := range responses {
+			if indexOf(b, r.address) < 0 {
+				return false
+			}
+		}
+		return true
+	})).Run(func(args mock.Arguments) {
+		b := args.Get(1).([]rpc.BatchElem)
+		for _, r := range responses {
+			i := indexOf(b, r.address)
+			if r.balance != nil {
+				*b[i].Result.(*hexutil.Big) = hexutil.Big(*r.balance)
+			}
+			b[i].Error = r.err
+		}
+	}).Return(nil).Once()
+}
 
 func TestBalanceMonitor_Connect(t *testing.T) {
 	t.Run("updates balance from nil for multiple keys", func(t *testing.T) {
 		store, cleanup := cltest.NewStore(t)
 		defer cleanup()
 
-		gethClient := new(mocks.GethClient)
+		rpcClient := new(mocks.RPCClient)
 		cltest.MockEthOnStore(t, store,
-			eth.NewClientWith(nil, gethClient),
+			eth.NewClientWith(rpcClient, nil),
 		)
 
 		k0 := cltest.MustDefaultKey(t, store)
@@ -45,8 +89,10 @@ func TestBalanceMonitor_Connect(t *testing.T) {
 		assert.Nil(t, bm.GetEthBalance(k0Addr))
 		assert.Nil(t, bm.GetEthBalance(k1Addr))
 
-		gethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(k0bal, nil)
-		gethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Once().Return(k1bal, nil)
+		mockBalances(rpcClient, []balanceResponse{
+			{k0Addr, k0bal, nil},
+			{k1Addr, k1bal, nil},
+		})
 
 		head := cltest.Head(0)
 
@@ -60,16 +106,16 @@ func TestBalanceMonitor_Connect(t *testing.T) {
 			return bm.GetEthBalance(k1Addr).ToInt()
 		}).Should(gomega.Equal(k1bal))
 
-		gethClient.AssertExpectations(t)
+		rpcClient.AssertExpectations(t)
 	})
 
 	t.Run("handles nil head", func(t *testing.T) {
 		store, cleanup := cltest.NewStore(t)
 		defer cleanup()
 
-		gethClient := new(mocks.GethClient)
+		rpcClient := new(mocks.RPCClient)
 		cltest.MockEthOnStore(t, store,
-			eth.NewClientWith(nil, gethClient),
+			eth.NewClientWith(rpcClient, nil),
 		)
 
 		k0 := cltest.MustDefaultKey(t, store)
@@ -79,7 +125,9 @@ func TestBalanceMonitor_Connect(t *testing.T) {
 		defer bm.Stop()
 		k0bal := big.NewInt(42)
 
-		gethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(k0bal, nil)
+		mockBalances(rpcClient, []balanceResponse{
+			{k0Addr, k0bal, nil},
+		})
 
 		// Do the thing
 		bm.Connect(nil)
@@ -88,16 +136,16 @@ func TestBalanceMonitor_Connect(t *testing.T) {
 			return bm.GetEthBalance(k0Addr).ToInt()
 		}).Should(gomega.Equal(k0bal))
 
-		gethClient.AssertExpectations(t)
+		rpcClient.AssertExpectations(t)
 	})
 
 	t.Run("recovers on error", func(t *testing.T) {
 		store, cleanup := cltest.NewStore(t)
 		defer cleanup()
 
-		gethClient := new(mocks.GethClient)
+		rpcClient := new(mocks.RPCClient)
 		cltest.MockEthOnStore(t, store,
-			eth.NewClientWith(nil, gethClient),
+			eth.NewClientWith(rpcClient, nil),
 		)
 
 		k0 := cltest.MustDefaultKey(t, store)
@@ -106,9 +154,9 @@ func TestBalanceMonitor_Connect(t *testing.T) {
 		bm := services.NewBalanceMonitor(store)
 		defer bm.Stop()
 
-		gethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).
-			Once().
-			Return(nil, errors.New("a little easter egg for the 4chan link marines error"))
+		mockBalances(rpcClient, []balanceResponse{
+			{k0Addr, nil, errors.New("a little easter egg for the 4chan link marines error")},
+		})
 
 		// Do the thing
 		bm.Connect(nil)
@@ -117,7 +165,7 @@ func TestBalanceMonitor_Connect(t *testing.T) {
 			return bm.GetEthBalance(k0Addr).ToInt()
 		}).Should(gomega.BeNil())
 
-		gethClient.AssertExpectations(t)
+		rpcClient.AssertExpectations(t)
 	})
 }
 
@@ -126,9 +174,9 @@ func TestBalanceMonitor_OnNewLongestChain_UpdatesBalance(t *testing.T) {
 		store, cleanup := cltest.NewStore(t)
 		defer cleanup()
 
-		gethClient := new(mocks.GethClient)
+		rpcClient := new(mocks.RPCClient)
 		cltest.MockEthOnStore(t, store,
-			eth.NewClientWith(nil, gethClient),
+			eth.NewClientWith(rpcClient, nil),
 		)
 
 		k0 := cltest.MustDefaultKey(t, store)
@@ -145,8 +193,10 @@ func TestBalanceMonitor_OnNewLongestChain_UpdatesBalance(t *testing.T) {
 
 		head := cltest.Head(0)
 
-		gethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(k0bal, nil)
-		gethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Once().Return(k1bal, nil)
+		mockBalances(rpcClient, []balanceResponse{
+			{k0Addr, k0bal, nil},
+			{k1Addr, k1bal, nil},
+		})
 
 		// Do the thing
 		bm.OnNewLongestChain(context.TODO(), *head)
@@ -164,8 +214,10 @@ func TestBalanceMonitor_OnNewLongestChain_UpdatesBalance(t *testing.T) {
 
 		head = cltest.Head(1)
 
-		gethClient.On("BalanceAt", mock.Anything, k0Addr, nilBigInt).Once().Return(k0bal2, nil)
-		gethClient.On("BalanceAt", mock.Anything, k1Addr, nilBigInt).Once().Return(k1bal2, nil)
+		mockBalances(rpcClient, []balanceResponse{
+			{k0Addr, k0bal2, nil},
+			{k1Addr, k1bal2, nil},
+		})
 
 		bm.OnNewLongestChain(context.TODO(), *head)
 
@@ -176,7 +228,7 @@ func TestBalanceMonitor_OnNewLongestChain_UpdatesBalance(t *testing.T) {
 			return bm.GetEthBalance(k1Addr).ToInt()
 		}).Should(gomega.Equal(k1bal2))
 
-		gethClient.AssertExpectations(t)
+		rpcClient.AssertExpectations(t)
 	})
 }
 
@@ -184,9 +236,9 @@ func TestBalanceMonitor_FewerRPCCallsWhenBehind(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
 
-	gethClient := new(mocks.GethClient)
+	rpcClient := new(mocks.RPCClient)
 	cltest.MockEthOnStore(t, store,
-		eth.NewClientWith(nil, gethClient),
+		eth.NewClientWith(rpcClient, nil),
 	)
 
 	bm := services.NewBalanceMonitor(store)
@@ -195,18 +247,18 @@ func TestBalanceMonitor_FewerRPCCallsWhenBehind(t *testing.T) {
 
 	// Only expect this twice, even though 10 heads will come in
 	mockUnblocker := make(chan time.Time)
-	gethClient.On("BalanceAt", mock.Anything, mock.Anything, mock.Anything).
+	rpcClient.On("BatchCallContext", mock.Anything, mock.Anything).
 		WaitUntil(mockUnblocker).
 		Once().
-		Return(big.NewInt(42), nil)
+		Return(nil)
 	// This second call is Maybe because the SleeperTask may not have started
 	// before we call `OnNewLongestChain` 10 times, in which case it's only
 	// executed once
 	var callCount int32
-	gethClient.On("BalanceAt", mock.Anything, mock.Anything, mock.Anything).
+	rpcClient.On("BatchCallContext", mock.Anything, mock.Anything).
 		Run(func(mock.Arguments) { atomic.AddInt32(&callCount, 1) }).
 		Maybe().
-		Return(big.NewInt(42), nil)
+		Return(nil)
 
 	// Do the thing multiple times
 	for i := 0; i < 10; i++ {
@@ -214,13 +266,88 @@ func TestBalanceMonitor_FewerRPCCallsWhenBehind(t *testing.T) {
 	}
 
 	// Unblock the first mock
-	cltest.CallbackOrTimeout(t, "FewerRPCCallsWhenBehind unblock BalanceAt", func() {
+	cltest.CallbackOrTimeout(t, "FewerRPCCallsWhenBehind unblock BatchCallContext", func() {
 		mockUnblocker <- time.Time{}
 	})
 
 	bm.Stop()
 
-	// Make sure the BalanceAt mock wasn't called more than once
+	// Make sure the BatchCallContext mock wasn't called more than once
 	assert.LessOrEqual(t, atomic.LoadInt32(&callCount), int32(1))
-	gethClient.AssertExpectations(t)
+	rpcClient.AssertExpectations(t)
+}
+
+func TestBalanceMonitor_TopUpKeys(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	rpcClient := new(mocks.RPCClient)
+	cltest.MockEthOnStore(t, store,
+		eth.NewClientWith(rpcClient, nil),
+	)
+
+	treasury := cltest.MustDefaultKey(t, store)
+	treasuryAddr := treasury.Address.Address()
+	k1 := cltest.MustInsertRandomKey(t, store)
+	k1Addr := k1.Address.Address()
+	k2 := cltest.MustInsertRandomKey(t, store)
+	k2Addr := k2.Address.Address()
+
+	store.Config.Set("ENABLE_BULLETPROOF_TX_MANAGER", true)
+	store.Config.Set("ETH_TREASURY_ADDRESS", treasuryAddr.Hex())
+	store.Config.Set("ETH_TREASURY_DAILY_LIMIT_WEI", 1000)
+	store.Config.Set("ETH_KEY_LOW_WATERMARK_WEI", 100)
+	store.Config.Set("ETH_KEY_HIGH_WATERMARK_WEI", 1000)
+	require.NoError(t, store.SetKeyWatermarks(k2Addr, assets.NewEth(600), assets.NewEth(700)))
+	k2, err := store.KeyByAddress(k2Addr)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(600), k2.EthLowWatermark.ToInt())
+	assert.Equal(t, big.NewInt(700), k2.EthHighWatermark.ToInt())
+
+	bm := services.NewBalanceMonitor(store)
+	defer bm.Stop()
+
+	for i := 0; i < 2; i++ {
+		mockBalances(rpcClient, []balanceResponse{
+			{treasuryAddr, big.NewInt(1000000), nil},
+			{k1Addr, big.NewInt(50), nil},
+			{k2Addr, big.NewInt(500), nil},
+		})
+		bm.OnNewLongestChain(context.TODO(), *cltest.Head(i))
+		// Wait for the balances to be fetched before the next head
+		gomega.NewGomegaWithT(t).Eventually(func() bool {
+			return rpcClient.AssertExpectations(new(testing.T))
+		}).Should(gomega.BeTrue())
+	}
+
+	// k1 is topped up to the configured high watermark only once, since the
+	// first top up is still pending. k2 has its own watermarks, but topping it
+	// up would exceed the daily limit.
+	var transfers []models.TreasuryTransfer
+	gomega.NewGomegaWithT(t).Eventually(func() []models.TreasuryTransfer {
+		require.NoError(t, store.DB.Order("id").Find(&transfers).Error)
+		return transfers
+	}).Should(gomega.HaveLen(1))
+	gomega.NewGomegaWithT(t).Consistently(func() []models.TreasuryTransfer {
+		require.NoError(t, store.DB.Order("id").Find(&transfers).Error)
+		return transfers
+	}).Should(gomega.HaveLen(1))
+
+	transfer := transfers[0]
+	assert.Equal(t, treasuryAddr, transfer.FromAddress)
+	assert.Equal(t, k1Addr, transfer.ToAddress)
+	assert.Equal(t, big.NewInt(950), transfer.Amount.ToInt())
+	assert.Equal(t, big.NewInt(50), transfer.ToBalance.ToInt())
+	require.NotNil(t, transfer.EthTxID)
+
+	etx, err := store.FindEthTxWithAttempts(*transfer.EthTxID)
+	require.NoError(t, err)
+	assert.Equal(t, treasuryAddr, etx.FromAddress)
+	assert.Equal(t, k1Addr, etx.ToAddress)
+	assert.Equal(t, big.NewInt(950), etx.Value.ToInt())
+	assert.Equal(t, models.EthTxUnstarted, etx.State)
+
+	spent, err := store.TreasurySpentSince(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(950), spent.ToInt())
 }
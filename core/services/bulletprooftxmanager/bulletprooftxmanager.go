@@ -3,20 +3,26 @@ package bulletprooftxmanager
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	gethAccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 )
@@ -32,30 +38,160 @@ const (
 
 // SendEther creates a transaction that transfers the given value of ether
 func SendEther(s *strpkg.Store, from, to gethCommon.Address, value assets.Eth) (etx models.EthTx, err error) {
+	etx, err = NewSendEtherTx(s.Config, from, to, value)
+	if err != nil {
+		return etx, err
+	}
+	err = s.DB.Create(&etx).Error
+	return etx, err
+}
+
+// NewSendEtherTx returns an unsaved transaction that transfers the given
+// value of ether, for callers that must save it along with other records
+func NewSendEtherTx(config orm.ConfigReader, from, to gethCommon.Address, value assets.Eth) (models.EthTx, error) {
 	if to == utils.ZeroAddress {
-		return etx, errors.New("cannot send ether to zero address")
+		return models.EthTx{}, errors.New("cannot send ether to zero address")
 	}
-	etx = models.EthTx{
+	return models.EthTx{
 		FromAddress:    from,
 		ToAddress:      to,
 		EncodedPayload: []byte{},
 		Value:          value,
-		GasLimit:       s.Config.EthGasLimitDefault(),
+		GasLimit:       config.EthGasLimitDefault(),
 		State:          models.EthTxUnstarted,
+	}, nil
+}
+
+// fees are the prices per unit of gas offered by a transaction attempt.
+// Legacy attempts only set GasPrice, and EIP-1559 dynamic fee attempts only
+// set TipCap and FeeCap.
+type fees struct {
+	GasPrice *big.Int
+	TipCap   *big.Int
+	FeeCap   *big.Int
+}
+
+func (f fees) dynamic() bool {
+	return f.FeeCap != nil
+}
+
+func (f fees) String() string {
+	if f.dynamic() {
+		return fmt.Sprintf("tip cap %v wei and fee cap %v wei", f.TipCap, f.FeeCap)
 	}
-	err = s.DB.Create(&etx).Error
-	return etx, err
+	return fmt.Sprintf("gas price %v wei", f.GasPrice)
+}
+
+// ethTxConfig applies a transaction's own gas strategy on top of the node's
+// configuration
+type ethTxConfig struct {
+	orm.ConfigReader
+	strategy models.EthTxStrategy
+}
+
+func configForEthTx(config orm.ConfigReader, etx models.EthTx) ethTxConfig {
+	return ethTxConfig{ConfigReader: config, strategy: etx.EthTxStrategy}
+}
+
+func (c ethTxConfig) EthMaxGasPriceWei() *big.Int {
+	max := c.ConfigReader.EthMaxGasPriceWei()
+	if c.strategy.MaxGasPriceWei != nil && c.strategy.MaxGasPriceWei.ToInt().Cmp(max) < 0 {
+		return c.strategy.MaxGasPriceWei.ToInt()
+	}
+	return max
+}
+
+func (c ethTxConfig) EthGasBumpPercent() uint16 {
+	if c.strategy.GasBumpPercent != nil {
+		return *c.strategy.GasBumpPercent
+	}
+	return c.ConfigReader.EthGasBumpPercent()
+}
+
+func (c ethTxConfig) EthGasBumpThreshold() uint64 {
+	if c.strategy.GasBumpThreshold != nil {
+		return uint64(*c.strategy.GasBumpThreshold)
+	}
+	return c.ConfigReader.EthGasBumpThreshold()
+}
+
+// defaultFees returns the fees for the first attempt of a transaction, limited
+// to the transaction's max gas price
+func defaultFees(estimator gas.Estimator, config ethTxConfig) fees {
+	max := config.EthMaxGasPriceWei()
+	if config.EthEIP1559DynamicFees() {
+		tipCap, feeCap := estimator.EstimateDynamicFee()
+		if feeCap.Cmp(max) > 0 {
+			feeCap = max
+		}
+		if tipCap.Cmp(feeCap) > 0 {
+			tipCap = feeCap
+		}
+		return fees{TipCap: tipCap, FeeCap: feeCap}
+	}
+	gasPrice := estimator.EstimateGasPrice()
+	if gasPrice.Cmp(max) > 0 {
+		gasPrice = max
+	}
+	return fees{GasPrice: gasPrice}
+}
+
+// bumpFees returns higher fees that allow an attempt to replace the given
+// one.  The replacement has the same transaction type as the original.
+func bumpFees(config ethTxConfig, attempt models.EthTxAttempt) (fees, error) {
+	if attempt.TxType == models.DynamicFeeTxType {
+		tipCap, feeCap, err := BumpDynamicFee(config, attempt.GasTipCap.ToInt(), attempt.GasFeeCap.ToInt())
+		return fees{TipCap: tipCap, FeeCap: feeCap}, err
+	}
+	gasPrice, err := BumpGas(config, attempt.GasPrice.ToInt())
+	return fees{GasPrice: gasPrice}, err
 }
 
-func newAttempt(s *strpkg.Store, etx models.EthTx, gasPrice *big.Int) (models.EthTxAttempt, error) {
+// attemptFees returns the fees offered by an existing attempt
+func attemptFees(attempt models.EthTxAttempt) fees {
+	if attempt.TxType == models.DynamicFeeTxType {
+		return fees{TipCap: attempt.GasTipCap.ToInt(), FeeCap: attempt.GasFeeCap.ToInt()}
+	}
+	return fees{GasPrice: attempt.GasPrice.ToInt()}
+}
+
+func newAttempt(s *strpkg.Store, etx models.EthTx, f fees) (models.EthTxAttempt, error) {
 	attempt := models.EthTxAttempt{}
 	account, err := s.KeyStore.GetAccountByAddress(etx.FromAddress)
 	if err != nil {
 		return attempt, errors.Wrapf(err, "error getting account %s for transaction %v", etx.FromAddress.String(), etx.ID)
 	}
 
-	transaction := gethTypes.NewTransaction(uint64(*etx.Nonce), etx.ToAddress, etx.Value.ToInt(), etx.GasLimit, gasPrice, etx.EncodedPayload)
-	hash, signedTxBytes, err := signTx(s.KeyStore, account, transaction, s.Config.ChainID())
+	to, value, data := etx.ToAddress, etx.Value.ToInt(), etx.EncodedPayload
+	if etx.CancelledAt != nil {
+		// Replace the transaction with one that does nothing but use up the nonce
+		to, value, data = etx.FromAddress, big.NewInt(0), []byte{}
+		attempt.Cancellation = true
+	}
+
+	var hash gethCommon.Hash
+	var signedTxBytes []byte
+	if f.dynamic() {
+		transaction := models.DynamicFeeTx{
+			ChainID:   s.Config.ChainID(),
+			Nonce:     uint64(*etx.Nonce),
+			GasTipCap: f.TipCap,
+			GasFeeCap: f.FeeCap,
+			Gas:       etx.GasLimit,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		}
+		signedTxBytes, hash, err = s.KeyStore.SignDynamicFeeTx(account, transaction)
+		attempt.TxType = models.DynamicFeeTxType
+		attempt.GasPrice = *utils.NewBig(f.FeeCap)
+		attempt.GasTipCap = utils.NewBig(f.TipCap)
+		attempt.GasFeeCap = utils.NewBig(f.FeeCap)
+	} else {
+		transaction := gethTypes.NewTransaction(uint64(*etx.Nonce), to, value, etx.GasLimit, f.GasPrice, data)
+		hash, signedTxBytes, err = signTx(s.KeyStore, account, transaction, s.Config.ChainID())
+		attempt.GasPrice = *utils.NewBig(f.GasPrice)
+	}
 	if err != nil {
 		return attempt, errors.Wrapf(err, "error using account %s to sign transaction %v", etx.FromAddress.String(), etx.ID)
 	}
@@ -63,7 +199,6 @@ func newAttempt(s *strpkg.Store, etx models.EthTx, gasPrice *big.Int) (models.Et
 	attempt.State = models.EthTxAttemptInProgress
 	attempt.SignedRawTx = signedTxBytes
 	attempt.EthTxID = etx.ID
-	attempt.GasPrice = *utils.NewBig(gasPrice)
 	attempt.Hash = hash
 
 	return attempt, nil
@@ -85,6 +220,9 @@ func signTx(keyStore strpkg.KeyStoreInterface, account gethAccounts.Account, tx
 // send broadcasts the transaction to the ethereum network, writes any relevant
 // data onto the attempt and returns an error (or nil) depending on the status
 func sendTransaction(ctx context.Context, ethClient eth.Client, a models.EthTxAttempt) *eth.SendError {
+	if a.TxType == models.DynamicFeeTxType {
+		return sendDynamicFeeTransaction(ctx, ethClient, a)
+	}
 	signedTx, err := a.GetSignedTx()
 	if err != nil {
 		return eth.NewFatalSendError(err)
@@ -104,6 +242,103 @@ func sendTransaction(ctx context.Context, ethClient eth.Client, a models.EthTxAt
 	return eth.NewSendError(err)
 }
 
+// sendDynamicFeeTransaction broadcasts an EIP-1559 transaction as raw bytes,
+// since go-ethereum's SendTransaction cannot encode it.  Unlike
+// SendTransaction, this is not also sent to any secondary eth nodes.
+func sendDynamicFeeTransaction(ctx context.Context, ethClient eth.Client, a models.EthTxAttempt) *eth.SendError {
+	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	err := ethClient.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(a.SignedRawTx))
+	err = errors.WithStack(err)
+
+	logger.Debugw("BulletproofTxManager: Broadcasting dynamic fee transaction", "ethTxAttemptID", a.ID, "txHash", a.Hash, "tipCapWei", a.GasTipCap, "feeCapWei", a.GasFeeCap)
+	sendErr := eth.NewSendError(err)
+	if sendErr.IsTransactionAlreadyInMempool() {
+		logger.Debugw("transaction already in mempool", "txHash", a.Hash, "nodeErr", sendErr.Error())
+		return nil
+	}
+	return sendErr
+}
+
+// sendTransactions broadcasts the attempts as raw transactions in a single
+// batch request and returns the error for each, in the same order.  Like
+// sendDynamicFeeTransaction, these are not also sent to any secondary eth
+// nodes.
+func sendTransactions(ctx context.Context, ethClient eth.Client, attempts []models.EthTxAttempt) []*eth.SendError {
+	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	reqs := make([]rpc.BatchElem, len(attempts))
+	for i, a := range attempts {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_sendRawTransaction",
+			Args:   []interface{}{hexutil.Encode(a.SignedRawTx)},
+			Result: &gethCommon.Hash{},
+		}
+	}
+	batchErr := ethClient.BatchCallContext(ctx, reqs)
+
+	sendErrors := make([]*eth.SendError, len(attempts))
+	for i, a := range attempts {
+		err := batchErr
+		if err == nil {
+			err = reqs[i].Error
+		}
+		logger.Debugw("BulletproofTxManager: Broadcasting transaction in batch", "ethTxAttemptID", a.ID, "txHash", a.Hash, "gasPriceWei", a.GasPrice.String())
+		sendErr := eth.NewSendError(errors.WithStack(err))
+		if sendErr.IsTransactionAlreadyInMempool() {
+			logger.Debugw("transaction already in mempool", "txHash", a.Hash, "nodeErr", sendErr.Error())
+			sendErr = nil
+		}
+		sendErrors[i] = sendErr
+	}
+	return sendErrors
+}
+
+// simulateTransaction runs the transaction with eth_call against the pending
+// block. If it would revert, reverted is true and reason holds the decoded
+// revert reason, or the node's error message if it could not be decoded.
+// An error means the simulation itself could not be run.
+func simulateTransaction(ctx context.Context, ethClient eth.Client, etx models.EthTx) (reverted bool, reason string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	callArgs := map[string]interface{}{
+		"from":  etx.FromAddress,
+		"to":    etx.ToAddress,
+		"gas":   hexutil.Uint64(etx.GasLimit),
+		"value": (*hexutil.Big)(etx.Value.ToInt()),
+		"data":  hexutil.Bytes(etx.EncodedPayload),
+	}
+	var result hexutil.Bytes
+	err = ethClient.CallContext(ctx, &result, "eth_call", callArgs, "pending")
+	if err == nil {
+		return false, "", nil
+	}
+	if reason, ok := extractRevertReason(err); ok {
+		return true, reason, nil
+	}
+	return false, "", errors.Wrap(err, "simulateTransaction failed")
+}
+
+// extractRevertReason returns the revert reason of an eth_call error, if it
+// was caused by the call reverting. Geth returns the ABI encoded reason as
+// the error's data, while Parity returns it prefixed by "Reverted ".
+func extractRevertReason(err error) (string, bool) {
+	var data string
+	if de, ok := errors.Cause(err).(interface{ ErrorData() interface{} }); ok {
+		data, _ = de.ErrorData().(string)
+		data = strings.TrimPrefix(data, "Reverted ")
+	}
+	if data == "" && !strings.Contains(strings.ToLower(err.Error()), "revert") {
+		return "", false
+	}
+	if b, decodeErr := hexutil.Decode(data); decodeErr == nil {
+		if reason, unpackErr := abi.UnpackRevert(b); unpackErr == nil {
+			return reason, true
+		}
+	}
+	return errors.Cause(err).Error(), true
+}
+
 // sendEmptyTransaction sends a transaction with 0 Eth and an empty payload to the burn address
 // May be useful for clearing stuck nonces
 func sendEmptyTransaction(
@@ -141,6 +376,12 @@ func BumpGas(config orm.ConfigReader, originalGasPrice *big.Int) (*big.Int, erro
 	return strpkg.BumpGas(config, originalGasPrice)
 }
 
+// BumpDynamicFee returns a new tip cap and fee cap for a dynamic fee
+// transaction, each increased by the same rules as BumpGas
+func BumpDynamicFee(config orm.ConfigReader, originalTipCap, originalFeeCap *big.Int) (tipCap, feeCap *big.Int, err error) {
+	return strpkg.BumpDynamicFee(config, originalTipCap, originalFeeCap)
+}
+
 func saveReplacementInProgressAttempt(store *strpkg.Store, oldAttempt models.EthTxAttempt, replacementAttempt *models.EthTxAttempt) error {
 	if oldAttempt.State != models.EthTxAttemptInProgress || replacementAttempt.State != models.EthTxAttemptInProgress {
 		return errors.New("expected attempts to be in_progress")
@@ -118,6 +118,69 @@ func TestBulletproofTxManager_BumpGas_NoBumpError(t *testing.T) {
 	require.Contains(t, err.Error(), "bumped gas price of 40000000000 is equal to original gas price of 40000000000. ACTION REQUIRED: This is a configuration error, you must increase either ETH_GAS_BUMP_PERCENT or ETH_GAS_BUMP_WEI")
 }
 
+func TestBulletproofTxManager_BumpDynamicFee(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name           string
+		originalTipCap *big.Int
+		originalFeeCap *big.Int
+		tipCapDefault  *big.Int
+		expectedTipCap *big.Int
+		expectedFeeCap *big.Int
+	}{
+		{
+			name:           "bumps both",
+			originalTipCap: toBigInt("2e9"),    // 2 GWei
+			originalFeeCap: toBigInt("3e10"),   // 30 GWei
+			tipCapDefault:  toBigInt("1e9"),    // 1 GWei
+			expectedTipCap: toBigInt("7e9"),    // 7 GWei
+			expectedFeeCap: toBigInt("3.6e10"), // 36 GWei
+		},
+		{
+			name:           "default tip cap is the baseline",
+			originalTipCap: toBigInt("2e9"),    // 2 GWei
+			originalFeeCap: toBigInt("3e10"),   // 30 GWei
+			tipCapDefault:  toBigInt("2e10"),   // 20 GWei
+			expectedTipCap: toBigInt("2.5e10"), // 25 GWei
+			expectedFeeCap: toBigInt("3.6e10"), // 36 GWei
+		},
+		{
+			name:           "tip cap is limited to fee cap",
+			originalTipCap: toBigInt("2e9"),    // 2 GWei
+			originalFeeCap: toBigInt("3e10"),   // 30 GWei
+			tipCapDefault:  toBigInt("5e10"),   // 50 GWei
+			expectedTipCap: toBigInt("3.6e10"), // 36 GWei
+			expectedFeeCap: toBigInt("3.6e10"), // 36 GWei
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			config := orm.NewConfig()
+			config.Set("ETH_GAS_PRICE_DEFAULT", toBigInt("2e10")) // 20 GWei
+			config.Set("ETH_GAS_TIP_CAP_DEFAULT", test.tipCapDefault)
+			config.Set("ETH_GAS_BUMP_PERCENT", 20)
+			config.Set("ETH_GAS_BUMP_WEI", toBigInt("5e9"))       // 5 GWei
+			config.Set("ETH_MAX_GAS_PRICE_WEI", toBigInt("5e11")) // 0.5 uEther
+			tipCap, feeCap, err := bulletprooftxmanager.BumpDynamicFee(config, test.originalTipCap, test.originalFeeCap)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedTipCap.String(), tipCap.String())
+			require.Equal(t, test.expectedFeeCap.String(), feeCap.String())
+		})
+	}
+}
+
+func TestBulletproofTxManager_BumpDynamicFee_HitsMaxError(t *testing.T) {
+	config := orm.NewConfig()
+	config.Set("ETH_GAS_BUMP_PERCENT", "50")
+	config.Set("ETH_GAS_PRICE_DEFAULT", toBigInt("2e10")) // 20 GWei
+	config.Set("ETH_GAS_BUMP_WEI", toBigInt("5e9"))       // 5 GWei
+	config.Set("ETH_MAX_GAS_PRICE_WEI", toBigInt("4e10")) // 40 Gwei
+
+	_, _, err := bulletprooftxmanager.BumpDynamicFee(config, toBigInt("1e9"), toBigInt("3e10"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bumped fee cap of 45000000000 would exceed configured max gas price of 40000000000 (original price was 30000000000)")
+}
+
 // Helpers
 
 // toBigInt is used to convert scientific notation string to a *big.Int
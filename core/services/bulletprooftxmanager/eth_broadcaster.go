@@ -10,6 +10,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -29,6 +30,8 @@ const (
 	databasePollInterval = 5 * time.Second
 )
 
+var errEthTxNoLongerUnstarted = errors.New("eth_tx is no longer unstarted")
+
 // EthBroadcaster monitors eth_txes for transactions that need to
 // be broadcast, assigns nonces and ensures that at least one eth node
 // somewhere has received the transaction successfully.
@@ -42,6 +45,10 @@ const (
 // - a monotonic series of increasing nonces for eth_txes that can all eventually be confirmed if you retry enough times
 // - transition of eth_txes out of unstarted into either fatal_error or unconfirmed
 // - existence of a saved eth_tx_attempt
+//
+// Up to ETH_NONCE_PIPELINE_DEPTH transactions from the same key are given
+// consecutive nonces and broadcast together, so a key is not limited to one
+// round trip to the eth node per transaction.
 type EthBroadcaster interface {
 	Start() error
 	Stop() error
@@ -55,6 +62,7 @@ type ethBroadcaster struct {
 	store     *store.Store
 	ethClient eth.Client
 	config    orm.ConfigReader
+	estimator gas.Estimator
 
 	ethTxInsertListener postgres.Subscription
 	eventBroadcaster    postgres.EventBroadcaster
@@ -69,10 +77,11 @@ type ethBroadcaster struct {
 }
 
 // NewEthBroadcaster returns a new concrete ethBroadcaster
-func NewEthBroadcaster(store *store.Store, config orm.ConfigReader, eventBroadcaster postgres.EventBroadcaster) EthBroadcaster {
+func NewEthBroadcaster(store *store.Store, config orm.ConfigReader, eventBroadcaster postgres.EventBroadcaster, estimator gas.Estimator) EthBroadcaster {
 	return &ethBroadcaster{
 		store:            store,
 		config:           config,
+		estimator:        estimator,
 		ethClient:        store.EthClient,
 		trigger:          make(chan struct{}, 1),
 		chStop:           make(chan struct{}),
@@ -206,66 +215,160 @@ func (eb *ethBroadcaster) processUnstartedEthTxs(fromAddress gethCommon.Address)
 		}
 	}()
 
-	if err := eb.handleAnyInProgressEthTx(fromAddress); err != nil {
+	if err := eb.handleAnyInProgressEthTxs(fromAddress); err != nil {
 		return errors.Wrap(err, "processUnstartedEthTxs failed")
 	}
 
 	for {
-		etx, err := eb.nextUnstartedTransactionWithNonce(fromAddress)
+		etxs, attempts, err := eb.claimUnstartedEthTxs(fromAddress)
 		if err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed")
 		}
-		if etx == nil {
+		if len(etxs) == 0 {
 			return nil
 		}
-		n++
-		a, err := newAttempt(eb.store, *etx, eb.config.EthGasPriceDefault())
-		if err != nil {
+		n += uint(len(etxs))
+		if err := eb.handleInProgressEthTxs(etxs, attempts, time.Now()); err != nil {
 			return errors.Wrap(err, "processUnstartedEthTxs failed")
 		}
-		if err := eb.saveInProgressTransaction(etx, &a); err != nil {
-			return errors.Wrap(err, "processUnstartedEthTxs failed")
+	}
+}
+
+// claimUnstartedEthTxs moves up to ETH_NONCE_PIPELINE_DEPTH of the next
+// unstarted transactions to in_progress, with consecutive nonces and an
+// attempt each. If it fails part way, the transactions claimed so far are
+// left in_progress and sent on the next run.
+func (eb *ethBroadcaster) claimUnstartedEthTxs(fromAddress gethCommon.Address) (etxs []models.EthTx, attempts []models.EthTxAttempt, err error) {
+	for uint(len(etxs)) < eb.config.EthNoncePipelineDepth() {
+		etx, err := eb.nextUnstartedTransactionWithNonce(fromAddress)
+		if err != nil {
+			return etxs, attempts, err
 		}
+		if etx == nil {
+			return etxs, attempts, nil
+		}
+		// keys.next_nonce is only incremented once a transaction is sent, so
+		// it does not count the ones claimed before this
+		nonce := *etx.Nonce + int64(len(etxs))
+		etx.Nonce = &nonce
+		if etx.Simulate {
+			reverted, err := eb.simulateUnstartedEthTx(etx)
+			if errors.Cause(err) == errEthTxNoLongerUnstarted {
+				logger.Infow("EthBroadcaster: transaction was cancelled before it could be sent", "ethTxID", etx.ID)
+				continue
+			} else if err != nil {
+				return etxs, attempts, err
+			}
+			if reverted {
+				continue
+			}
+		}
+		a, err := newAttempt(eb.store, *etx, defaultFees(eb.estimator, configForEthTx(eb.config, *etx)))
+		if err != nil {
+			return etxs, attempts, err
+		}
+		if err := eb.saveInProgressTransaction(etx, &a); errors.Cause(err) == errEthTxNoLongerUnstarted {
+			logger.Infow("EthBroadcaster: transaction was cancelled before it could be sent", "ethTxID", etx.ID)
+			continue
+		} else if err != nil {
+			return etxs, attempts, err
+		}
+		etxs = append(etxs, *etx)
+		attempts = append(attempts, a)
+	}
+	return etxs, attempts, nil
+}
 
-		if err := eb.handleInProgressEthTx(*etx, a, time.Now()); err != nil {
-			return errors.Wrap(err, "processUnstartedEthTxs failed")
+// handleInProgressEthTxs broadcasts in_progress transactions from the same
+// key, in nonce order. More than one is sent in a single batch request, and
+// the result of each is then handled in nonce order as if it had been sent on
+// its own. This stops at the first that cannot be handled, leaving it and the
+// rest in_progress to be sent again on the next run.
+func (eb *ethBroadcaster) handleInProgressEthTxs(etxs []models.EthTx, attempts []models.EthTxAttempt, initialBroadcastAt time.Time) error {
+	if len(etxs) == 1 {
+		return eb.handleInProgressEthTx(etxs[0], attempts[0], initialBroadcastAt)
+	}
+	sendErrors := sendTransactions(context.Background(), eb.ethClient, attempts)
+	for i := range etxs {
+		if err := eb.handleSendError(etxs[i], attempts[i], initialBroadcastAt, sendErrors[i]); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
-// handleInProgressEthTx checks if there is any transaction
+// simulateUnstartedEthTx runs the transaction with eth_call before its first
+// broadcast. If it would revert, the transaction is moved straight to
+// fatal_error with the revert reason and its nonce is left for the next one.
+// If the simulation fails for any other reason, such as the eth node being
+// unreachable or not supporting the call, the transaction is sent anyway so
+// that it cannot hold up the queue behind it.
+// The transaction is only marked if it is still unstarted, so that a
+// concurrent cancel by the operator is never overwritten.
+func (eb *ethBroadcaster) simulateUnstartedEthTx(etx *models.EthTx) (reverted bool, err error) {
+	if etx.State != models.EthTxUnstarted {
+		return false, errors.Errorf("can only simulate unstarted transactions, transaction is currently %s", etx.State)
+	}
+	reverted, reason, err := simulateTransaction(context.Background(), eb.ethClient, *etx)
+	if err != nil {
+		logger.Warnw("EthBroadcaster: could not simulate transaction, sending it anyway", "ethTxID", etx.ID, "err", err)
+		return false, nil
+	}
+	if !reverted {
+		return false, nil
+	}
+	logger.Errorw("EthBroadcaster: transaction reverted in simulation, it will not be sent", "ethTxID", etx.ID, "reason", reason)
+	errStr := fmt.Sprintf("transaction reverted in simulation: %s", reason)
+	res := eb.store.DB.Exec(`UPDATE eth_txes SET state = 'fatal_error', error = ?, nonce = NULL WHERE id = ? AND state = 'unstarted'`, errStr, etx.ID)
+	if res.Error != nil {
+		return true, errors.Wrap(res.Error, "simulateUnstartedEthTx failed to save eth_tx")
+	}
+	if res.RowsAffected == 0 {
+		return true, errEthTxNoLongerUnstarted
+	}
+	etx.Error = &errStr
+	etx.Nonce = nil
+	etx.State = models.EthTxFatalError
+	return true, nil
+}
+
+// handleAnyInProgressEthTxs checks if there are any transactions
 // in_progress and if so, finishes the job
-func (eb *ethBroadcaster) handleAnyInProgressEthTx(fromAddress gethCommon.Address) error {
-	etx, err := getInProgressEthTx(eb.store, fromAddress)
+func (eb *ethBroadcaster) handleAnyInProgressEthTxs(fromAddress gethCommon.Address) error {
+	etxs, err := getInProgressEthTxs(eb.store, fromAddress)
 	if err != nil {
-		return errors.Wrap(err, "handleAnyInProgressEthTx failed")
+		return errors.Wrap(err, "handleAnyInProgressEthTxs failed")
 	}
-	if etx != nil {
-		if err := eb.handleInProgressEthTx(*etx, etx.EthTxAttempts[0], etx.CreatedAt); err != nil {
-			return errors.Wrap(err, "handleAnyInProgressEthTx failed")
+	for _, etx := range etxs {
+		if err := eb.handleInProgressEthTx(etx, etx.EthTxAttempts[0], etx.CreatedAt); err != nil {
+			return errors.Wrap(err, "handleAnyInProgressEthTxs failed")
 		}
 	}
 	return nil
 }
 
-// getInProgressEthTx returns either 0 or 1 transaction that was left in
-// an unfinished state because something went screwy the last time. Most likely
-// the node crashed in the middle of the ProcessUnstartedEthTxs loop.
-// It may or may not have been broadcast to an eth node.
-func getInProgressEthTx(store *store.Store, fromAddress gethCommon.Address) (*models.EthTx, error) {
-	etx := &models.EthTx{}
-	err := store.DB.Preload("EthTxAttempts").First(etx, "from_address = ? AND state = 'in_progress'", fromAddress.Bytes()).Error
-	if gorm.IsRecordNotFoundError(err) {
-		return nil, nil
+// getInProgressEthTxs returns, in nonce order, the transactions that were
+// left in an unfinished state because something went screwy the last time.
+// Most likely the node crashed in the middle of the ProcessUnstartedEthTxs
+// loop. There can be up to ETH_NONCE_PIPELINE_DEPTH of them, and each may or
+// may not have been broadcast to an eth node.
+func getInProgressEthTxs(store *store.Store, fromAddress gethCommon.Address) ([]models.EthTx, error) {
+	var etxs []models.EthTx
+	err := store.DB.Preload("EthTxAttempts").Order("nonce ASC").Find(&etxs, "from_address = ? AND state = 'in_progress'", fromAddress.Bytes()).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "getInProgressEthTxs failed")
 	}
-	if len(etx.EthTxAttempts) != 1 || etx.EthTxAttempts[0].State != models.EthTxAttemptInProgress {
-		return nil, errors.Errorf("invariant violation: expected in_progress transaction %v to have exactly one unsent attempt. "+
-			"Your database is in an inconsistent state and this node will not function correctly until the problem is resolved", etx.ID)
+	for _, etx := range etxs {
+		if len(etx.EthTxAttempts) != 1 || etx.EthTxAttempts[0].State != models.EthTxAttemptInProgress {
+			return nil, errors.Errorf("invariant violation: expected in_progress transaction %v to have exactly one unsent attempt. "+
+				"Your database is in an inconsistent state and this node will not function correctly until the problem is resolved", etx.ID)
+		}
 	}
-	return etx, errors.Wrap(err, "getInProgressEthTx failed")
+	return etxs, nil
 }
 
-// There can be at most one in_progress transaction per address.
+// There can be more than one in_progress transaction per address, see
+// ETH_NONCE_PIPELINE_DEPTH, and they must be handled in nonce order.
 // Here we complete the job that we didn't finish last time.
 func (eb *ethBroadcaster) handleInProgressEthTx(etx models.EthTx, attempt models.EthTxAttempt, initialBroadcastAt time.Time) error {
 	if etx.State != models.EthTxInProgress {
@@ -276,7 +379,20 @@ func (eb *ethBroadcaster) handleInProgressEthTx(etx models.EthTx, attempt models
 	defer cancel()
 	sendError := sendTransaction(ctx, eb.ethClient, attempt)
 
+	return eb.handleSendError(etx, attempt, initialBroadcastAt, sendError)
+}
+
+// handleSendError moves an in_progress transaction on according to the eth
+// node's response to its attempt
+func (eb *ethBroadcaster) handleSendError(etx models.EthTx, attempt models.EthTxAttempt, initialBroadcastAt time.Time, sendError *eth.SendError) error {
 	if sendError.Fatal() {
+		later, err := hasLaterInProgressEthTx(eb.store.DB, etx)
+		if err != nil {
+			return errors.Wrap(err, "handleSendError failed")
+		}
+		if later {
+			return eb.replaceRejectedEthTx(etx, attempt, initialBroadcastAt, sendError)
+		}
 		etx.Error = sendError.StrPtr()
 		// Attempt is thrown away in this case; we don't need it since it never got accepted by a node
 		return saveFatallyErroredTransaction(eb.store, &etx)
@@ -345,6 +461,38 @@ func (eb *ethBroadcaster) handleInProgressEthTx(etx models.EthTx, attempt models
 	return saveUnconfirmed(eb.store, &etx, attempt)
 }
 
+// hasLaterInProgressEthTx reports whether another in_progress transaction
+// from the same key has a higher nonce, and so may already have been sent
+func hasLaterInProgressEthTx(db *gorm.DB, etx models.EthTx) (bool, error) {
+	var count int
+	err := db.Model(&models.EthTx{}).
+		Where("from_address = ? AND state = 'in_progress' AND nonce > ?", etx.FromAddress, *etx.Nonce).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// replaceRejectedEthTx handles a transaction that the eth node will never
+// accept, but whose nonce cannot be given back because transactions with
+// later nonces may already have been sent, and would otherwise never be
+// mined. Instead, the transaction is cancelled and its nonce is used up by an
+// empty transaction to self, like a transaction that was not mined in time.
+func (eb *ethBroadcaster) replaceRejectedEthTx(etx models.EthTx, attempt models.EthTxAttempt, initialBroadcastAt time.Time, sendError *eth.SendError) error {
+	if attempt.Cancellation {
+		return errors.Wrapf(sendError, "empty replacement of transaction %v was rejected", etx.ID)
+	}
+	logger.Errorw("EthBroadcaster: fatal error sending transaction that is followed by later nonces, replacing it with an empty transaction", "ethTxID", etx.ID, "nonce", *etx.Nonce, "error", sendError.Error())
+	now := time.Now()
+	etx.CancelledAt = &now
+	replacementAttempt, err := newAttempt(eb.store, etx, attemptFees(attempt))
+	if err != nil {
+		return errors.Wrap(err, "replaceRejectedEthTx failed")
+	}
+	if err := saveReplacementInProgressAttempt(eb.store, attempt, &replacementAttempt); err != nil {
+		return errors.Wrap(err, "replaceRejectedEthTx failed")
+	}
+	return eb.handleInProgressEthTx(etx, replacementAttempt, initialBroadcastAt)
+}
+
 // Finds next transaction in the queue, assigns a nonce, and moves it to "in_progress" state ready for broadcast.
 // Returns nil if no transactions are in queue
 func (eb *ethBroadcaster) nextUnstartedTransactionWithNonce(fromAddress gethCommon.Address) (*models.EthTx, error) {
@@ -374,6 +522,14 @@ func (eb *ethBroadcaster) saveInProgressTransaction(etx *models.EthTx, attempt *
 	}
 	etx.State = models.EthTxInProgress
 	return eb.store.Transaction(func(tx *gorm.DB) error {
+		// The node operator may have cancelled the transaction since we loaded it
+		var state models.EthTxState
+		if err := tx.Raw(`SELECT state FROM eth_txes WHERE id = ? FOR UPDATE`, etx.ID).Row().Scan(&state); err != nil {
+			return errors.Wrap(err, "saveInProgressTransaction failed to lock eth_tx")
+		}
+		if state != models.EthTxUnstarted {
+			return errEthTxNoLongerUnstarted
+		}
 		if err := tx.Create(attempt).Error; err != nil {
 			return errors.Wrap(err, "saveInProgressTransaction failed to create eth_tx_attempt")
 		}
@@ -385,7 +541,7 @@ func (eb *ethBroadcaster) saveInProgressTransaction(etx *models.EthTx, attempt *
 func findNextUnstartedTransactionFromAddress(db *gorm.DB, etx *models.EthTx, fromAddress gethCommon.Address) error {
 	return db.
 		Where("from_address = ? AND state = 'unstarted'", fromAddress).
-		Order("value ASC, created_at ASC, id ASC").
+		Order("priority DESC, value ASC, created_at ASC, id ASC").
 		First(etx).
 		Error
 }
@@ -398,6 +554,12 @@ func saveUnconfirmed(store *store.Store, etx *models.EthTx, attempt models.EthTx
 		return errors.New("attempt must be in in_progress state")
 	}
 	logger.Debugw("EthBroadcaster: successfully broadcast transaction", "ethTxID", etx.ID, "txHash", attempt.Hash.Hex())
+	if attempt.Cancellation && etx.CancelledAt == nil {
+		// The empty replacement of a rejected transaction was saved, but the
+		// node stopped before sending it
+		now := time.Now()
+		etx.CancelledAt = &now
+	}
 	etx.State = models.EthTxUnconfirmed
 	attempt.State = models.EthTxAttemptBroadcast
 	return store.Transaction(func(tx *gorm.DB) error {
@@ -420,18 +582,19 @@ func saveUnconfirmed(store *store.Store, etx *models.EthTx, attempt models.EthTx
 }
 
 func (eb *ethBroadcaster) tryAgainWithHigherGasPrice(sendError *eth.SendError, etx models.EthTx, attempt models.EthTxAttempt, initialBroadcastAt time.Time) error {
-	bumpedGasPrice, err := BumpGas(eb.config, attempt.GasPrice.ToInt())
+	config := configForEthTx(eb.config, etx)
+	bumpedFees, err := bumpFees(config, attempt)
 	if err != nil {
 		return errors.Wrap(err, "tryAgainWithHigherGasPrice failed")
 	}
-	logger.Errorw(fmt.Sprintf("default gas price %v wei was rejected by the eth node for being too low. "+
+	logger.Errorw(fmt.Sprintf("default %s was rejected by the eth node for being too low. "+
 		"Eth node returned: '%s'. "+
-		"Bumping to %v wei and retrying. ACTION REQUIRED: This is a configuration error. "+
-		"Consider increasing ETH_GAS_PRICE_DEFAULT", eb.config.EthGasPriceDefault(), sendError.Error(), bumpedGasPrice), "err", err)
-	if bumpedGasPrice.Cmp(attempt.GasPrice.ToInt()) == 0 && bumpedGasPrice.Cmp(eb.config.EthMaxGasPriceWei()) == 0 {
+		"Bumping to %s and retrying. ACTION REQUIRED: This is a configuration error. "+
+		"Consider increasing ETH_GAS_PRICE_DEFAULT", defaultFees(eb.estimator, config), sendError.Error(), bumpedFees), "err", err)
+	if bumpedFees.GasPrice != nil && bumpedFees.GasPrice.Cmp(attempt.GasPrice.ToInt()) == 0 && bumpedFees.GasPrice.Cmp(config.EthMaxGasPriceWei()) == 0 {
 		return errors.Errorf("Hit gas price bump ceiling, will not bump further. This is a terminal error")
 	}
-	replacementAttempt, err := newAttempt(eb.store, etx, bumpedGasPrice)
+	replacementAttempt, err := newAttempt(eb.store, etx, bumpedFees)
 	if err != nil {
 		return errors.Wrap(err, "tryAgainWithHigherGasPrice failed")
 	}
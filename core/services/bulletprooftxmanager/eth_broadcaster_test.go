@@ -1,6 +1,7 @@
 package bulletprooftxmanager_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
 
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
@@ -23,9 +25,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	gethAccounts "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
@@ -244,6 +250,436 @@ func TestEthBroadcaster_ProcessUnstartedEthTxs_Success(t *testing.T) {
 	})
 }
 
+func TestEthBroadcaster_ProcessUnstartedEthTxs_DynamicFees(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("ETH_EIP1559_DYNAMIC_FEES", true)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 2000000000)
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+
+	etx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411"),
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(142),
+		GasLimit:       242,
+		State:          models.EthTxUnstarted,
+	}
+	require.NoError(t, store.DB.Save(&etx).Error)
+
+	var rawTx string
+	ethClient.On("CallContext", mock.Anything, nil, "eth_sendRawTransaction", mock.Anything).
+		Run(func(args mock.Arguments) { rawTx = args.Get(3).(string) }).
+		Return(nil).Once()
+
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	etx, err = store.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+	require.Len(t, etx.EthTxAttempts, 1)
+
+	attempt := etx.EthTxAttempts[0]
+	assert.Equal(t, models.DynamicFeeTxType, attempt.TxType)
+	assert.Equal(t, "2000000000", attempt.GasTipCap.String())
+	// Until the block history estimator has estimated a fee cap, the default gas price is used
+	assert.Equal(t, config.EthGasPriceDefault().String(), attempt.GasFeeCap.String())
+	assert.Equal(t, attempt.GasFeeCap.String(), attempt.GasPrice.String())
+	assert.Equal(t, hexutil.Encode(attempt.SignedRawTx), rawTx)
+	assert.Equal(t, byte(models.DynamicFeeTxType), attempt.SignedRawTx[0])
+	assert.Equal(t, models.EthTxAttemptBroadcast, attempt.State)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_UsesGasEstimator(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	estimator := new(mocks.Estimator)
+	estimator.On("EstimateGasPrice").Return(big.NewInt(42000000000))
+
+	eventBroadcaster := postgres.NewEventBroadcaster(config.DatabaseURL(), 0, 0)
+	eventBroadcaster.Start()
+	defer eventBroadcaster.Stop()
+	eb := bulletprooftxmanager.NewEthBroadcaster(store, config, eventBroadcaster, estimator)
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+
+	etx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411"),
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(142),
+		GasLimit:       242,
+		State:          models.EthTxUnstarted,
+	}
+	require.NoError(t, store.DB.Save(&etx).Error)
+
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.GasPrice().Cmp(big.NewInt(42000000000)) == 0
+	})).Return(nil).Once()
+
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	etx, err = store.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	require.Len(t, etx.EthTxAttempts, 1)
+	assert.Equal(t, "42000000000", etx.EthTxAttempts[0].GasPrice.String())
+
+	ethClient.AssertExpectations(t)
+	estimator.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_GasStrategy(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("ETH_GAS_PRICE_DEFAULT", 20000000000)
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+	toAddress := gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411")
+
+	routineEthTx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      toAddress,
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		CreatedAt:      time.Unix(0, 0),
+		State:          models.EthTxUnstarted,
+	}
+	require.NoError(t, store.DB.Save(&routineEthTx).Error)
+	urgentEthTx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      toAddress,
+		EncodedPayload: []byte{42, 42, 1},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		CreatedAt:      time.Unix(0, 1),
+		State:          models.EthTxUnstarted,
+		EthTxStrategy: models.EthTxStrategy{
+			Priority:       models.EthTxPriorityHigh,
+			MaxGasPriceWei: utils.NewBigI(15000000000),
+		},
+	}
+	require.NoError(t, store.DB.Save(&urgentEthTx).Error)
+
+	// The later but higher priority transaction goes first, at its own max gas price
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 0 && tx.Data()[2] == 1 && tx.GasPrice().Cmp(big.NewInt(15000000000)) == 0
+	})).Return(nil).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 1 && tx.Data()[2] == 0 && tx.GasPrice().Cmp(big.NewInt(20000000000)) == 0
+	})).Return(nil).Once()
+
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	ethClient.AssertExpectations(t)
+}
+
+type ethCallRevertError struct{ data string }
+
+func (e ethCallRevertError) Error() string          { return "execution reverted" }
+func (e ethCallRevertError) ErrorCode() int         { return 3 }
+func (e ethCallRevertError) ErrorData() interface{} { return e.data }
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_Simulate(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+	toAddress := gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411")
+
+	revertingEthTx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      toAddress,
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		CreatedAt:      time.Unix(0, 0),
+		State:          models.EthTxUnstarted,
+		EthTxStrategy:  models.EthTxStrategy{Simulate: true},
+	}
+	require.NoError(t, store.DB.Save(&revertingEthTx).Error)
+	succeedingEthTx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      toAddress,
+		EncodedPayload: []byte{42, 42, 1},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		CreatedAt:      time.Unix(0, 1),
+		State:          models.EthTxUnstarted,
+		EthTxStrategy:  models.EthTxStrategy{Simulate: true},
+	}
+	require.NoError(t, store.DB.Save(&succeedingEthTx).Error)
+
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("round not accepting submissions")
+	require.NoError(t, err)
+	revertData := hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], reason...))
+
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.MatchedBy(func(callArgs map[string]interface{}) bool {
+		return bytes.Equal(callArgs["data"].(hexutil.Bytes), revertingEthTx.EncodedPayload)
+	}), "pending").Return(ethCallRevertError{revertData}).Once()
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.MatchedBy(func(callArgs map[string]interface{}) bool {
+		return bytes.Equal(callArgs["data"].(hexutil.Bytes), succeedingEthTx.EncodedPayload)
+	}), "pending").Return(nil).Once()
+	// The reverting transaction is never sent, so its nonce goes to the next one
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 0 && bytes.Equal(tx.Data(), succeedingEthTx.EncodedPayload)
+	})).Return(nil).Once()
+
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	revertingEthTx, err = store.FindEthTxWithAttempts(revertingEthTx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxFatalError, revertingEthTx.State)
+	assert.Nil(t, revertingEthTx.Nonce)
+	require.NotNil(t, revertingEthTx.Error)
+	assert.Equal(t, "transaction reverted in simulation: round not accepting submissions", *revertingEthTx.Error)
+	assert.Len(t, revertingEthTx.EthTxAttempts, 0)
+
+	succeedingEthTx, err = store.FindEthTxWithAttempts(succeedingEthTx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxUnconfirmed, succeedingEthTx.State)
+	require.NotNil(t, succeedingEthTx.Nonce)
+	assert.Equal(t, int64(0), *succeedingEthTx.Nonce)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_SimulateFailure(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+
+	etx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411"),
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		CreatedAt:      time.Unix(0, 0),
+		State:          models.EthTxUnstarted,
+		EthTxStrategy:  models.EthTxStrategy{Simulate: true},
+	}
+	require.NoError(t, store.DB.Save(&etx).Error)
+
+	// A simulation that fails without reverting must not hold up the queue
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, "pending").Return(errors.New("method not found")).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 0 && bytes.Equal(tx.Data(), etx.EncodedPayload)
+	})).Return(nil).Once()
+
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	etx, err = store.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+	assert.Nil(t, etx.Error)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_SimulateDoesNotOverwriteCancel(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+
+	etx := models.EthTx{
+		FromAddress:    key.Address.Address(),
+		ToAddress:      gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411"),
+		EncodedPayload: []byte{42, 42, 0},
+		Value:          assets.NewEthValue(0),
+		GasLimit:       242,
+		CreatedAt:      time.Unix(0, 0),
+		State:          models.EthTxUnstarted,
+		EthTxStrategy:  models.EthTxStrategy{Simulate: true},
+	}
+	require.NoError(t, store.DB.Save(&etx).Error)
+
+	// The operator cancels the transaction while it is being simulated
+	ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_call", mock.Anything, "pending").Run(func(mock.Arguments) {
+		require.NoError(t, store.DB.Exec(`UPDATE eth_txes SET state = 'fatal_error', error = ? WHERE id = ?`, bulletprooftxmanager.ErrCancelledByOperator, etx.ID).Error)
+	}).Return(ethCallRevertError{"0x"}).Once()
+
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	etx, err = store.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxFatalError, etx.State)
+	require.NotNil(t, etx.Error)
+	assert.Equal(t, bulletprooftxmanager.ErrCancelledByOperator, *etx.Error)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthBroadcaster_ProcessUnstartedEthTxs_Pipelined(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("ETH_NONCE_PIPELINE_DEPTH", 3)
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+	defer cleanup()
+
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	key := keys[0]
+	defaultFromAddress := key.Address.Address()
+	toAddress := cltest.NewAddress()
+	require.NoError(t, store.DB.Exec(`UPDATE keys SET next_nonce = 0 WHERE address = ?`, defaultFromAddress.Bytes()).Error)
+
+	etxs := make([]models.EthTx, 4)
+	for i := range etxs {
+		etxs[i] = models.EthTx{
+			FromAddress:    defaultFromAddress,
+			ToAddress:      toAddress,
+			EncodedPayload: []byte{42, byte(i)},
+			Value:          assets.NewEthValue(142),
+			GasLimit:       242,
+			CreatedAt:      time.Unix(int64(i), 0),
+			State:          models.EthTxUnstarted,
+		}
+		require.NoError(t, store.DB.Save(&etxs[i]).Error)
+	}
+
+	decode := func(elem rpc.BatchElem) *gethTypes.Transaction {
+		tx := new(gethTypes.Transaction)
+		require.NoError(t, rlp.DecodeBytes(hexutil.MustDecode(elem.Args[0].(string)), tx))
+		return tx
+	}
+
+	// The first three are sent together, and the node rejects the second
+	// for good after it has accepted the third
+	ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		if len(b) != 3 {
+			return false
+		}
+		for i, elem := range b {
+			if elem.Method != "eth_sendRawTransaction" || decode(elem).Nonce() != uint64(i) {
+				return false
+			}
+		}
+		return true
+	})).Return(nil).Once().Run(func(args mock.Arguments) {
+		args.Get(1).([]rpc.BatchElem)[1].Error = errors.New("exceeds block gas limit")
+	})
+	// Its nonce is then used up by an empty transaction to self
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 1 && *tx.To() == defaultFromAddress && tx.Value().Sign() == 0 && len(tx.Data()) == 0
+	})).Return(nil).Once()
+	// The last is sent on its own
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == 3 && *tx.To() == toAddress
+	})).Return(nil).Once()
+
+	// Do the thing
+	require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+	for i := range etxs {
+		etx, err := store.FindEthTxWithAttempts(etxs[i].ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+		require.NotNil(t, etx.Nonce)
+		assert.Equal(t, int64(i), *etx.Nonce)
+		require.Len(t, etx.EthTxAttempts, 1)
+		assert.Equal(t, i == 1, etx.EthTxAttempts[0].Cancellation)
+		assert.Equal(t, i == 1, etx.CancelledAt != nil)
+	}
+	nonce, err := bulletprooftxmanager.GetNextNonce(store.DB, defaultFromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), *nonce)
+
+	ethClient.AssertExpectations(t)
+}
+
 func TestEthBroadcaster_AssignsNonceOnFirstRun(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -345,51 +781,54 @@ func TestEthBroadcaster_AssignsNonceOnFirstRun(t *testing.T) {
 }
 
 func TestEthBroadcaster_ProcessUnstartedEthTxs_ResumingFromCrash(t *testing.T) {
-	toAddress := gethCommon.HexToAddress("0x6C03DDA95a2AEd917EeCc6eddD4b9D16E6380411")
-	value := assets.NewEthValue(142)
-	gasLimit := uint64(242)
-	encodedPayload := []byte{0, 1}
 	nextNonce := int64(916714082576372851)
 
-	t.Run("cannot be more than one transaction per address in an unfinished state", func(t *testing.T) {
-		firstNonce := nextNonce + 1
-		secondNonce := nextNonce + 2
-
+	t.Run("previous run assigned nonces to several transactions and sends them in nonce order", func(t *testing.T) {
 		store, cleanup := cltest.NewStore(t)
 		defer cleanup()
+		// Use the real KeyStore loaded from database fixtures
+		store.KeyStore.Unlock(cltest.Password)
+
+		config, cleanup := cltest.NewConfig(t)
+		defer cleanup()
+
+		ethClient := new(mocks.Client)
+		store.EthClient = ethClient
+
+		eb, cleanup := cltest.NewEthBroadcaster(t, store, config)
+		defer cleanup()
+
 		keys, err := store.SendKeys()
 		require.NoError(t, err)
 		key := keys[0]
 		defaultFromAddress := key.Address.Address()
 
-		firstInProgress := models.EthTx{
-			FromAddress:    defaultFromAddress,
-			Nonce:          &firstNonce,
-			ToAddress:      toAddress,
-			EncodedPayload: encodedPayload,
-			Value:          value,
-			GasLimit:       gasLimit,
-			BroadcastAt:    nil,
-			Error:          nil,
-			State:          models.EthTxInProgress,
-		}
+		require.NoError(t, store.DB.Exec(`UPDATE keys SET next_nonce = ? WHERE address = ?`, nextNonce, defaultFromAddress.Bytes()).Error)
 
-		secondInProgress := models.EthTx{
-			FromAddress:    defaultFromAddress,
-			Nonce:          &secondNonce,
-			ToAddress:      toAddress,
-			EncodedPayload: encodedPayload,
-			Value:          value,
-			GasLimit:       gasLimit,
-			BroadcastAt:    nil,
-			Error:          nil,
-			State:          models.EthTxInProgress,
+		// Crashed after claiming two transactions together, so neither has
+		// incremented keys.next_nonce yet
+		secondInProgress := cltest.MustInsertInProgressEthTxWithAttempt(t, store, nextNonce+1)
+		firstInProgress := cltest.MustInsertInProgressEthTxWithAttempt(t, store, nextNonce)
+
+		var sent []uint64
+		ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Twice().Run(func(args mock.Arguments) {
+			sent = append(sent, args.Get(1).(*gethTypes.Transaction).Nonce())
+		})
+
+		// Do the thing
+		require.NoError(t, eb.ProcessUnstartedEthTxs(key))
+
+		assert.Equal(t, []uint64{uint64(nextNonce), uint64(nextNonce + 1)}, sent)
+		for _, id := range []int64{firstInProgress.ID, secondInProgress.ID} {
+			etx, err := store.FindEthTxWithAttempts(id)
+			require.NoError(t, err)
+			assert.Equal(t, models.EthTxUnconfirmed, etx.State)
 		}
+		nonce, err := bulletprooftxmanager.GetNextNonce(store.DB, defaultFromAddress)
+		require.NoError(t, err)
+		assert.Equal(t, nextNonce+2, *nonce)
 
-		require.NoError(t, store.DB.Create(&firstInProgress).Error)
-		err = store.DB.Create(&secondInProgress).Error
-		require.Error(t, err)
-		assert.EqualError(t, err, "pq: duplicate key value violates unique constraint \"idx_only_one_in_progress_tx_per_account\"")
+		ethClient.AssertExpectations(t)
 	})
 
 	t.Run("previous run assigned nonce but never broadcast", func(t *testing.T) {
@@ -11,14 +11,17 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/null"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 
+	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -28,6 +31,8 @@ var (
 	// ErrCouldNotGetReceipt is the error string we save if we reach our finality depth for a confirmed transaction without ever getting a receipt
 	// This most likely happened because an external wallet used the account for this nonce
 	ErrCouldNotGetReceipt = "could not get receipt"
+	// ErrCancelledByOperator is the error string we save on an unstarted transaction that was cancelled by the node operator
+	ErrCancelledByOperator = "cancelled by node operator"
 )
 
 // EthConfirmer is a broad service which performs four different tasks in sequence on every new longest chain
@@ -35,21 +40,28 @@ var (
 // Step 2: Check pending transactions for receipts
 // Step 3: See if any transactions have exceeded the gas bumping block threshold and, if so, bump them
 // Step 4: Check confirmed transactions to make sure they are still in the longest chain (reorg protection)
+//
+// It also lets the node operator cancel or reprice individual transactions.
 type EthConfirmer interface {
 	store.HeadTrackable
+	store.ReorgTrackable
+	CancelEthTx(ctx context.Context, etxID int64) (models.EthTx, error)
+	RepriceEthTx(ctx context.Context, etxID int64, gasPriceWei *big.Int) (models.EthTx, error)
 }
 
 type ethConfirmer struct {
 	store     *store.Store
 	ethClient eth.Client
 	config    orm.ConfigReader
+	estimator gas.Estimator
 }
 
-func NewEthConfirmer(store *store.Store, config orm.ConfigReader) *ethConfirmer {
+func NewEthConfirmer(store *store.Store, config orm.ConfigReader, estimator gas.Estimator) *ethConfirmer {
 	return &ethConfirmer{
 		store:     store,
 		ethClient: store.EthClient,
 		config:    config,
+		estimator: estimator,
 	}
 }
 
@@ -70,6 +82,35 @@ func (ec *ethConfirmer) OnNewLongestChain(ctx context.Context, head models.Head)
 	}
 }
 
+// OnReorg marks transactions for rebroadcast as soon as the blocks their
+// receipts are in are removed from the longest chain. Transactions that are
+// included again are confirmed by the next check for receipts.
+func (ec *ethConfirmer) OnReorg(ctx context.Context, reorg models.Reorg) {
+	if !ec.config.EnableBulletproofTxManager() || len(reorg.Removed) == 0 {
+		return
+	}
+	err := ec.store.AdvisoryLocker.WithAdvisoryLock(context.TODO(), postgres.AdvisoryLockClassID_EthConfirmer, postgres.AdvisoryLockObjectID_EthConfirmer, func() error {
+		return ec.handleReorg(reorg)
+	})
+	if err != nil {
+		logger.Errorw("EthConfirmer: error handling reorg", "err", err, "depth", reorg.Depth)
+	}
+}
+
+func (ec *ethConfirmer) handleReorg(reorg models.Reorg) error {
+	etxs, err := findTransactionsConfirmedInBlocks(ec.store.DB, reorg.Removed)
+	if err != nil {
+		return errors.Wrap(err, "findTransactionsConfirmedInBlocks failed")
+	}
+	for _, etx := range etxs {
+		logger.Infow("EthConfirmer: block of transaction receipt was reorged out, marking for rebroadcast", "ethTxID", etx.ID, "depth", reorg.Depth)
+		if err := ec.markForRebroadcast(etx); err != nil {
+			return errors.Wrapf(err, "markForRebroadcast failed for etx %v", etx.ID)
+		}
+	}
+	return nil
+}
+
 // ProcessHead takes all required transactions for the confirmer on a new head
 func (ec *ethConfirmer) ProcessHead(ctx context.Context, head models.Head) error {
 	return ec.store.AdvisoryLocker.WithAdvisoryLock(context.TODO(), postgres.AdvisoryLockClassID_EthConfirmer, postgres.AdvisoryLockObjectID_EthConfirmer, func() error {
@@ -117,10 +158,6 @@ func (ec *ethConfirmer) SetBroadcastBeforeBlockNum(blockNum int64) error {
 	).Error
 }
 
-// receiptFetcherWorkerCount is the max number of concurrently executing
-// workers that will fetch receipts for eth transactions
-const receiptFetcherWorkerCount = 10
-
 func (ec *ethConfirmer) CheckForReceipts(ctx context.Context, blockNum int64) error {
 	etxs, err := ec.findEthTxsRequiringReceiptFetch()
 	if err != nil {
@@ -132,7 +169,7 @@ func (ec *ethConfirmer) CheckForReceipts(ctx context.Context, blockNum int64) er
 
 	logger.Debugf("EthConfirmer: fetching receipt for %v transactions", len(etxs))
 
-	ec.concurrentlyFetchReceipts(ctx, etxs)
+	ec.batchFetchReceipts(ctx, etxs)
 
 	if err := ec.markConfirmedMissingReceipt(ctx); err != nil {
 		return errors.Wrap(err, "unable to mark eth_txes as 'confirmed_missing_receipt'")
@@ -156,38 +193,51 @@ func (ec *ethConfirmer) findEthTxsRequiringReceiptFetch() (etxs []models.EthTx,
 	return
 }
 
-func (ec *ethConfirmer) concurrentlyFetchReceipts(ctx context.Context, etxs []models.EthTx) {
-	var wg sync.WaitGroup
-	wg.Add(receiptFetcherWorkerCount)
-	chEthTxes := make(chan models.EthTx)
-	for i := 0; i < receiptFetcherWorkerCount; i++ {
-		go ec.fetchReceipts(ctx, chEthTxes, &wg)
+// batchFetchReceipts fetches the receipts for every attempt of the given
+// eth_txes, using as few round trips to the eth node as possible.  The
+// attempts of each eth_tx are always requested in the same batch.
+func (ec *ethConfirmer) batchFetchReceipts(ctx context.Context, etxs []models.EthTx) {
+	batchSize := int(ec.config.EthRPCBatchSize())
+	var batch []models.EthTx
+	var nAttempts int
+	for i, etx := range etxs {
+		batch = append(batch, etx)
+		nAttempts += len(etx.EthTxAttempts)
+		if nAttempts >= batchSize || i == len(etxs)-1 {
+			ec.fetchReceipts(ctx, batch)
+			batch, nAttempts = nil, 0
+		}
 	}
+}
+
+func (ec *ethConfirmer) fetchReceipts(ctx context.Context, etxs []models.EthTx) {
+	var reqs []rpc.BatchElem
 	for _, etx := range etxs {
-		chEthTxes <- etx
+		for _, attempt := range etx.EthTxAttempts {
+			reqs = append(reqs, rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{attempt.Hash},
+				Result: new(*gethTypes.Receipt),
+			})
+		}
 	}
-	close(chEthTxes)
-	wg.Wait()
 
-}
+	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	if err := ec.ethClient.BatchCallContext(ctx, reqs); err != nil {
+		logger.Errorw("EthConfirmer#fetchReceipts: batch request for receipts failed", "nRequests", len(reqs), "err", err)
+		return
+	}
 
-func (ec *ethConfirmer) fetchReceipts(ctx context.Context, chEthTxes <-chan models.EthTx, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for {
-		etx, ok := <-chEthTxes
-		if !ok {
-			return
-		}
-		for _, attempt := range etx.EthTxAttempts {
-			// NOTE: This could conceivably be optimised even further at the
-			// expense of slightly higher load for the remote eth node, by
-			// batch requesting all receipts at once
-			receipt, err := ec.fetchReceipt(ctx, attempt.Hash)
+	var offset int
+	for _, etx := range etxs {
+		for i, attempt := range etx.EthTxAttempts {
+			receipt, err := receiptFromBatchElem(reqs[offset+i])
 			if eth.IsParityQueriedReceiptTooEarly(err) || (receipt != nil && receipt.BlockNumber == nil) {
 				logger.Debugw("EthConfirmer#fetchReceipts: got receipt for transaction but it's still in the mempool and not included in a block yet", "txHash", attempt.Hash.Hex())
 				break
 			} else if err != nil {
-				logger.Errorw("EthConfirmer#fetchReceipts: fetchReceipt failed", "txHash", attempt.Hash.Hex(), "err", err)
+				logger.Errorw("EthConfirmer#fetchReceipts: fetching receipt failed", "txHash", attempt.Hash.Hex(), "err", err)
 				break
 			}
 			if receipt != nil {
@@ -205,17 +255,20 @@ func (ec *ethConfirmer) fetchReceipts(ctx context.Context, chEthTxes <-chan mode
 				logger.Debugw("EthConfirmer#fetchReceipts: still waiting for receipt", "txHash", attempt.Hash.Hex(), "ethTxAttemptID", attempt.ID, "ethTxID", etx.ID)
 			}
 		}
+		offset += len(etx.EthTxAttempts)
 	}
 }
 
-func (ec *ethConfirmer) fetchReceipt(ctx context.Context, hash gethCommon.Hash) (*gethTypes.Receipt, error) {
-	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
-	defer cancel()
-	receipt, err := ec.ethClient.TransactionReceipt(ctx, hash)
-	if err != nil && err.Error() == "not found" {
-		return nil, nil
+// receiptFromBatchElem returns the receipt from an eth_getTransactionReceipt
+// request, which is nil if the transaction has not been mined yet
+func receiptFromBatchElem(req rpc.BatchElem) (*gethTypes.Receipt, error) {
+	if req.Error != nil {
+		if errors.Cause(req.Error) == ethereum.NotFound {
+			return nil, nil
+		}
+		return nil, req.Error
 	}
-	return receipt, err
+	return *req.Result.(**gethTypes.Receipt), nil
 }
 
 func (ec *ethConfirmer) saveReceipt(receipt gethTypes.Receipt, ethTxID int64) error {
@@ -286,7 +339,11 @@ func (ec *ethConfirmer) markOldTxesMissingReceiptAsErrored(ctx context.Context,
 	// cutoff is a block height
 	// Any 'confirmed_missing_receipt' eth_tx with all attempts older than this block height will be marked as errored
 	// We will not try to query for receipts for this transaction any more
+	// It is the finalized block if the HeadTracker has set one, and the finality depth below blockNum otherwise
 	cutoff := blockNum - int64(ec.config.EthFinalityDepth())
+	if finalized, known := ec.store.HeadChain.FinalizedBlockNumber(); known {
+		cutoff = finalized
+	}
 	if cutoff <= 0 {
 		return nil
 	}
@@ -355,6 +412,10 @@ func (ec *ethConfirmer) bumpGasWhereNecessary(ctx context.Context, address gethC
 		return errors.Wrap(err, "handleAnyInProgressAttempts failed")
 	}
 
+	if err := ec.dropExpiredEthTxs(ctx, address, blockHeight); err != nil {
+		return errors.Wrap(err, "dropExpiredEthTxs failed")
+	}
+
 	threshold := int64(ec.config.EthGasBumpThreshold())
 	depth := int64(ec.config.EthGasBumpTxDepth())
 	etxs, err := FindEthTxsRequiringNewAttempt(ec.store.DB, address, blockHeight, threshold, depth)
@@ -381,6 +442,58 @@ func (ec *ethConfirmer) bumpGasWhereNecessary(ctx context.Context, address gethC
 	return nil
 }
 
+// dropExpiredEthTxs cancels transactions that have not been mined within
+// their DropAfterBlocks. Their nonce has already been used, so instead of
+// being abandoned they are replaced by an empty transaction to themselves
+// at a bumped gas price. If the gas price cannot be bumped, the replacement
+// would not be accepted, so the transaction is left alone until the next head.
+func (ec *ethConfirmer) dropExpiredEthTxs(ctx context.Context, address gethCommon.Address, blockHeight int64) error {
+	etxs, err := findEthTxsToDrop(ec.store.DB, address, blockHeight)
+	if err != nil {
+		return errors.Wrap(err, "findEthTxsToDrop failed")
+	}
+	for _, etx := range etxs {
+		logger.Warnw("EthConfirmer: transaction was not mined in time, cancelling it", "ethTxID", etx.ID, "nonce", *etx.Nonce, "dropAfterBlocks", *etx.DropAfterBlocks)
+		now := time.Now()
+		etx.CancelledAt = &now
+		attempt, err := ec.newAttemptWithGasBump(etx)
+		if err != nil {
+			return errors.Wrap(err, "newAttemptWithGasBump failed")
+		}
+		if attempt.ID != 0 {
+			// newAttemptWithGasBump fell back to the previous attempt,
+			// which still carries the original payload
+			logger.Warnw("EthConfirmer: could not bump gas to cancel transaction, will try again on the next head", "ethTxID", etx.ID, "nonce", *etx.Nonce, "previousAttemptState", etx.EthTxAttempts[0].State)
+			continue
+		}
+		err = ec.store.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`UPDATE eth_txes SET cancelled_at = ? WHERE id = ?`, etx.CancelledAt, etx.ID).Error; err != nil {
+				return err
+			}
+			return tx.Save(&attempt).Error
+		})
+		if err != nil {
+			return errors.Wrap(err, "dropExpiredEthTxs failed to save cancellation")
+		}
+		if err := ec.handleInProgressAttempt(ctx, etx, attempt, blockHeight); err != nil {
+			return errors.Wrap(err, "handleInProgressAttempt failed")
+		}
+	}
+	return nil
+}
+
+func findEthTxsToDrop(db *gorm.DB, address gethCommon.Address, blockNum int64) (etxs []models.EthTx, err error) {
+	err = db.
+		Preload("EthTxAttempts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("eth_tx_attempts.gas_price DESC")
+		}).
+		Where("state = 'unconfirmed' AND from_address = ? AND drop_after_blocks IS NOT NULL AND cancelled_at IS NULL", address).
+		Where("(SELECT MIN(broadcast_before_block_num) FROM eth_tx_attempts WHERE eth_tx_id = eth_txes.id) <= ? - drop_after_blocks", blockNum).
+		Order("nonce ASC").
+		Find(&etxs).Error
+	return
+}
+
 // "in_progress" attempts were left behind after a crash/restart and may or may not have been sent
 // We should try to ensure they get on-chain so we can fetch a receipt for them
 func (ec *ethConfirmer) handleAnyInProgressAttempts(ctx context.Context, address gethCommon.Address, blockHeight int64) error {
@@ -408,8 +521,8 @@ func getInProgressEthTxAttempts(s *store.Store, address gethCommon.Address) ([]m
 }
 
 // FindEthTxsRequiringNewAttempt returns transactions that have all
-// attempts which are unconfirmed for at least gasBumpThreshold blocks,
-// limited by limit pending transactions
+// attempts which are unconfirmed for at least gasBumpThreshold blocks, or the
+// transaction's own GasBumpThreshold, limited by limit pending transactions
 func FindEthTxsRequiringNewAttempt(db *gorm.DB, address gethCommon.Address, blockNum, gasBumpThreshold, depth int64) (etxs []models.EthTx, err error) {
 	q := db.
 		Preload("EthTxAttempts", func(db *gorm.DB) *gorm.DB {
@@ -417,7 +530,7 @@ func FindEthTxsRequiringNewAttempt(db *gorm.DB, address gethCommon.Address, bloc
 		}).
 		Joins("LEFT JOIN eth_tx_attempts ON eth_txes.id = eth_tx_attempts.eth_tx_id "+
 			"AND eth_tx_attempts.state != 'insufficient_eth' "+
-			"AND (broadcast_before_block_num > ? OR broadcast_before_block_num IS NULL OR eth_tx_attempts.state != 'broadcast')", blockNum-gasBumpThreshold).
+			"AND (broadcast_before_block_num > ? - COALESCE(eth_txes.gas_bump_threshold, ?) OR broadcast_before_block_num IS NULL OR eth_tx_attempts.state != 'broadcast')", blockNum, gasBumpThreshold).
 		Where("eth_txes.state = 'unconfirmed' AND eth_tx_attempts.id IS NULL")
 
 	if depth > 0 {
@@ -432,7 +545,8 @@ func FindEthTxsRequiringNewAttempt(db *gorm.DB, address gethCommon.Address, bloc
 }
 
 func (ec *ethConfirmer) newAttemptWithGasBump(etx models.EthTx) (attempt models.EthTxAttempt, err error) {
-	var bumpedGasPrice *big.Int
+	config := configForEthTx(ec.config, etx)
+	var bumpedFees fees
 	if len(etx.EthTxAttempts) > 0 {
 		previousAttempt := etx.EthTxAttempts[0]
 		if previousAttempt.State == models.EthTxAttemptInsufficientEth {
@@ -442,9 +556,9 @@ func (ec *ethConfirmer) newAttemptWithGasBump(etx models.EthTx) (attempt models.
 			return previousAttempt, nil
 		}
 		previousGasPrice := previousAttempt.GasPrice
-		bumpedGasPrice, err = BumpGas(ec.config, previousGasPrice.ToInt())
+		bumpedFees, err = bumpFees(config, previousAttempt)
 		if err != nil {
-			logger.Errorw("Failed to bump gas", "err", err, "etxID", etx.ID, "txHash", attempt.Hash, "originalGasPrice", previousGasPrice.String(), "maxGasPrice", ec.config.EthMaxGasPriceWei())
+			logger.Errorw("Failed to bump gas", "err", err, "etxID", etx.ID, "txHash", attempt.Hash, "originalGasPrice", previousGasPrice.String(), "maxGasPrice", config.EthMaxGasPriceWei())
 			// Do not create a new attempt if bumping gas would put us over the limit or cause some other problem
 			// Instead try to resubmit the previous attempt, and keep resubmitting until its accepted
 			previousAttempt.BroadcastBeforeBlockNum = nil
@@ -455,9 +569,9 @@ func (ec *ethConfirmer) newAttemptWithGasBump(etx models.EthTx) (attempt models.
 		logger.Errorf("invariant violation: EthTx %v was unconfirmed but didn't have any attempts. "+
 			"Falling back to default gas price instead."+
 			"This is a bug! Please report to https://github.com/smartcontractkit/chainlink/issues", etx.ID)
-		bumpedGasPrice = ec.config.EthGasPriceDefault()
+		bumpedFees = defaultFees(ec.estimator, config)
 	}
-	return newAttempt(ec.store, etx, bumpedGasPrice)
+	return newAttempt(ec.store, etx, bumpedFees)
 }
 
 func (ec *ethConfirmer) saveInProgressAttempt(attempt *models.EthTxAttempt) error {
@@ -479,15 +593,15 @@ func (ec *ethConfirmer) handleInProgressAttempt(ctx context.Context, etx models.
 		// already bumped above the required minimum in ethBroadcaster.
 		//
 		// It could conceivably happen if the remote eth node changed it's configuration.
-		bumpedGasPrice, err := BumpGas(ec.config, attempt.GasPrice.ToInt())
+		bumpedFees, err := bumpFees(configForEthTx(ec.config, etx), attempt)
 		if err != nil {
 			return errors.Wrap(err, "could not bump gas for terminally underpriced transaction")
 		}
 		logger.Errorf("gas price %v wei was rejected by the eth node for being too low. "+
 			"Eth node returned: '%s'. "+
-			"Bumping to %v wei and retrying. "+
-			"ACTION REQUIRED: You should consider increasing ETH_GAS_PRICE_DEFAULT", attempt.GasPrice, sendError.Error(), bumpedGasPrice)
-		replacementAttempt, err := newAttempt(ec.store, etx, bumpedGasPrice)
+			"Bumping to %s and retrying. "+
+			"ACTION REQUIRED: You should consider increasing ETH_GAS_PRICE_DEFAULT", attempt.GasPrice, sendError.Error(), bumpedFees)
+		replacementAttempt, err := newAttempt(ec.store, etx, bumpedFees)
 		if err != nil {
 			return errors.Wrap(err, "newAttempt failed")
 		}
@@ -615,8 +729,9 @@ func (ec *ethConfirmer) EnsureConfirmedTransactionsInLongestChain(ctx context.Co
 		return errors.Wrap(err, "findTransactionsConfirmedAtOrAboveBlockHeight failed")
 	}
 
+	canonical := canonicalHashes(head)
 	for _, etx := range etxs {
-		if !hasReceiptInLongestChain(etx, head) {
+		if !hasReceiptInLongestChain(etx, canonical) {
 			if err := ec.markForRebroadcast(etx); err != nil {
 				return errors.Wrapf(err, "markForRebroadcast failed for etx %v", etx.ID)
 			}
@@ -662,20 +777,41 @@ func findTransactionsConfirmedAtOrAboveBlockHeight(db *gorm.DB, blockNumber int6
 	return etxs, errors.Wrap(err, "findTransactionsConfirmedAtOrAboveBlockHeight failed")
 }
 
-func hasReceiptInLongestChain(etx models.EthTx, head models.Head) bool {
-	for {
-		for _, attempt := range etx.EthTxAttempts {
-			for _, receipt := range attempt.EthReceipts {
-				if receipt.BlockHash == head.Hash && receipt.BlockNumber == head.Number {
-					return true
-				}
+func findTransactionsConfirmedInBlocks(db *gorm.DB, blockHashes []gethCommon.Hash) ([]models.EthTx, error) {
+	var etxs []models.EthTx
+	err := db.
+		Preload("EthTxAttempts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("eth_tx_attempts.gas_price DESC")
+		}).
+		Order("nonce ASC").
+		Where(`eth_txes.state IN ('confirmed', 'confirmed_missing_receipt') AND eth_txes.id IN (
+			SELECT eth_tx_attempts.eth_tx_id FROM eth_tx_attempts
+			INNER JOIN eth_receipts ON eth_receipts.tx_hash = eth_tx_attempts.hash
+			WHERE eth_receipts.block_hash IN (?)
+		)`, blockHashes).
+		Find(&etxs).Error
+	return etxs, errors.Wrap(err, "findTransactionsConfirmedInBlocks failed")
+}
+
+// canonicalHashes indexes the block hashes of the given chain by number, so
+// that receipts can be checked against it without walking the chain each time
+func canonicalHashes(head models.Head) map[int64]gethCommon.Hash {
+	hashes := make(map[int64]gethCommon.Hash)
+	for h := &head; h != nil; h = h.Parent {
+		hashes[h.Number] = h.Hash
+	}
+	return hashes
+}
+
+func hasReceiptInLongestChain(etx models.EthTx, canonical map[int64]gethCommon.Hash) bool {
+	for _, attempt := range etx.EthTxAttempts {
+		for _, receipt := range attempt.EthReceipts {
+			if hash, exists := canonical[receipt.BlockNumber]; exists && hash == receipt.BlockHash {
+				return true
 			}
 		}
-		if head.Parent == nil {
-			return false
-		}
-		head = *head.Parent
 	}
+	return false
 }
 
 func (ec *ethConfirmer) markForRebroadcast(etx models.EthTx) error {
@@ -750,7 +886,7 @@ func (ec *ethConfirmer) ForceRebroadcast(beginningNonce uint, endingNonce uint,
 			if overrideGasLimit != 0 {
 				etx.GasLimit = overrideGasLimit
 			}
-			attempt, err := newAttempt(ec.store, *etx, big.NewInt(int64(gasPriceWei)))
+			attempt, err := newAttempt(ec.store, *etx, fees{GasPrice: big.NewInt(int64(gasPriceWei))})
 			if err != nil {
 				logger.Errorw("ForceRebroadcast: failed to create new attempt", "ethTxID", etx.ID, "err", err)
 				continue
@@ -795,3 +931,119 @@ func findEthTxWithNonce(db *gorm.DB, fromAddress gethCommon.Address, nonce uint)
 	}
 	return &etx, errors.Wrap(err, "findEthTxsWithNonce failed")
 }
+
+// CancelEthTx cancels a transaction on behalf of the node operator. An
+// unstarted transaction is marked as errored and will never be sent. An
+// unconfirmed transaction cannot be taken back, so it is replaced by an empty
+// transaction to self at a bumped gas price, which uses up its nonce without
+// doing anything.
+func (ec *ethConfirmer) CancelEthTx(ctx context.Context, etxID int64) (models.EthTx, error) {
+	res := ec.store.DB.Exec(`UPDATE eth_txes SET state = 'fatal_error', error = ? WHERE id = ? AND state = 'unstarted'`, ErrCancelledByOperator, etxID)
+	if res.Error != nil {
+		return models.EthTx{}, errors.Wrap(res.Error, "CancelEthTx failed")
+	}
+	if res.RowsAffected == 1 {
+		logger.Infow("EthConfirmer: cancelled unstarted transaction", "ethTxID", etxID)
+		return ec.store.FindEthTxWithAttempts(etxID)
+	}
+
+	etx, attempt, err := ec.saveOperatorAttempt(etxID, func(etx *models.EthTx) (fees, error) {
+		if etx.CancelledAt != nil {
+			return fees{}, errors.Errorf("transaction %v has already been cancelled", etx.ID)
+		}
+		now := time.Now()
+		etx.CancelledAt = &now
+		return bumpFees(configForEthTx(ec.config, *etx), etx.EthTxAttempts[0])
+	})
+	if err != nil {
+		return etx, errors.Wrap(err, "CancelEthTx failed")
+	}
+	logger.Infow("EthConfirmer: replacing unconfirmed transaction with an empty transaction", "ethTxID", etx.ID, "nonce", *etx.Nonce, "gasPriceWei", attempt.GasPrice.String())
+	return ec.sendOperatorAttempt(ctx, etx, attempt)
+}
+
+// RepriceEthTx sends a new attempt of an unconfirmed transaction at the given
+// gas price, which must be higher than that of every previous attempt. For
+// dynamic fee transactions the gas price is the new fee cap.
+func (ec *ethConfirmer) RepriceEthTx(ctx context.Context, etxID int64, gasPriceWei *big.Int) (models.EthTx, error) {
+	etx, attempt, err := ec.saveOperatorAttempt(etxID, func(etx *models.EthTx) (fees, error) {
+		config := configForEthTx(ec.config, *etx)
+		previousAttempt := etx.EthTxAttempts[0]
+		if gasPriceWei.Cmp(previousAttempt.GasPrice.ToInt()) <= 0 {
+			return fees{}, errors.Errorf("gas price of %v wei must be higher than the current %v wei", gasPriceWei, previousAttempt.GasPrice.String())
+		}
+		if gasPriceWei.Cmp(config.EthMaxGasPriceWei()) > 0 {
+			return fees{}, errors.Errorf("gas price of %v wei may not exceed the maximum of %v wei", gasPriceWei, config.EthMaxGasPriceWei())
+		}
+		if previousAttempt.TxType != models.DynamicFeeTxType {
+			return fees{GasPrice: gasPriceWei}, nil
+		}
+		tipCap, _, err := BumpDynamicFee(config, previousAttempt.GasTipCap.ToInt(), previousAttempt.GasFeeCap.ToInt())
+		if err != nil {
+			tipCap = previousAttempt.GasTipCap.ToInt()
+		}
+		if tipCap.Cmp(gasPriceWei) > 0 {
+			tipCap = gasPriceWei
+		}
+		return fees{TipCap: tipCap, FeeCap: gasPriceWei}, nil
+	})
+	if err != nil {
+		return etx, errors.Wrap(err, "RepriceEthTx failed")
+	}
+	logger.Infow("EthConfirmer: repricing unconfirmed transaction", "ethTxID", etx.ID, "nonce", *etx.Nonce, "gasPriceWei", attempt.GasPrice.String())
+	return ec.sendOperatorAttempt(ctx, etx, attempt)
+}
+
+// saveOperatorAttempt locks an unconfirmed transaction and saves a new
+// in_progress attempt for it, at the fees returned by getFees. getFees may
+// also change the transaction's cancelled_at.
+//
+// If sending the attempt fails, it is left in_progress and will be sent again
+// by the EthConfirmer on the next head, like any other.
+func (ec *ethConfirmer) saveOperatorAttempt(etxID int64, getFees func(etx *models.EthTx) (fees, error)) (etx models.EthTx, attempt models.EthTxAttempt, err error) {
+	err = ec.store.Transaction(func(tx *gorm.DB) error {
+		err = tx.
+			Set("gorm:query_option", "FOR UPDATE").
+			Preload("EthTxAttempts", func(db *gorm.DB) *gorm.DB {
+				return db.Order("eth_tx_attempts.gas_price DESC")
+			}).
+			First(&etx, "id = ?", etxID).
+			Error
+		if err != nil {
+			return err
+		}
+		if etx.State != models.EthTxUnconfirmed {
+			return errors.Errorf("only unstarted or unconfirmed transactions can be changed, transaction %v is currently %s", etx.ID, etx.State)
+		}
+		if len(etx.EthTxAttempts) == 0 {
+			return errors.Errorf("invariant violation: unconfirmed transaction %v has no attempts", etx.ID)
+		}
+		if etx.EthTxAttempts[0].State == models.EthTxAttemptInProgress {
+			return errors.Errorf("transaction %v already has an attempt waiting to be sent", etx.ID)
+		}
+		f, err := getFees(&etx)
+		if err != nil {
+			return err
+		}
+		attempt, err = newAttempt(ec.store, etx, f)
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE eth_txes SET cancelled_at = ? WHERE id = ?`, etx.CancelledAt, etx.ID).Error; err != nil {
+			return err
+		}
+		return tx.Create(&attempt).Error
+	})
+	return etx, attempt, err
+}
+
+func (ec *ethConfirmer) sendOperatorAttempt(ctx context.Context, etx models.EthTx, attempt models.EthTxAttempt) (models.EthTx, error) {
+	var blockHeight int64
+	if head, err := ec.store.LastHead(); err == nil && head != nil {
+		blockHeight = head.Number
+	}
+	if err := ec.handleInProgressAttempt(ctx, etx, attempt, blockHeight); err != nil {
+		return etx, errors.Wrap(err, "handleInProgressAttempt failed")
+	}
+	return ec.store.FindEthTxWithAttempts(etx.ID)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
@@ -21,6 +22,7 @@ import (
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -62,6 +64,42 @@ func mustInsertConfirmedEthTx(t *testing.T, store *store.Store, nonce int64) mod
 	return etx
 }
 
+// receiptResponse is the eth node's answer to a request for the receipt of
+// the attempt with the given hash
+type receiptResponse struct {
+	hash    gethCommon.Hash
+	receipt *gethTypes.Receipt
+	err     error
+}
+
+// mockReceipts expects a single batched request for receipts that includes
+// each of the given hashes.  Any other receipts in the batch are not found.
+func mockReceipts(ethClient *mocks.Client, responses []receiptResponse) {
+	indexOf := func(b []rpc.BatchElem, hash gethCommon.Hash) int {
+		for i, req := range b {
+			if req.Method == "eth_getTransactionReceipt" && req.Args[0] == hash {
+				return i
+			}
+		}
+		return -1
+	}
+	ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		for _, r := range responses {
+			if indexOf(b, r.hash) < 0 {
+				return false
+			}
+		}
+		return true
+	})).Run(func(args mock.Arguments) {
+		b := args.Get(1).([]rpc.BatchElem)
+		for _, r := range responses {
+			i := indexOf(b, r.hash)
+			*b[i].Result.(**gethTypes.Receipt) = r.receipt
+			b[i].Error = r.err
+		}
+	}).Return(nil).Once()
+}
+
 func TestEthConfirmer_SetBroadcastBeforeBlockNum(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -69,7 +107,7 @@ func TestEthConfirmer_SetBroadcastBeforeBlockNum(t *testing.T) {
 
 	config, cleanup := cltest.NewConfig(t)
 	defer cleanup()
-	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0)
 
@@ -116,7 +154,7 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 
 	config, cleanup := cltest.NewConfig(t)
 	defer cleanup()
-	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 	nonce := int64(0)
 	var err error
@@ -144,10 +182,10 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 	require.Len(t, attempt1_1.EthReceipts, 0)
 
 	t.Run("fetches receipt for an unconfirmed eth_tx", func(t *testing.T) {
-		// Transaction not confirmed yet, receipt is nil
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			// Transaction not confirmed yet, receipt is nil
+			{attempt1_1.Hash, nil, nil},
+		})
 
 		// Do the thing
 		require.NoError(t, ec.CheckForReceipts(ctx, blockNum))
@@ -169,10 +207,10 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 			TransactionIndex: uint(1),
 		}
 
-		// First transaction confirmed
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(&gethReceipt, nil).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			// First transaction confirmed
+			{attempt1_1.Hash, &gethReceipt, nil},
+		})
 
 		// Do the thing
 		// No error because it is merely logged
@@ -199,14 +237,12 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 			TransactionIndex: uint(1),
 		}
 
-		// First transaction confirmed
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(&gethReceipt, nil).Once()
-		// Second transaction still unconfirmed
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt2_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			// First transaction confirmed
+			{attempt1_1.Hash, &gethReceipt, nil},
+			// Second transaction still unconfirmed
+			{attempt2_1.Hash, nil, nil},
+		})
 
 		// Do the thing
 		require.NoError(t, ec.CheckForReceipts(ctx, blockNum))
@@ -246,21 +282,19 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 		require.NoError(t, store.DB.Create(&attempt2_3).Error)
 		require.NoError(t, store.DB.Create(&attempt2_2).Error)
 
-		// Most expensive attempt still unconfirmed
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt2_3.Hash
-		})).Return(nil, errors.New("not found")).Once()
-
 		gethReceipt := gethTypes.Receipt{
 			TxHash:           attempt2_2.Hash,
 			BlockHash:        cltest.NewHash(),
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
 		}
-		// Second most expensive attempt is confirmed
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt2_2.Hash
-		})).Return(&gethReceipt, nil).Once()
+
+		mockReceipts(ethClient, []receiptResponse{
+			// Most expensive attempt still unconfirmed
+			{attempt2_3.Hash, nil, nil},
+			// Second most expensive attempt is confirmed
+			{attempt2_2.Hash, &gethReceipt, nil},
+		})
 
 		// Do the thing
 		require.NoError(t, ec.CheckForReceipts(ctx, blockNum))
@@ -280,9 +314,9 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 	nonce++
 
 	t.Run("ignores error that comes from querying parity too early", func(t *testing.T) {
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt3_1.Hash
-		})).Return(nil, errors.New("missing required field 'transactionHash' for Log")).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt3_1.Hash, nil, errors.New("missing required field 'transactionHash' for Log")},
+		})
 
 		// Do the thing
 		require.NoError(t, ec.CheckForReceipts(ctx, blockNum))
@@ -301,9 +335,9 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 		receipt := gethTypes.Receipt{
 			TxHash: attempt3_1.Hash,
 		}
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt3_1.Hash
-		})).Return(&receipt, nil).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt3_1.Hash, &receipt, nil},
+		})
 
 		// Do the thing
 		require.NoError(t, ec.CheckForReceipts(ctx, blockNum))
@@ -327,9 +361,9 @@ func TestEthConfirmer_CheckForReceipts(t *testing.T) {
 			BlockNumber:      big.NewInt(ethReceipt.BlockNumber),
 			TransactionIndex: ethReceipt.TransactionIndex,
 		}
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt3_1.Hash
-		})).Return(&gethReceipt, nil).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt3_1.Hash, &gethReceipt, nil},
+		})
 
 		// Do the thing
 		require.NoError(t, ec.CheckForReceipts(ctx, blockNum))
@@ -365,7 +399,7 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 	config, cleanup := cltest.NewConfig(t)
 	defer cleanup()
 	config.Set("ETH_FINALITY_DEPTH", 50)
-	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 	ctx := context.Background()
 
@@ -423,21 +457,13 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 			BlockNumber:      big.NewInt(42),
 			TransactionIndex: uint(1),
 		}
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt3_1.Hash
-		})).Return(&gethReceipt3, nil).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt2_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_2.Hash
-		})).Return(nil, errors.New("not found")).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt0_1.Hash
-		})).Return(&gethReceipt0, nil).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt3_1.Hash, &gethReceipt3, nil},
+			{attempt2_1.Hash, nil, nil},
+			{attempt1_1.Hash, nil, nil},
+			{attempt1_2.Hash, nil, nil},
+			{attempt0_1.Hash, &gethReceipt0, nil},
+		})
 
 		// PERFORM
 		// Block num of 43 is one higher than the receipt (as would generally be expected)
@@ -482,15 +508,11 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 			BlockNumber:      big.NewInt(43),
 			TransactionIndex: uint(1),
 		}
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt2_1.Hash
-		})).Return(&gethReceipt, nil).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_2.Hash
-		})).Return(nil, errors.New("not found")).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt2_1.Hash, &gethReceipt, nil},
+			{attempt1_1.Hash, nil, nil},
+			{attempt1_2.Hash, nil, nil},
+		})
 
 		// PERFORM
 		// Block num of 44 is one higher than the receipt (as would generally be expected)
@@ -525,12 +547,10 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 	// eth_txes with nonce 3 is confirmed
 
 	t.Run("continues to leave eth_txes with state 'confirmed_missing_receipt' unchanged if at least one attempt is above ETH_FINALITY_DEPTH", func(t *testing.T) {
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_2.Hash
-		})).Return(nil, errors.New("not found")).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt1_1.Hash, nil, nil},
+			{attempt1_2.Hash, nil, nil},
+		})
 
 		// PERFORM
 		// Block num of 80 puts the first attempt (21) below threshold but second attempt (41) still above
@@ -561,12 +581,10 @@ func TestEthConfirmer_CheckForReceipts_confirmed_missing_receipt(t *testing.T) {
 	// eth_txes with nonce 3 is confirmed
 
 	t.Run("marks eth_Txes with state 'confirmed_missing_receipt' as 'errored' if a receipt fails to show up and all attempts are buried deeper than ETH_FINALITY_DEPTH", func(t *testing.T) {
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_1.Hash
-		})).Return(nil, errors.New("not found")).Once()
-		ethClient.On("TransactionReceipt", mock.Anything, mock.MatchedBy(func(txHash gethCommon.Hash) bool {
-			return txHash == attempt1_2.Hash
-		})).Return(nil, errors.New("not found")).Once()
+		mockReceipts(ethClient, []receiptResponse{
+			{attempt1_1.Hash, nil, nil},
+			{attempt1_2.Hash, nil, nil},
+		})
 
 		// PERFORM
 		// Block num of 100 puts the first attempt (21) and second attempt (41) below threshold
@@ -800,7 +818,7 @@ func TestEthConfirmer_BumpGasWhereNecessary(t *testing.T) {
 	kst := new(mocks.KeyStoreInterface)
 	// Use a mock keystore for this test
 	store.KeyStore = kst
-	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 	currentHead := int64(30)
 	oldEnough := int64(19)
 	nonce := int64(0)
@@ -1139,7 +1157,7 @@ func TestEthConfirmer_BumpGasWhereNecessary(t *testing.T) {
 
 		// One new attempt saved
 		require.Len(t, etx2.EthTxAttempts, 3)
-		assert.Equal(t, models.EthTxAttemptBroadcast, etx2.EthTxAttempts[0].State)
+		assert.Equal(t, models.EthTxAttemptBroadcast, etx2.EthTxAttempts[1].State)
 		assert.Equal(t, models.EthTxAttemptBroadcast, etx2.EthTxAttempts[1].State)
 		assert.Equal(t, models.EthTxAttemptBroadcast, etx2.EthTxAttempts[2].State)
 
@@ -1356,7 +1374,7 @@ func TestEthConfirmer_BumpGasWhereNecessary_WhenOutOfEth(t *testing.T) {
 	config, cleanup := cltest.NewConfig(t)
 	defer cleanup()
 
-	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 	currentHead := int64(30)
 	oldEnough := int64(19)
 	nonce := int64(0)
@@ -1447,6 +1465,129 @@ func TestEthConfirmer_BumpGasWhereNecessary_WhenOutOfEth(t *testing.T) {
 	})
 }
 
+func TestEthConfirmer_BumpGasWhereNecessary_GasStrategy(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+	fromAddress := keys[0].Address.Address()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
+	currentHead := int64(30)
+	tooNew := int64(27)
+	oldEnough := int64(19)
+
+	// Too new for the global gas bump threshold, but not for its own
+	etx1 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0)
+	gasBumpThreshold := int64(3)
+	gasBumpPercent := uint16(50)
+	etx1.GasBumpThreshold = &gasBumpThreshold
+	etx1.GasBumpPercent = &gasBumpPercent
+	require.NoError(t, store.DB.Save(&etx1).Error)
+	attempt1_1 := etx1.EthTxAttempts[0]
+	attempt1_1.BroadcastBeforeBlockNum = &tooNew
+	require.NoError(t, store.DB.Save(&attempt1_1).Error)
+
+	// Not mined within its drop after blocks
+	etx2 := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 1)
+	dropAfterBlocks := int64(10)
+	etx2.DropAfterBlocks = &dropAfterBlocks
+	require.NoError(t, store.DB.Save(&etx2).Error)
+	attempt2_1 := etx2.EthTxAttempts[0]
+	attempt2_1.BroadcastBeforeBlockNum = &oldEnough
+	require.NoError(t, store.DB.Save(&attempt2_1).Error)
+
+	// Bumped by its own percentage
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == uint64(0) && tx.GasPrice().Cmp(big.NewInt(30000000000)) == 0
+	})).Return(nil).Once()
+	// Replaced with an empty transaction to self
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == uint64(1) &&
+			*tx.To() == fromAddress &&
+			tx.Value().Sign() == 0 &&
+			len(tx.Data()) == 0 &&
+			tx.GasPrice().Cmp(big.NewInt(25000000000)) == 0
+	})).Return(nil).Once()
+
+	// Do the thing
+	require.NoError(t, ec.BumpGasWhereNecessary(context.TODO(), keys, currentHead))
+
+	etx1, err = store.FindEthTxWithAttempts(etx1.ID)
+	require.NoError(t, err)
+	require.Len(t, etx1.EthTxAttempts, 2)
+	assert.Equal(t, int64(30000000000), etx1.EthTxAttempts[1].GasPrice.ToInt().Int64())
+	assert.Equal(t, models.EthTxAttemptBroadcast, etx1.EthTxAttempts[1].State)
+	assert.Nil(t, etx1.CancelledAt)
+
+	etx2, err = store.FindEthTxWithAttempts(etx2.ID)
+	require.NoError(t, err)
+	require.Len(t, etx2.EthTxAttempts, 2)
+	assert.Equal(t, int64(25000000000), etx2.EthTxAttempts[1].GasPrice.ToInt().Int64())
+	assert.Equal(t, models.EthTxAttemptBroadcast, etx2.EthTxAttempts[1].State)
+	assert.Equal(t, models.EthTxUnconfirmed, etx2.State)
+	assert.NotNil(t, etx2.CancelledAt)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestEthConfirmer_BumpGasWhereNecessary_DropAtMaxGasPrice(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	// Use the real KeyStore loaded from database fixtures
+	store.KeyStore.Unlock(cltest.Password)
+	keys, err := store.SendKeys()
+	require.NoError(t, err)
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("ETH_MAX_GAS_PRICE_WEI", 20000000000)
+
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
+	currentHead := int64(30)
+	oldEnough := int64(19)
+
+	// Not mined within its drop after blocks, but already at the max gas price
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0)
+	dropAfterBlocks := int64(10)
+	etx.DropAfterBlocks = &dropAfterBlocks
+	require.NoError(t, store.DB.Save(&etx).Error)
+	attempt := etx.EthTxAttempts[0]
+	attempt.BroadcastBeforeBlockNum = &oldEnough
+	require.NoError(t, store.DB.Save(&attempt).Error)
+
+	// The original attempt is resent as it is, since it can't be replaced
+	ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+		return tx.Nonce() == uint64(0) && len(tx.Data()) > 0
+	})).Return(nil).Once()
+
+	// Do the thing
+	require.NoError(t, ec.BumpGasWhereNecessary(context.TODO(), keys, currentHead))
+
+	etx, err = store.FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	require.Len(t, etx.EthTxAttempts, 1)
+	assert.Equal(t, models.EthTxAttemptBroadcast, etx.EthTxAttempts[0].State)
+	assert.Nil(t, etx.CancelledAt)
+
+	ethClient.AssertExpectations(t)
+}
+
 func TestEthConfirmer_EnsureConfirmedTransactionsInLongestChain(t *testing.T) {
 	t.Parallel()
 
@@ -1460,7 +1601,7 @@ func TestEthConfirmer_EnsureConfirmedTransactionsInLongestChain(t *testing.T) {
 
 	config, cleanup := cltest.NewConfig(t)
 	defer cleanup()
-	ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 	head := models.Head{
 		Hash:   cltest.NewHash(),
@@ -1504,6 +1645,21 @@ func TestEthConfirmer_EnsureConfirmedTransactionsInLongestChain(t *testing.T) {
 		assert.Equal(t, models.EthTxConfirmed, etx.State)
 	})
 
+	t.Run("checks receipts against the given chain rather than the store's head chain", func(t *testing.T) {
+		// The store's head chain has a different block at the given head's number
+		store.HeadChain.SetLongestChain(models.Head{Hash: cltest.NewHash(), Number: head.Number})
+		etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 7, 1)
+		attempt := etx.EthTxAttempts[0]
+		cltest.MustInsertEthReceipt(t, store, head.Number, head.Hash, attempt.Hash)
+
+		// Do the thing
+		require.NoError(t, ec.EnsureConfirmedTransactionsInLongestChain(context.TODO(), keys, head))
+
+		etx, err := store.FindEthTxWithAttempts(etx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EthTxConfirmed, etx.State)
+	})
+
 	t.Run("does nothing to confirmed transactions that only have receipts older than the start of the chain", func(t *testing.T) {
 		etx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 3, 1)
 		attempt := etx.EthTxAttempts[0]
@@ -1609,6 +1765,38 @@ func TestEthConfirmer_EnsureConfirmedTransactionsInLongestChain(t *testing.T) {
 	})
 }
 
+func TestEthConfirmer_OnReorg(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
+
+	removedHash := cltest.NewHash()
+	reorgedEthTx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 0, 1)
+	cltest.MustInsertEthReceipt(t, store, 10, removedHash, reorgedEthTx.EthTxAttempts[0].Hash)
+	keptEthTx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 1, 1)
+	cltest.MustInsertEthReceipt(t, store, 9, cltest.NewHash(), keptEthTx.EthTxAttempts[0].Hash)
+
+	ec.OnReorg(context.TODO(), models.Reorg{Depth: 1, Removed: []gethCommon.Hash{removedHash}})
+
+	etx, err := store.FindEthTxWithAttempts(reorgedEthTx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+	require.Len(t, etx.EthTxAttempts, 1)
+	assert.Equal(t, models.EthTxAttemptInProgress, etx.EthTxAttempts[0].State)
+	assert.Len(t, etx.EthTxAttempts[0].EthReceipts, 0)
+
+	etx, err = store.FindEthTxWithAttempts(keptEthTx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxConfirmed, etx.State)
+	require.Len(t, etx.EthTxAttempts, 1)
+	assert.Len(t, etx.EthTxAttempts[0].EthReceipts, 1)
+}
+
 func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	t.Parallel()
 
@@ -1630,7 +1818,7 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	t.Run("rebroadcasts one eth_tx if it falls within in nonce range", func(t *testing.T) {
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+		ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
 			return tx.Nonce() == uint64(*etx1.Nonce) &&
@@ -1648,7 +1836,7 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	t.Run("uses default gas limit if overrideGasLimit is 0", func(t *testing.T) {
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+		ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
 			return tx.Nonce() == uint64(*etx1.Nonce) &&
@@ -1666,7 +1854,7 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	t.Run("rebroadcasts several eth_txes in nonce range", func(t *testing.T) {
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+		ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
 			return tx.Nonce() == uint64(*etx1.Nonce) && uint64(tx.GasPrice().Int64()) == gasPriceWei && tx.Gas() == overrideGasLimit
@@ -1683,7 +1871,7 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	t.Run("broadcasts zero transactions if eth_tx doesn't exist for that nonce", func(t *testing.T) {
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+		ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
 			return tx.Nonce() == uint64(1)
@@ -1711,7 +1899,7 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 	t.Run("zero transactions use default gas limit if override wasn't specified", func(t *testing.T) {
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ec := bulletprooftxmanager.NewEthConfirmer(store, config)
+		ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
 
 		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
 			return tx.Nonce() == uint64(0) && uint64(tx.GasPrice().Int64()) == gasPriceWei && uint64(tx.Gas()) == config.EthGasLimitDefault()
@@ -1722,3 +1910,120 @@ func TestEthConfirmer_ForceRebroadcast(t *testing.T) {
 		ethClient.AssertExpectations(t)
 	})
 }
+
+func TestEthConfirmer_CancelEthTx(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.KeyStore.Unlock(cltest.Password)
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+	fromAddress := cltest.GetDefaultFromAddress(t, store)
+
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
+
+	t.Run("marks unstarted eth_tx as errored", func(t *testing.T) {
+		etx := cltest.NewEthTx(t, store)
+		etx.State = models.EthTxUnstarted
+		require.NoError(t, store.DB.Save(&etx).Error)
+
+		etx, err := ec.CancelEthTx(context.TODO(), etx.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.EthTxFatalError, etx.State)
+		require.NotNil(t, etx.Error)
+		assert.Equal(t, bulletprooftxmanager.ErrCancelledByOperator, *etx.Error)
+		assert.Nil(t, etx.CancelledAt)
+		assert.Len(t, etx.EthTxAttempts, 0)
+	})
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0)
+
+	t.Run("replaces unconfirmed eth_tx with empty transaction to self", func(t *testing.T) {
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce) &&
+				*tx.To() == fromAddress &&
+				tx.Value().Sign() == 0 &&
+				len(tx.Data()) == 0 &&
+				tx.GasPrice().Cmp(big.NewInt(25000000000)) == 0
+		})).Return(nil).Once()
+
+		originalHash := etx.EthTxAttempts[0].Hash
+		etx, err := ec.CancelEthTx(context.TODO(), etx.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+		assert.NotNil(t, etx.CancelledAt)
+		require.Len(t, etx.EthTxAttempts, 2)
+		assert.Equal(t, models.EthTxAttemptBroadcast, etx.EthTxAttempts[1].State)
+		// Only the empty replacement is marked as a cancellation
+		for _, attempt := range etx.EthTxAttempts {
+			assert.Equal(t, attempt.Hash != originalHash, attempt.Cancellation)
+		}
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("refuses to cancel eth_tx twice", func(t *testing.T) {
+		_, err := ec.CancelEthTx(context.TODO(), etx.ID)
+		require.EqualError(t, err, fmt.Sprintf("CancelEthTx failed: transaction %v has already been cancelled", etx.ID))
+	})
+
+	t.Run("refuses to cancel confirmed eth_tx", func(t *testing.T) {
+		confirmedEthTx := cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 1, 42)
+
+		_, err := ec.CancelEthTx(context.TODO(), confirmedEthTx.ID)
+		require.EqualError(t, err, fmt.Sprintf("CancelEthTx failed: only unstarted or unconfirmed transactions can be changed, transaction %v is currently confirmed", confirmedEthTx.ID))
+	})
+}
+
+func TestEthConfirmer_RepriceEthTx(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.KeyStore.Unlock(cltest.Password)
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	ec := bulletprooftxmanager.NewEthConfirmer(store, config, gas.NewFixedEstimator(config))
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0)
+	gasPriceWei := big.NewInt(42000000000)
+
+	t.Run("sends a new attempt at the given gas price", func(t *testing.T) {
+		ethClient.On("SendTransaction", mock.Anything, mock.MatchedBy(func(tx *gethTypes.Transaction) bool {
+			return tx.Nonce() == uint64(*etx.Nonce) &&
+				*tx.To() == etx.ToAddress &&
+				reflect.DeepEqual(tx.Data(), etx.EncodedPayload) &&
+				tx.GasPrice().Cmp(gasPriceWei) == 0
+		})).Return(nil).Once()
+
+		etx, err := ec.RepriceEthTx(context.TODO(), etx.ID, gasPriceWei)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+		assert.Nil(t, etx.CancelledAt)
+		require.Len(t, etx.EthTxAttempts, 2)
+		assert.Equal(t, gasPriceWei.String(), etx.EthTxAttempts[1].GasPrice.String())
+		assert.Equal(t, models.EthTxAttemptBroadcast, etx.EthTxAttempts[1].State)
+
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("refuses a gas price that is not higher than the current one", func(t *testing.T) {
+		_, err := ec.RepriceEthTx(context.TODO(), etx.ID, gasPriceWei)
+		require.EqualError(t, err, "RepriceEthTx failed: gas price of 42000000000 wei must be higher than the current 42000000000 wei")
+	})
+
+	t.Run("refuses a gas price above the maximum", func(t *testing.T) {
+		tooHigh := new(big.Int).Add(config.EthMaxGasPriceWei(), big.NewInt(1))
+		_, err := ec.RepriceEthTx(context.TODO(), etx.ID, tooHigh)
+		require.EqualError(t, err, fmt.Sprintf("RepriceEthTx failed: gas price of %v wei may not exceed the maximum of %v wei", tooHigh, config.EthMaxGasPriceWei()))
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
@@ -49,15 +50,19 @@ type Application interface {
 	Stop() error
 	GetStore() *strpkg.Store
 	GetStatsPusher() synchronization.StatsPusher
+	GetGasEstimator() gas.Estimator
+	GetEthConfirmer() bulletprooftxmanager.EthConfirmer
 	WakeSessionReaper()
 	AddJob(job models.JobSpec) error
 	AddJobV2(ctx context.Context, job job.Spec) (int32, error)
 	ArchiveJob(*models.ID) error
 	DeleteJobV2(ctx context.Context, jobID int32) error
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
+	ResumeJobV2(ctx context.Context, taskRunID int64, result pipeline.Result) error
 	AddServiceAgreement(*models.ServiceAgreement) error
 	NewBox() packr.Box
 	AwaitRun(ctx context.Context, runID int64) error
+	SubscribeRunEventsV2(jobID int32) (pipeline.RunEventSubscription, error)
 	services.RunManager
 }
 
@@ -71,8 +76,9 @@ type ChainlinkApplication struct {
 	services.RunManager
 	RunQueue                 services.RunQueue
 	JobSubscriber            services.JobSubscriber
-	GasUpdater               services.GasUpdater
+	GasEstimator             gas.Estimator
 	EthBroadcaster           bulletprooftxmanager.EthBroadcaster
+	EthConfirmer             bulletprooftxmanager.EthConfirmer
 	LogBroadcaster           eth.LogBroadcaster
 	EventBroadcaster         postgres.EventBroadcaster
 	jobSpawner               job.Spawner
@@ -112,12 +118,12 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 	runQueue := services.NewRunQueue(runExecutor)
 	runManager := services.NewRunManager(runQueue, config, store.ORM, statsPusher, store.TxManager, store.Clock)
 	jobSubscriber := services.NewJobSubscriber(store, runManager)
-	gasUpdater := services.NewGasUpdater(store)
-	logBroadcaster := eth.NewLogBroadcaster(ethClient, store.ORM, store.Config.BlockBackfillDepth())
+	gasEstimator := gas.NewEstimator(store)
+	logBroadcaster := eth.NewLogBroadcaster(ethClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 	eventBroadcaster := postgres.NewEventBroadcaster(config.DatabaseURL(), config.DatabaseListenerMinReconnectInterval(), config.DatabaseListenerMaxReconnectDuration())
 	fluxMonitor := fluxmonitor.New(store, runManager, logBroadcaster)
-	ethBroadcaster := bulletprooftxmanager.NewEthBroadcaster(store, config, eventBroadcaster)
-	ethConfirmer := bulletprooftxmanager.NewEthConfirmer(store, config)
+	ethBroadcaster := bulletprooftxmanager.NewEthBroadcaster(store, config, eventBroadcaster, gasEstimator)
+	ethConfirmer := bulletprooftxmanager.NewEthConfirmer(store, config, gasEstimator)
 	var balanceMonitor services.BalanceMonitor
 	if config.BalanceMonitorEnabled() {
 		balanceMonitor = services.NewBalanceMonitor(store)
@@ -127,13 +133,13 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 
 	var (
 		pipelineORM    = pipeline.NewORM(store.ORM.DB, store.Config, eventBroadcaster)
-		pipelineRunner = pipeline.NewRunner(pipelineORM, store.Config)
+		pipelineRunner = pipeline.NewRunner(pipelineORM, store.Config, store.SecretStore)
 		jobORM         = job.NewORM(store.ORM.DB, store.Config, pipelineORM, eventBroadcaster, advisoryLocker)
 		jobSpawner     = job.NewSpawner(jobORM, store.Config)
 	)
 
 	if config.Dev() || config.FeatureOffchainReporting() {
-		offchainreporting.RegisterJobType(store.ORM.DB, jobORM, store.Config, store.OCRKeyStore, jobSpawner, pipelineRunner, ethClient, logBroadcaster)
+		offchainreporting.RegisterJobType(store.ORM.DB, jobORM, store.Config, store.OCRKeyStore, jobSpawner, pipelineRunner, ethClient, logBroadcaster, store.HeadChain)
 	}
 
 	store.NotifyNewEthTx = ethBroadcaster
@@ -142,8 +148,9 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 
 	app := &ChainlinkApplication{
 		JobSubscriber:            jobSubscriber,
-		GasUpdater:               gasUpdater,
+		GasEstimator:             gasEstimator,
 		EthBroadcaster:           ethBroadcaster,
+		EthConfirmer:             ethConfirmer,
 		LogBroadcaster:           logBroadcaster,
 		EventBroadcaster:         eventBroadcaster,
 		jobSpawner:               jobSpawner,
@@ -163,7 +170,7 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		explorerClient:           explorerClient,
 	}
 
-	headTrackables := []strpkg.HeadTrackable{gasUpdater}
+	headTrackables := []strpkg.HeadTrackable{gasEstimator}
 
 	if store.Config.EnableBulletproofTxManager() {
 		headTrackables = append(headTrackables, ethConfirmer)
@@ -176,6 +183,7 @@ func NewApplication(config *orm.Config, ethClient eth.Client, advisoryLocker pos
 		jobSubscriber,
 		pendingConnectionResumer,
 		balanceMonitor,
+		logBroadcaster,
 	)
 
 	for _, onConnectCallback := range onConnectCallbacks {
@@ -285,6 +293,17 @@ func (app *ChainlinkApplication) GetStatsPusher() synchronization.StatsPusher {
 	return app.StatsPusher
 }
 
+// GetGasEstimator returns the estimator that prices new transactions
+func (app *ChainlinkApplication) GetGasEstimator() gas.Estimator {
+	return app.GasEstimator
+}
+
+// GetEthConfirmer returns the EthConfirmer, which lets the node operator
+// cancel or reprice transactions
+func (app *ChainlinkApplication) GetEthConfirmer() bulletprooftxmanager.EthConfirmer {
+	return app.EthConfirmer
+}
+
 // WakeSessionReaper wakes up the reaper to do its reaping.
 func (app *ChainlinkApplication) WakeSessionReaper() {
 	app.SessionReaper.WakeUp()
@@ -313,10 +332,20 @@ func (app *ChainlinkApplication) RunJobV2(ctx context.Context, jobID int32, meta
 	return app.pipelineRunner.CreateRun(ctx, jobID, meta)
 }
 
+func (app *ChainlinkApplication) ResumeJobV2(ctx context.Context, taskRunID int64, result pipeline.Result) error {
+	return app.pipelineRunner.ResumeTaskRun(ctx, taskRunID, result)
+}
+
 func (app *ChainlinkApplication) AwaitRun(ctx context.Context, runID int64) error {
 	return app.pipelineRunner.AwaitRun(ctx, runID)
 }
 
+// SubscribeRunEventsV2 streams the pipeline run events for a v2 job, or for
+// every v2 job if jobID is 0
+func (app *ChainlinkApplication) SubscribeRunEventsV2(jobID int32) (pipeline.RunEventSubscription, error) {
+	return app.pipelineRunner.SubscribeRunEvents(jobID)
+}
+
 // ArchiveJob silences the job from the system, preventing future job runs.
 func (app *ChainlinkApplication) ArchiveJob(ID *models.ID) error {
 	_ = app.JobSubscriber.RemoveJob(ID)
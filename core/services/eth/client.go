@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -36,6 +37,7 @@ type Client interface {
 	SendRawTx(bytes []byte) (common.Hash, error)
 	Call(result interface{}, method string, args ...interface{}) error
 	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
 
 	// These methods are reimplemented due to a difference in how block header hashes are
 	// calculated by Parity nodes running on Kovan.  We have to return our own wrapper
@@ -89,6 +91,10 @@ type client struct {
 	SecondaryRPCClient  RPCClient
 	secondaryURL        string
 	mocked              bool
+
+	// pollingInterval is set for nodes that are reached over http(s), which
+	// cannot serve subscriptions.  New heads and logs are polled for instead.
+	pollingInterval time.Duration
 }
 
 var _ Client = (*client)(nil)
@@ -98,8 +104,13 @@ func NewClient(rpcUrl string, secondaryRPCURLs ...string) (*client, error) {
 	if err != nil {
 		return nil, err
 	}
-	if parsed.Scheme != "ws" && parsed.Scheme != "wss" {
-		return nil, errors.Errorf("ethereum url scheme must be websocket: %s", parsed.String())
+	var pollingInterval time.Duration
+	switch parsed.Scheme {
+	case "ws", "wss":
+	case "http", "https":
+		pollingInterval = DefaultPollingInterval
+	default:
+		return nil, errors.Errorf("ethereum url scheme must be websocket or http(s): %s", parsed.String())
 	}
 
 	var secondaryRPCURL string
@@ -113,7 +124,7 @@ func NewClient(rpcUrl string, secondaryRPCURLs ...string) (*client, error) {
 			return nil, errors.Errorf("secondary ethereum rpc url scheme must be http(s): %s", secondaryParsed.String())
 		}
 	}
-	return &client{url: rpcUrl, secondaryURL: secondaryRPCURL}, nil
+	return &client{url: rpcUrl, secondaryURL: secondaryRPCURL, pollingInterval: pollingInterval}, nil
 }
 
 // This alternate constructor exists for testing purposes.
@@ -316,11 +327,17 @@ func (client *client) SubscribeFilterLogs(ctx context.Context, q ethereum.Filter
 	logger.Debugw("eth.Client#SubscribeFilterLogs(...)",
 		"q", q,
 	)
+	if client.pollingInterval > 0 {
+		return client.pollFilterLogs(ctx, q, ch)
+	}
 	return client.GethClient.SubscribeFilterLogs(ctx, q, ch)
 }
 
 func (client *client) SubscribeNewHead(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
 	logger.Debugw("eth.Client#SubscribeNewHead(...)")
+	if client.pollingInterval > 0 {
+		return client.pollNewHeads(ctx, ch)
+	}
 	return client.RPCClient.EthSubscribe(ctx, ch, "newHeads")
 }
 
@@ -348,3 +365,12 @@ func (client *client) CallContext(ctx context.Context, result interface{}, metho
 	)
 	return client.RPCClient.CallContext(ctx, result, method, args...)
 }
+
+// BatchCallContext sends all of the given requests in a single round trip.
+// Errors for individual requests are set on each BatchElem.
+func (client *client) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	logger.Debugw("eth.Client#BatchCallContext(...)",
+		"nRequests", len(b),
+	)
+	return client.RPCClient.BatchCallContext(ctx, b)
+}
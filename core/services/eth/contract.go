@@ -10,6 +10,7 @@ type ConnectedContract interface {
 	ContractCodec
 	Call(result interface{}, methodName string, args ...interface{}) error
 	SubscribeToLogs(listener LogListener) (connected bool, _ UnsubscribeFunc)
+	SubscribeToLogsWithTopics(topics [][]common.Hash, listener LogListener) (connected bool, _ UnsubscribeFunc)
 }
 
 type connectedContract struct {
@@ -52,3 +53,11 @@ func (contract *connectedContract) SubscribeToLogs(listener LogListener) (connec
 	unsub := func() { contract.logBroadcaster.Unregister(contract.address, listener) }
 	return connected, unsub
 }
+
+// SubscribeToLogsWithTopics only delivers the contract's logs that match
+// topics, so that listeners aren't sent events they would ignore anyway
+func (contract *connectedContract) SubscribeToLogsWithTopics(topics [][]common.Hash, listener LogListener) (connected bool, _ UnsubscribeFunc) {
+	connected = contract.logBroadcaster.RegisterWithTopics(contract.address, topics, listener)
+	unsub := func() { contract.logBroadcaster.Unregister(contract.address, listener) }
+	return connected, unsub
+}
@@ -43,6 +43,15 @@ func NewFlagsDecodingLogListener(
 	}
 }
 
+// MinConfirmations passes through the inner listener's confirmation
+// requirement, if it has one
+func (ll flagsDecodingLogListener) MinConfirmations() uint64 {
+	if l, ok := ll.LogListener.(eth.ConfirmingLogListener); ok {
+		return l.MinConfirmations()
+	}
+	return 0
+}
+
 func (ll flagsDecodingLogListener) HandleLog(lb eth.LogBroadcast, err error) {
 	if err != nil {
 		ll.LogListener.HandleLog(lb, err)
@@ -68,7 +68,12 @@ func NewFluxAggregator(address common.Address, ethClient eth.Client, logBroadcas
 }
 
 func (fa *fluxAggregator) SubscribeToLogs(listener eth.LogListener) (connected bool, _ eth.UnsubscribeFunc) {
-	return fa.ConnectedContract.SubscribeToLogs(
+	var eventIDs []common.Hash
+	for eventID := range fluxAggregatorLogTypes {
+		eventIDs = append(eventIDs, eventID)
+	}
+	return fa.ConnectedContract.SubscribeToLogsWithTopics(
+		[][]common.Hash{eventIDs},
 		eth.NewDecodingLogListener(fa, fluxAggregatorLogTypes, listener),
 	)
 }
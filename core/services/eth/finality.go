@@ -0,0 +1,76 @@
+package eth
+
+import (
+	"gopkg.in/guregu/null.v3"
+)
+
+// Finality tells services which blocks can no longer be reorged out of the
+// longest chain. Finalized blocks never will be, while safe blocks are
+// unlikely to be. It is implemented by the HeadChain, whose finalized and
+// safe blocks are set by the HeadTracker according to ETH_FINALITY_MODE.
+// TaggedFinality is true in Tag mode, where the node itself reports them.
+type Finality interface {
+	FinalizedBlockNumber() (int64, bool)
+	SafeBlockNumber() (int64, bool)
+	IsFinal(number int64) bool
+	TaggedFinality() bool
+}
+
+var _ Finality = (*HeadChain)(nil)
+
+// IsNodeFinal returns true if the node reported the block with the given
+// number as finalized. In Depth mode, the finalized block is only a guess at
+// ETH_FINALITY_DEPTH below the head, so it never overrides the confirmations
+// that a job or the config asks for.
+func IsNodeFinal(finality Finality, number int64) bool {
+	return finality.TaggedFinality() && finality.IsFinal(number)
+}
+
+// HasConfirmations returns true if the block with the given number has at
+// least the given number of confirmations at the latest block, counting the
+// block itself as one, or if the node reported it as finalized. Such a block
+// can never be reorged out, so there is no point in waiting for more
+// confirmations than that.
+func HasConfirmations(finality Finality, latest, number int64, confirmations uint64) bool {
+	return latest-number+1 >= int64(confirmations) || IsNodeFinal(finality, number)
+}
+
+// FinalityStatus is the finality view of the chain, as reported by the API
+type FinalityStatus struct {
+	Mode                 string   `json:"mode"`
+	LatestBlockNumber    null.Int `json:"latestBlockNumber"`
+	SafeBlockNumber      null.Int `json:"safeBlockNumber"`
+	FinalizedBlockNumber null.Int `json:"finalizedBlockNumber"`
+}
+
+// CurrentFinality returns the head chain's latest, safe and finalized block
+// numbers. Those that are not yet known are null.
+func CurrentFinality(headChain *HeadChain, mode string) FinalityStatus {
+	status := FinalityStatus{Mode: mode}
+	if latest := headChain.LatestHead(); latest != nil {
+		status.LatestBlockNumber = null.IntFrom(latest.Number)
+	}
+	if safe, known := headChain.SafeBlockNumber(); known {
+		status.SafeBlockNumber = null.IntFrom(safe)
+	}
+	if finalized, known := headChain.FinalizedBlockNumber(); known {
+		status.FinalizedBlockNumber = null.IntFrom(finalized)
+	}
+	return status
+}
+
+// GetID returns the jsonapi ID, which is the finality mode
+func (s FinalityStatus) GetID() string {
+	return s.Mode
+}
+
+// GetName returns the jsonapi type name
+func (s FinalityStatus) GetName() string {
+	return "finality"
+}
+
+// SetID is used to set the jsonapi ID
+func (s *FinalityStatus) SetID(value string) error {
+	s.Mode = value
+	return nil
+}
@@ -0,0 +1,228 @@
+package eth
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// HeadChain keeps the heads of the most recent blocks in memory, so that the
+// canonical chain can be looked up by number or hash without querying the
+// database.  Heads more than depth blocks below the highest are pruned.
+//
+// The HeadTracker keeps it in sync with the heads table, and sets the longest
+// chain and the finalized and safe blocks before calling its HeadTrackables.
+type HeadChain struct {
+	depth     uint
+	mu        sync.RWMutex
+	heads     map[common.Hash]models.Head
+	byNumber  map[int64][]common.Hash
+	canonical map[int64]common.Hash
+	highest   int64
+	latest    *models.Head
+	finalized int64
+	safe      int64
+	tagged    bool
+}
+
+// NewHeadChain creates an empty HeadChain that keeps the heads of the last
+// depth blocks
+func NewHeadChain(depth uint) *HeadChain {
+	return &HeadChain{
+		depth:     depth,
+		heads:     make(map[common.Hash]models.Head),
+		byNumber:  make(map[int64][]common.Hash),
+		canonical: make(map[int64]common.Hash),
+		finalized: -1,
+		safe:      -1,
+	}
+}
+
+// Add adds the head and its parents
+func (hc *HeadChain) Add(head models.Head) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.add(head)
+	hc.prune()
+}
+
+func (hc *HeadChain) add(head models.Head) {
+	for h := &head; h != nil; h = h.Parent {
+		if _, exists := hc.heads[h.Hash]; exists {
+			continue
+		}
+		stored := *h
+		if h.Parent != nil {
+			stored.ParentHash = h.Parent.Hash
+		}
+		stored.Parent = nil
+		hc.heads[h.Hash] = stored
+		hc.byNumber[h.Number] = append(hc.byNumber[h.Number], h.Hash)
+		if h.Number > hc.highest {
+			hc.highest = h.Number
+		}
+	}
+}
+
+func (hc *HeadChain) prune() {
+	for number, hashes := range hc.byNumber {
+		if number > hc.highest-int64(hc.depth) {
+			continue
+		}
+		for _, hash := range hashes {
+			delete(hc.heads, hash)
+		}
+		delete(hc.byNumber, number)
+		delete(hc.canonical, number)
+	}
+}
+
+// SetLongestChain adds the head and its parents, and makes them the canonical
+// chain
+func (hc *HeadChain) SetLongestChain(head models.Head) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.add(head)
+	for number := range hc.canonical {
+		if number > head.Number {
+			delete(hc.canonical, number)
+		}
+	}
+	for h := &head; h != nil; h = h.Parent {
+		hc.canonical[h.Number] = h.Hash
+	}
+	latest := hc.heads[head.Hash]
+	hc.latest = &latest
+	hc.prune()
+}
+
+// HeadByHash returns the head with the given hash, without its parents, or
+// nil if it is not in memory
+func (hc *HeadChain) HeadByHash(hash common.Hash) *models.Head {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	head, exists := hc.heads[hash]
+	if !exists {
+		return nil
+	}
+	return &head
+}
+
+// Chain returns the head with the given hash and up to depth-1 of its
+// parents, as far as they are in memory. It returns false if the head is not
+// in memory.
+func (hc *HeadChain) Chain(hash common.Hash, depth uint) (models.Head, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	first, exists := hc.heads[hash]
+	if !exists || depth == 0 {
+		return models.Head{}, exists
+	}
+	h := &first
+	for i := uint(1); i < depth; i++ {
+		parent, exists := hc.heads[h.ParentHash]
+		if !exists {
+			break
+		}
+		h.Parent = &parent
+		h = h.Parent
+	}
+	return first, true
+}
+
+// LatestHead returns the head of the longest chain, without its parents, or
+// nil if none has been set
+func (hc *HeadChain) LatestHead() *models.Head {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	if hc.latest == nil {
+		return nil
+	}
+	latest := *hc.latest
+	return &latest
+}
+
+// CanonicalHash returns the hash of the block with the given number in the
+// longest chain, if it is known
+func (hc *HeadChain) CanonicalHash(number int64) (common.Hash, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	hash, known := hc.canonical[number]
+	return hash, known
+}
+
+// IsCanonical returns true if the block with the given hash and number is
+// known to be in the longest chain
+func (hc *HeadChain) IsCanonical(hash common.Hash, number int64) bool {
+	canonicalHash, known := hc.CanonicalHash(number)
+	return known && canonicalHash == hash
+}
+
+// IsOrphaned returns true if the block with the given hash and number is
+// known not to be in the longest chain, because another block with that
+// number is
+func (hc *HeadChain) IsOrphaned(hash common.Hash, number int64) bool {
+	canonicalHash, known := hc.CanonicalHash(number)
+	return known && canonicalHash != hash
+}
+
+// SetFinality records the numbers of the highest finalized and safe blocks,
+// as a depth below the head. Negative numbers are ignored, and neither number
+// ever decreases.
+func (hc *HeadChain) SetFinality(finalized, safe int64) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.setFinality(finalized, safe)
+}
+
+// SetTaggedFinality records the numbers of the highest finalized and safe
+// blocks, as reported by the node for the "finalized" and "safe" block tags.
+// Negative numbers are ignored, and neither number ever decreases.
+func (hc *HeadChain) SetTaggedFinality(finalized, safe int64) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.tagged = true
+	hc.setFinality(finalized, safe)
+}
+
+func (hc *HeadChain) setFinality(finalized, safe int64) {
+	if finalized > hc.finalized {
+		hc.finalized = finalized
+	}
+	if safe > hc.safe {
+		hc.safe = safe
+	}
+}
+
+// FinalizedBlockNumber returns the number of the highest finalized block, if
+// it is known
+func (hc *HeadChain) FinalizedBlockNumber() (int64, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.finalized, hc.finalized >= 0
+}
+
+// SafeBlockNumber returns the number of the highest safe block, if it is
+// known
+func (hc *HeadChain) SafeBlockNumber() (int64, bool) {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.safe, hc.safe >= 0
+}
+
+// IsFinal returns true if the block with the given number is known to be
+// finalized
+func (hc *HeadChain) IsFinal(number int64) bool {
+	finalized, known := hc.FinalizedBlockNumber()
+	return known && number <= finalized
+}
+
+// TaggedFinality returns true if the finalized and safe blocks were reported
+// by the node, rather than set at a depth below the head
+func (hc *HeadChain) TaggedFinality() bool {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	return hc.tagged
+}
@@ -0,0 +1,123 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func TestHeadChain(t *testing.T) {
+	t.Parallel()
+
+	hc := eth.NewHeadChain(10)
+	assert.Nil(t, hc.LatestHead())
+
+	hc.SetLongestChain(*headChain(12, 11, 0xa))
+	assert.Equal(t, int64(12), hc.LatestHead().Number)
+	assert.Nil(t, hc.LatestHead().Parent)
+
+	// Heads more than 10 blocks below the highest are pruned
+	assert.Nil(t, hc.HeadByHash(headChain(2, 11, 0xa).Hash))
+	require.NotNil(t, hc.HeadByHash(headChain(3, 11, 0xa).Hash))
+	_, known := hc.CanonicalHash(2)
+	assert.False(t, known)
+
+	chain, exists := hc.Chain(headChain(12, 11, 0xa).Hash, 5)
+	require.True(t, exists)
+	assert.Equal(t, uint32(5), chain.ChainLength())
+	assert.Equal(t, int64(8), chain.EarliestInChain().Number)
+	chain, exists = hc.Chain(headChain(12, 11, 0xa).Hash, 50)
+	require.True(t, exists)
+	assert.Equal(t, uint32(10), chain.ChainLength())
+	_, exists = hc.Chain(common.Hash{1}, 5)
+	assert.False(t, exists)
+
+	// A block of another fork is added but is not canonical
+	fork := headChain(12, 11, 0xb)
+	hc.Add(*fork)
+	require.NotNil(t, hc.HeadByHash(fork.Hash))
+	assert.True(t, hc.IsCanonical(headChain(12, 11, 0xa).Hash, 12))
+	assert.True(t, hc.IsOrphaned(fork.Hash, 12))
+	assert.False(t, hc.IsCanonical(fork.Hash, 13))
+	assert.False(t, hc.IsOrphaned(fork.Hash, 13))
+
+	// The fork becomes the longest chain
+	hc.SetLongestChain(*headChain(13, 11, 0xb))
+	assert.Equal(t, int64(13), hc.LatestHead().Number)
+	assert.True(t, hc.IsCanonical(fork.Hash, 12))
+	assert.True(t, hc.IsOrphaned(headChain(12, 11, 0xa).Hash, 12))
+	assert.True(t, hc.IsCanonical(headChain(11, 11, 0xa).Hash, 11))
+
+	// A shorter longest chain forgets the canonical blocks above it
+	hc.SetLongestChain(*headChain(12, 11, 0xa))
+	_, known = hc.CanonicalHash(13)
+	assert.False(t, known)
+	assert.Equal(t, models.Head{
+		Number:     5,
+		Hash:       common.BigToHash(big.NewInt(5)),
+		ParentHash: common.BigToHash(big.NewInt(4)),
+	}, *hc.HeadByHash(common.BigToHash(big.NewInt(5))))
+}
+
+func TestHeadChain_Finality(t *testing.T) {
+	hc := eth.NewHeadChain(10)
+
+	_, known := hc.FinalizedBlockNumber()
+	assert.False(t, known)
+	assert.False(t, hc.IsFinal(0))
+	assert.Equal(t, eth.FinalityStatus{Mode: "Depth"}, eth.CurrentFinality(hc, "Depth"))
+
+	// Negative numbers, from heads below the finality depth, are ignored
+	hc.SetFinality(-40, 2)
+	_, known = hc.FinalizedBlockNumber()
+	assert.False(t, known)
+	safe, known := hc.SafeBlockNumber()
+	require.True(t, known)
+	assert.Equal(t, int64(2), safe)
+
+	hc.SetLongestChain(*headChain(60, 10, 0xa))
+	hc.SetFinality(10, 48)
+	assert.True(t, hc.IsFinal(10))
+	assert.False(t, hc.IsFinal(11))
+	safe, _ = hc.SafeBlockNumber()
+	assert.Equal(t, int64(48), safe)
+
+	// A finalized block at a depth below the head does not override the
+	// confirmations asked for
+	assert.False(t, hc.TaggedFinality())
+	assert.False(t, eth.IsNodeFinal(hc, 10))
+	assert.False(t, eth.HasConfirmations(hc, 60, 10, 100))
+	assert.True(t, eth.HasConfirmations(hc, 60, 58, 3))
+	assert.False(t, eth.HasConfirmations(hc, 60, 59, 3))
+
+	// Neither number decreases
+	hc.SetFinality(5, 30)
+	finalized, known := hc.FinalizedBlockNumber()
+	require.True(t, known)
+	assert.Equal(t, int64(10), finalized)
+	safe, known = hc.SafeBlockNumber()
+	require.True(t, known)
+	assert.Equal(t, int64(48), safe)
+
+	status := eth.CurrentFinality(hc, "Depth")
+	assert.Equal(t, "Depth", status.GetID())
+	assert.Equal(t, int64(60), status.LatestBlockNumber.Int64)
+	assert.Equal(t, int64(48), status.SafeBlockNumber.Int64)
+	assert.Equal(t, int64(10), status.FinalizedBlockNumber.Int64)
+
+	// Blocks are confirmed once they are deep enough or the node reports them
+	// as finalized
+	hc.SetTaggedFinality(12, 50)
+	assert.True(t, hc.TaggedFinality())
+	assert.True(t, eth.IsNodeFinal(hc, 12))
+	assert.True(t, eth.HasConfirmations(hc, 60, 12, 100))
+	assert.False(t, eth.HasConfirmations(hc, 60, 13, 100))
+	assert.True(t, eth.HasConfirmations(hc, 60, 58, 3))
+	assert.False(t, eth.HasConfirmations(hc, 60, 59, 3))
+}
@@ -1,9 +1,64 @@
 package eth
 
 import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
 )
 
 func (lb *logBroadcaster) ExportedAppendLogChannel(ch1, ch2 <-chan types.Log) chan types.Log {
 	return lb.appendLogChannel(ch1, ch2)
 }
+
+func (lb *logBroadcaster) ExportedOnAddListener(address common.Address, topics [][]common.Hash, listener LogListener) bool {
+	return lb.onAddListener(registration{address, topics, listener})
+}
+
+func (lb *logBroadcaster) ExportedOnRemoveListener(address common.Address, listener LogListener) bool {
+	return lb.onRemoveListener(registration{address: address, listener: listener})
+}
+
+func (lb *logBroadcaster) ExportedFilterQuery() ethereum.FilterQuery {
+	return lb.filterQuery()
+}
+
+func (lb *logBroadcaster) ExportedOnRawLog(rawLog types.Log) {
+	lb.onRawLog(rawLog)
+}
+
+func (lb *logBroadcaster) ExportedOnNewHead(head *models.Head) {
+	lb.onNewHead(head)
+}
+
+func (lb *logBroadcaster) ExportedChunks(from, to int64) [][2]*big.Int {
+	return lb.chunks(from, to)
+}
+
+func (lb *logBroadcaster) ExportedAdvanceLogCursors(head *models.Head) {
+	lb.advanceLogCursors(head)
+}
+
+func (lb *logBroadcaster) ExportedEarliestLogCursor() (int64, bool) {
+	return lb.earliestLogCursor()
+}
+
+func (lb *logBroadcaster) ExportedStartCatchUp(from, to int64, cursors map[string]int64) {
+	lb.startCatchUp(from, to, cursors)
+}
+
+func (lb *logBroadcaster) ExportedOnCatchUpLog(rawLog types.Log) {
+	lb.onCatchUpLog(rawLog)
+}
+
+func (lb *logBroadcaster) ExportedFinishCatchUp() {
+	lb.finishCatchUp()
+}
+
+func (client *client) ExportedSetPollingInterval(interval time.Duration) {
+	client.pollingInterval = interval
+}
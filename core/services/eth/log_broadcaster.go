@@ -1,9 +1,13 @@
 package eth
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -25,12 +29,21 @@ import (
 // of creating a new websocket subscription for each request, it multiplexes all subscriptions
 // to all of the relevant contracts over a single connection and forwards the logs to the
 // relevant subscribers.
+//
+// The LogBroadcaster is also a HeadTrackable.  Heads from the HeadTracker are
+// used to hold back logs from listeners that require confirmations until the
+// logs are deep enough in the canonical chain.
 type LogBroadcaster interface {
 	utils.DependentAwaiter
 	Start() error
 	Stop() error
 	Register(address common.Address, listener LogListener) (connected bool)
+	RegisterWithTopics(address common.Address, topics [][]common.Hash, listener LogListener) (connected bool)
 	Unregister(address common.Address, listener LogListener)
+
+	Connect(head *models.Head) error
+	Disconnect()
+	OnNewLongestChain(ctx context.Context, head models.Head)
 }
 
 // The LogListener responds to log events through HandleLog, and contains setup/tear-down
@@ -44,44 +57,82 @@ type LogListener interface {
 	IsV2Job() bool
 }
 
+// A ConfirmingLogListener is only sent a log once the log's block has at least
+// MinConfirmations() confirmations on the canonical chain, counting the block
+// itself as the first.  Logs from blocks that are reorged out before then are
+// never sent.  Listeners that don't implement this interface are sent logs as
+// soon as they arrive.
+type ConfirmingLogListener interface {
+	LogListener
+	MinConfirmations() uint64
+}
+
 type ormInterface interface {
 	HasConsumedLog(blockHash common.Hash, logIndex uint, jobID *models.ID) (bool, error)
 	HasConsumedLogV2(blockHash common.Hash, logIndex uint, jobID int32) (bool, error)
 	MarkLogConsumed(blockHash common.Hash, logIndex uint, jobID *models.ID, blockNumber uint64) error
 	MarkLogConsumedV2(blockHash common.Hash, logIndex uint, jobID int32, blockNumber uint64) error
+	FindLogCursors(names []string) ([]models.LogCursor, error)
+	AdvanceLogCursors(names []string, blockIndex int64) error
 }
 
-type logBroadcaster struct {
-	ethClient     Client
-	orm           ormInterface
-	backfillDepth uint64
-	connected     *abool.AtomicBool
-	started       *abool.AtomicBool
+// backfillChunkDelay is the pause between eth_getLogs requests while catching
+// up from the log cursors, so that backfilling a long outage doesn't flood the
+// Ethereum node
+const backfillChunkDelay = 250 * time.Millisecond
 
-	listeners        map[common.Address]map[LogListener]struct{}
+type logBroadcaster struct {
+	ethClient         Client
+	orm               ormInterface
+	headChain         *HeadChain
+	backfillDepth     uint64
+	backfillBatchSize uint64
+	connected         *abool.AtomicBool
+	started           *abool.AtomicBool
+
+	listeners        map[common.Address]map[LogListener][][]common.Hash
 	chAddListener    chan registration
 	chRemoveListener chan registration
 
+	// pending holds the logs waiting for confirmations, keyed by block hash
+	pending      map[common.Hash][]pendingLog
+	latestHead   *models.Head
+	latestHeadMu sync.Mutex
+	chNewHead    chan struct{}
+	// lastHead is the last head handled by onNewHead, so that logs arriving
+	// after the head for their block don't wait for the next one
+	lastHead *models.Head
+	// catchingUp tracks the catch up that is in progress, if any.  Like
+	// pending and lastHead, it is only used from the broadcaster's goroutine.
+	catchingUp *catchUpProgress
+
 	utils.StartStopOnce
 	utils.DependentAwaiter
 	chStop chan struct{}
 	chDone chan struct{}
 }
 
-// NewLogBroadcaster creates a new instance of the logBroadcaster
-func NewLogBroadcaster(ethClient Client, orm ormInterface, backfillDepth uint64) LogBroadcaster {
+// NewLogBroadcaster creates a new instance of the logBroadcaster.  Backfills
+// request logs for at most backfillBatchSize blocks at a time, or for the
+// whole range at once if it is zero.  Logs from blocks that headChain shows
+// were reorged out are dropped.
+func NewLogBroadcaster(ethClient Client, orm ormInterface, headChain *HeadChain, backfillDepth uint64, backfillBatchSize uint64) LogBroadcaster {
 	return &logBroadcaster{
-		ethClient:        ethClient,
-		orm:              orm,
-		backfillDepth:    backfillDepth,
-		connected:        abool.New(),
-		started:          abool.New(),
-		listeners:        make(map[common.Address]map[LogListener]struct{}),
-		chAddListener:    make(chan registration),
-		chRemoveListener: make(chan registration),
-		chStop:           make(chan struct{}),
-		chDone:           make(chan struct{}),
-		DependentAwaiter: utils.NewDependentAwaiter(),
+		ethClient:         ethClient,
+		orm:               orm,
+		headChain:         headChain,
+		backfillDepth:     backfillDepth,
+		backfillBatchSize: backfillBatchSize,
+		connected:         abool.New(),
+		started:           abool.New(),
+		listeners:         make(map[common.Address]map[LogListener][][]common.Hash),
+		chAddListener:     make(chan registration),
+		chRemoveListener:  make(chan registration),
+		pending:           make(map[common.Hash][]pendingLog),
+		chNewHead:         make(chan struct{}, 1),
+		chStop:            make(chan struct{}),
+		chDone:            make(chan struct{}),
+		DependentAwaiter:  utils.NewDependentAwaiter(),
 	}
 }
 
@@ -134,12 +185,56 @@ func (lb *logBroadcast) MarkConsumed() error {
 }
 
 // A `registration` represents a LogListener's subscription to the logs of a
-// particular contract.
+// particular contract.  Topics has the same semantics as
+// ethereum.FilterQuery.Topics: the first position matches the event
+// signature, the rest match indexed arguments, and an empty position matches
+// anything.
 type registration struct {
 	address  common.Address
+	topics   [][]common.Hash
 	listener LogListener
 }
 
+// A pendingLog is a log held back until it has enough confirmations for its
+// listener
+type pendingLog struct {
+	rawLog           types.Log
+	listener         LogListener
+	minConfirmations uint64
+}
+
+// catchUpProgress tracks the delivery of logs from before the backfill depth
+// to the listeners whose log cursors were behind it
+type catchUpProgress struct {
+	// cursors holds the log cursor of each listener, by cursor name, as it
+	// was when the catch up started
+	cursors map[string]int64
+	to      int64
+	// delivered is the block up to which every caught up log has been
+	// delivered
+	delivered int64
+}
+
+// cursorCap returns the block that the cursor of the listener named name
+// may be advanced to while the catch up is in progress
+func (p *catchUpProgress) cursorCap(name string) (blockIndex int64, capped bool) {
+	cursor, exists := p.cursors[name]
+	if !exists || cursor >= p.to {
+		return 0, false
+	}
+	if p.delivered > cursor {
+		return p.delivered, true
+	}
+	return cursor, true
+}
+
+func minConfirmations(listener LogListener) uint64 {
+	if l, ok := listener.(ConfirmingLogListener); ok {
+		return l.MinConfirmations()
+	}
+	return 0
+}
+
 func (b *logBroadcaster) Start() error {
 	if !b.OkayToStart() {
 		return errors.New("LogBroadcaster is already started")
@@ -170,9 +265,83 @@ func (b *logBroadcaster) addresses() []common.Address {
 	for address := range b.listeners {
 		addresses = append(addresses, address)
 	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
 	return addresses
 }
 
+// filterQuery returns the narrowest single query that matches every log that
+// any listener is registered for.  Since one query can't express a different
+// set of topics for each address, it uses the union of all of the listeners'
+// topics at each position, and onRawLog filters out the extra logs.
+func (b *logBroadcaster) filterQuery() ethereum.FilterQuery {
+	q := ethereum.FilterQuery{Addresses: b.addresses()}
+
+	var topics [][]common.Hash
+	for position := 0; ; position++ {
+		union := make(map[common.Hash]struct{})
+		wildcard, anyConstrained := false, false
+		for _, listeners := range b.listeners {
+			for _, filter := range listeners {
+				if position >= len(filter) || len(filter[position]) == 0 {
+					wildcard = true
+					continue
+				}
+				anyConstrained = true
+				for _, topic := range filter[position] {
+					union[topic] = struct{}{}
+				}
+			}
+		}
+		if !anyConstrained {
+			break
+		}
+		var hashes []common.Hash
+		if !wildcard {
+			for topic := range union {
+				hashes = append(hashes, topic)
+			}
+			sort.Slice(hashes, func(i, j int) bool {
+				return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+			})
+		}
+		topics = append(topics, hashes)
+	}
+
+	// Trailing wildcards are implied
+	for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+		topics = topics[:len(topics)-1]
+	}
+	if len(topics) > 0 {
+		q.Topics = topics
+	}
+	return q
+}
+
+// topicsMatch reports whether a log's topics match a registration's topic
+// filter
+func topicsMatch(filter [][]common.Hash, topics []common.Hash) bool {
+	for position, wanted := range filter {
+		if len(wanted) == 0 {
+			continue
+		} else if position >= len(topics) {
+			return false
+		}
+		found := false
+		for _, topic := range wanted {
+			if topics[position] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *logBroadcaster) Stop() error {
 	if !b.OkayToStop() {
 		return errors.New("LogBroadcaster is already stopped")
@@ -182,9 +351,18 @@ func (b *logBroadcaster) Stop() error {
 	return nil
 }
 
+// Register subscribes listener to every log emitted by address
 func (b *logBroadcaster) Register(address common.Address, listener LogListener) (connected bool) {
+	return b.RegisterWithTopics(address, nil, listener)
+}
+
+// RegisterWithTopics subscribes listener to the logs emitted by address that
+// match topics, which has the same semantics as ethereum.FilterQuery.Topics.
+// For example, [][]common.Hash{{eventA, eventB}, {}, {indexedArg}} matches
+// eventA and eventB logs whose second indexed argument is indexedArg.
+func (b *logBroadcaster) RegisterWithTopics(address common.Address, topics [][]common.Hash, listener LogListener) (connected bool) {
 	select {
-	case b.chAddListener <- registration{address, listener}:
+	case b.chAddListener <- registration{address, topics, listener}:
 	case <-b.chStop:
 	}
 	return b.connected.IsSet()
@@ -192,7 +370,7 @@ func (b *logBroadcaster) Register(address common.Address, listener LogListener)
 
 func (b *logBroadcaster) Unregister(address common.Address, listener LogListener) {
 	select {
-	case b.chRemoveListener <- registration{address, listener}:
+	case b.chRemoveListener <- registration{address: address, listener: listener}:
 	case <-b.chStop:
 	}
 }
@@ -210,6 +388,9 @@ func (b *logBroadcaster) startResubscribeLoop() {
 	var subscription managedSubscription = newNoopSubscription()
 	defer func() { subscription.Unsubscribe() }()
 
+	cancelCatchUp := func() {}
+	defer func() { cancelCatchUp() }()
+
 	var chRawLogs chan types.Log
 	for {
 		newSubscription, abort := b.createSubscription()
@@ -217,7 +398,7 @@ func (b *logBroadcaster) startResubscribeLoop() {
 			return
 		}
 
-		chBackfilledLogs, abort := b.backfillLogs()
+		chBackfilledLogs, catchUpFrom, catchUpTo, cursors, abort := b.backfillLogs()
 		if abort {
 			return
 		}
@@ -231,8 +412,15 @@ func (b *logBroadcaster) startResubscribeLoop() {
 		subscription.Unsubscribe()
 		subscription = newSubscription
 
+		// Logs from before the backfill depth, which some listeners missed while
+		// the node was down, are fetched in the background and delivered
+		// alongside the new logs rather than ahead of them
+		cancelCatchUp()
+		var chCatchUpLogs <-chan types.Log
+		chCatchUpLogs, cancelCatchUp = b.catchUp(catchUpFrom, catchUpTo, cursors)
+
 		b.notifyConnect()
-		shouldResubscribe, err := b.process(subscription, chRawLogs)
+		shouldResubscribe, err := b.process(subscription, chRawLogs, chCatchUpLogs)
 		if err != nil {
 			logger.Error(err)
 			b.notifyDisconnect()
@@ -276,11 +464,16 @@ func (b *logBroadcaster) appendLogChannel(ch1, ch2 <-chan types.Log) chan types.
 	return chCombined
 }
 
-func (b *logBroadcaster) backfillLogs() (chBackfilledLogs chan types.Log, abort bool) {
+// backfillLogs fetches the logs from the last backfillDepth blocks, which are
+// delivered before any new logs.  If some listeners' log cursors are further
+// back than that, it also returns the range of blocks that they still need,
+// along with every listener's log cursor.
+func (b *logBroadcaster) backfillLogs() (chBackfilledLogs chan types.Log, catchUpFrom, catchUpTo int64, cursors map[string]int64, abort bool) {
+	catchUpFrom, catchUpTo = -1, -1
 	if len(b.listeners) == 0 {
 		ch := make(chan types.Log)
 		close(ch)
-		return ch, false
+		return ch, catchUpFrom, catchUpTo, nil, false
 	}
 
 	ctx, cancel := utils.ContextFromChan(b.chStop)
@@ -307,15 +500,22 @@ func (b *logBroadcaster) backfillLogs() (chBackfilledLogs chan types.Log, abort
 			fromBlock = 0 // Overflow protection
 		}
 
-		q := ethereum.FilterQuery{
-			FromBlock: big.NewInt(int64(fromBlock)),
-			Addresses: b.addresses(),
+		var logs []types.Log
+		for _, chunk := range b.chunks(int64(fromBlock), int64(currentHeight)) {
+			q := b.filterQuery()
+			q.FromBlock = chunk[0]
+			q.ToBlock = chunk[1]
+			chunkLogs, err := b.ethClient.FilterLogs(ctx, q)
+			if err != nil {
+				logger.Errorw("LogBroadcaster backfill: could not fetch logs", "error", err)
+				return true
+			}
+			logs = append(logs, chunkLogs...)
 		}
 
-		logs, err := b.ethClient.FilterLogs(ctx, q)
-		if err != nil {
-			logger.Errorw("LogBroadcaster backfill: could not fetch logs", "error", err)
-			return true
+		cursors = b.logCursors()
+		if cursor, exists := earliestLogCursor(cursors); exists && cursor < int64(fromBlock) {
+			catchUpFrom, catchUpTo = cursor, int64(fromBlock)-1
 		}
 
 		chBackfilledLogs = make(chan types.Log)
@@ -331,6 +531,214 @@ func (b *logBroadcaster) backfillLogs() (chBackfilledLogs chan types.Log, abort
 	return
 }
 
+// chunks splits the range of blocks from `from` to `to` inclusive into
+// ranges of at most backfillBatchSize blocks
+func (b *logBroadcaster) chunks(from, to int64) [][2]*big.Int {
+	if b.backfillBatchSize == 0 {
+		return [][2]*big.Int{{big.NewInt(from), big.NewInt(to)}}
+	}
+
+	var chunks [][2]*big.Int
+	for start := from; start <= to; start += int64(b.backfillBatchSize) {
+		end := start + int64(b.backfillBatchSize) - 1
+		if end > to {
+			end = to
+		}
+		chunks = append(chunks, [2]*big.Int{big.NewInt(start), big.NewInt(end)})
+	}
+	return chunks
+}
+
+// catchUp fetches the logs from `from` to `to` in the background, one chunk
+// at a time.  Each chunk is retried until it succeeds, and the next chunk is
+// only requested once the previous chunk's logs have been delivered.  Each
+// log is only delivered to the listeners whose cursors are behind it.  It
+// returns a nil channel if there is nothing to catch up on.
+func (b *logBroadcaster) catchUp(from, to int64, cursors map[string]int64) (chCatchUpLogs <-chan types.Log, cancel func()) {
+	b.catchingUp = nil
+	if from < 0 || to < from {
+		return nil, func() {}
+	}
+	b.startCatchUp(from, to, cursors)
+	logger.Infow(fmt.Sprintf("LogBroadcaster: catching up on logs from block %v to %v", from, to), "fromBlock", from, "toBlock", to)
+
+	ctx, cancel := utils.ContextFromChan(b.chStop)
+	q := b.filterQuery()
+	ch := make(chan types.Log)
+	go func() {
+		defer close(ch)
+		for i, chunk := range b.chunks(from, to) {
+			if i > 0 {
+				select {
+				case <-time.After(backfillChunkDelay):
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			var logs []types.Log
+			utils.RetryWithBackoff(ctx, func() (retry bool) {
+				chunkCtx, chunkCancel := context.WithTimeout(ctx, 30*time.Second)
+				defer chunkCancel()
+
+				q.FromBlock, q.ToBlock = chunk[0], chunk[1]
+				var err error
+				logs, err = b.ethClient.FilterLogs(chunkCtx, q)
+				if err != nil {
+					logger.Errorw("LogBroadcaster catch up: could not fetch logs", "fromBlock", chunk[0], "toBlock", chunk[1], "error", err)
+					return true
+				}
+				return false
+			})
+
+			for _, log := range logs {
+				select {
+				case ch <- log:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, cancel
+}
+
+// logCursorName identifies the log cursor of the job that a listener belongs
+// to, or is empty if the listener has no job
+func logCursorName(listener LogListener) string {
+	if listener.IsV2Job() {
+		return fmt.Sprintf("log_broadcaster:job_v2:%d", listener.JobIDV2())
+	} else if jobID := listener.JobID(); jobID != nil {
+		return "log_broadcaster:job:" + jobID.String()
+	}
+	return ""
+}
+
+func (b *logBroadcaster) startCatchUp(from, to int64, cursors map[string]int64) {
+	b.catchingUp = &catchUpProgress{cursors: cursors, to: to, delivered: from - 1}
+}
+
+// onCatchUpLog delivers a log from before the backfill depth to the
+// listeners that have not been sent it yet
+func (b *logBroadcaster) onCatchUpLog(rawLog types.Log) {
+	progress := b.catchingUp
+	if progress == nil {
+		return
+	}
+	// Caught up logs arrive in block order, so every earlier block is done
+	if blockNumber := int64(rawLog.BlockNumber); blockNumber-1 > progress.delivered {
+		progress.delivered = blockNumber - 1
+	}
+	b.dispatch(rawLog, func(listener LogListener) bool {
+		cursor, exists := progress.cursors[logCursorName(listener)]
+		return exists && int64(rawLog.BlockNumber) > cursor
+	})
+}
+
+// finishCatchUp is called once every caught up log has been delivered
+func (b *logBroadcaster) finishCatchUp() {
+	b.catchingUp = nil
+}
+
+// earliestLogCursor returns the lowest block that any listener's log cursor
+// has reached.  Listeners without a cursor are only backfilled to the
+// backfill depth.
+func (b *logBroadcaster) earliestLogCursor() (blockIndex int64, exists bool) {
+	return earliestLogCursor(b.logCursors())
+}
+
+func earliestLogCursor(cursors map[string]int64) (blockIndex int64, exists bool) {
+	for _, cursor := range cursors {
+		if !exists || cursor < blockIndex {
+			blockIndex, exists = cursor, true
+		}
+	}
+	return blockIndex, exists
+}
+
+// logCursors loads the log cursors of every listener that has one, by name
+func (b *logBroadcaster) logCursors() map[string]int64 {
+	var names []string
+	for _, listeners := range b.listeners {
+		for listener := range listeners {
+			if name := logCursorName(listener); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	cursors, err := b.orm.FindLogCursors(names)
+	if err != nil {
+		logger.Errorw("LogBroadcaster: could not load log cursors", "error", err)
+		return nil
+	}
+	byName := make(map[string]int64, len(cursors))
+	for _, cursor := range cursors {
+		byName[cursor.Name] = cursor.BlockIndex
+	}
+	return byName
+}
+
+// advanceLogCursors records that every listener has been sent all of its
+// logs up to backfillDepth blocks behind head, or up to just before its
+// earliest log that is still waiting for confirmations.  The cursors of
+// listeners that are still being caught up only advance as far as the catch
+// up has delivered.  A job with several listeners shares one cursor, which
+// only advances as far as the listener furthest behind.
+func (b *logBroadcaster) advanceLogCursors(head *models.Head) {
+	if head == nil {
+		return
+	}
+	safe := head.Number - int64(b.backfillDepth)
+	if safe <= 0 {
+		return
+	}
+
+	earliestPending := make(map[LogListener]int64)
+	for _, pls := range b.pending {
+		for _, pl := range pls {
+			blockNumber := int64(pl.rawLog.BlockNumber)
+			if earliest, exists := earliestPending[pl.listener]; !exists || blockNumber < earliest {
+				earliestPending[pl.listener] = blockNumber
+			}
+		}
+	}
+
+	blockIndexes := make(map[string]int64)
+	for _, listeners := range b.listeners {
+		for listener := range listeners {
+			name := logCursorName(listener)
+			if name == "" {
+				continue
+			}
+			blockIndex := safe
+			if earliest, exists := earliestPending[listener]; exists && earliest-1 < blockIndex {
+				blockIndex = earliest - 1
+			}
+			if b.catchingUp != nil {
+				if catchUpCap, capped := b.catchingUp.cursorCap(name); capped && catchUpCap < blockIndex {
+					blockIndex = catchUpCap
+				}
+			}
+			if current, exists := blockIndexes[name]; !exists || blockIndex < current {
+				blockIndexes[name] = blockIndex
+			}
+		}
+	}
+	namesByBlock := make(map[int64][]string)
+	for name, blockIndex := range blockIndexes {
+		namesByBlock[blockIndex] = append(namesByBlock[blockIndex], name)
+	}
+	for blockIndex, names := range namesByBlock {
+		if err := b.orm.AdvanceLogCursors(names, blockIndex); err != nil {
+			logger.Errorw("LogBroadcaster: could not advance log cursors", "blockIndex", blockIndex, "error", err)
+		}
+	}
+}
+
 func (b *logBroadcaster) deliverBackfilledLogs(logs []types.Log, chBackfilledLogs chan<- types.Log) {
 	defer close(chBackfilledLogs)
 	for _, log := range logs {
@@ -360,7 +768,7 @@ func (b *logBroadcaster) notifyDisconnect() {
 	}
 }
 
-func (b *logBroadcaster) process(subscription managedSubscription, chRawLogs <-chan types.Log) (shouldResubscribe bool, _ error) {
+func (b *logBroadcaster) process(subscription managedSubscription, chRawLogs <-chan types.Log, chCatchUpLogs <-chan types.Log) (shouldResubscribe bool, _ error) {
 	// We debounce requests to subscribe and unsubscribe to avoid making too many
 	// RPC calls to the Ethereum node, particularly on startup.
 	var needsResubscribe bool
@@ -372,12 +780,25 @@ func (b *logBroadcaster) process(subscription managedSubscription, chRawLogs <-c
 		case rawLog := <-chRawLogs:
 			b.onRawLog(rawLog)
 
+		case rawLog, open := <-chCatchUpLogs:
+			if !open {
+				chCatchUpLogs = nil
+				b.finishCatchUp()
+				continue
+			}
+			b.onCatchUpLog(rawLog)
+
 		case r := <-b.chAddListener:
 			needsResubscribe = b.onAddListener(r) || needsResubscribe
 
 		case r := <-b.chRemoveListener:
 			needsResubscribe = b.onRemoveListener(r) || needsResubscribe
 
+		case <-b.chNewHead:
+			head := b.takeLatestHead()
+			b.onNewHead(head)
+			b.advanceLogCursors(head)
+
 		case <-debounceResubscribe.C:
 			if needsResubscribe {
 				return true, nil
@@ -393,22 +814,174 @@ func (b *logBroadcaster) process(subscription managedSubscription, chRawLogs <-c
 }
 
 func (b *logBroadcaster) onRawLog(rawLog types.Log) {
-	for listener := range b.listeners[rawLog.Address] {
-		// Ignore duplicate logs sent back due to reorgs
-		if rawLog.Removed {
+	// Logs sent back due to reorgs are never delivered, and cancel any
+	// delivery that is still waiting for confirmations
+	if rawLog.Removed {
+		b.removePendingLog(rawLog)
+		return
+	}
+
+	b.dispatch(rawLog, nil)
+}
+
+// dispatch delivers a log to every listener whose topics it matches, and
+// that wants it if wants is not nil, or holds it back for the listeners that
+// are waiting for confirmations
+func (b *logBroadcaster) dispatch(rawLog types.Log, wants func(LogListener) bool) {
+	var addedPending bool
+	for listener, topics := range b.listeners[rawLog.Address] {
+		if !topicsMatch(topics, rawLog.Topics) {
+			continue
+		} else if wants != nil && !wants(listener) {
+			continue
+		}
+
+		if minConfs := minConfirmations(listener); minConfs > 0 {
+			b.addPendingLog(pendingLog{rawLog, listener, minConfs})
+			addedPending = true
 			continue
 		}
+		b.deliver(rawLog, listener)
+	}
+
+	if addedPending && b.lastHead != nil {
+		b.onNewHead(b.lastHead)
+	}
+}
+
+func (b *logBroadcaster) deliver(rawLog types.Log, listener LogListener) {
+	// Deep copy the log so that subscribers aren't sharing any state
+	rawLogCopy := copyLog(rawLog)
+	lb := &logBroadcast{
+		rawLog:  rawLogCopy,
+		orm:     b.orm,
+		jobID:   listener.JobID(),
+		jobIDV2: listener.JobIDV2(),
+		isV2:    listener.IsV2Job(),
+	}
+	listener.HandleLog(lb, nil)
+}
 
-		// Deep copy the log so that subscribers aren't sharing any state
-		rawLogCopy := copyLog(rawLog)
-		lb := &logBroadcast{
-			rawLog:  rawLogCopy,
-			orm:     b.orm,
-			jobID:   listener.JobID(),
-			jobIDV2: listener.JobIDV2(),
-			isV2:    listener.IsV2Job(),
+func (b *logBroadcaster) addPendingLog(pl pendingLog) {
+	// Backfilling and resubscribing deliver the same log more than once
+	for _, existing := range b.pending[pl.rawLog.BlockHash] {
+		if existing.listener == pl.listener && existing.rawLog.Index == pl.rawLog.Index {
+			return
 		}
-		listener.HandleLog(lb, nil)
+	}
+	b.pending[pl.rawLog.BlockHash] = append(b.pending[pl.rawLog.BlockHash], pl)
+}
+
+func (b *logBroadcaster) removePendingLog(rawLog types.Log) {
+	pls := b.pending[rawLog.BlockHash]
+	remaining := pls[:0]
+	for _, pl := range pls {
+		if pl.rawLog.Index != rawLog.Index {
+			remaining = append(remaining, pl)
+		}
+	}
+	b.setPending(rawLog.BlockHash, remaining)
+}
+
+func (b *logBroadcaster) removePendingLogsFor(listener LogListener) {
+	for blockHash, pls := range b.pending {
+		remaining := pls[:0]
+		for _, pl := range pls {
+			if pl.listener != listener {
+				remaining = append(remaining, pl)
+			}
+		}
+		b.setPending(blockHash, remaining)
+	}
+}
+
+func (b *logBroadcaster) setPending(blockHash common.Hash, pls []pendingLog) {
+	if len(pls) == 0 {
+		delete(b.pending, blockHash)
+	} else {
+		b.pending[blockHash] = pls
+	}
+}
+
+// Connect implements HeadTrackable
+func (b *logBroadcaster) Connect(head *models.Head) error {
+	return nil
+}
+
+// Disconnect implements HeadTrackable
+func (b *logBroadcaster) Disconnect() {}
+
+// OnNewLongestChain hands the head to the broadcaster's goroutine without
+// blocking the HeadTracker.  If several heads arrive before the goroutine
+// gets to them, only the latest is used.
+func (b *logBroadcaster) OnNewLongestChain(ctx context.Context, head models.Head) {
+	b.latestHeadMu.Lock()
+	b.latestHead = &head
+	b.latestHeadMu.Unlock()
+
+	select {
+	case b.chNewHead <- struct{}{}:
+	default:
+	}
+}
+
+func (b *logBroadcaster) takeLatestHead() *models.Head {
+	b.latestHeadMu.Lock()
+	defer b.latestHeadMu.Unlock()
+	head := b.latestHead
+	b.latestHead = nil
+	return head
+}
+
+// onNewHead delivers the pending logs that now have enough confirmations, and
+// drops those whose block is no longer in the canonical chain of headChain
+func (b *logBroadcaster) onNewHead(head *models.Head) {
+	if head == nil {
+		return
+	}
+	b.lastHead = head
+	if len(b.pending) == 0 {
+		return
+	}
+
+	// Deliver in chain order, so that listeners see logs in the order they
+	// were emitted
+	var ready []pendingLog
+	for blockHash, pls := range b.pending {
+		blockNumber := int64(pls[0].rawLog.BlockNumber)
+		if canonicalHash, known := b.headChain.CanonicalHash(blockNumber); known && canonicalHash != blockHash {
+			logger.Debugw("LogBroadcaster: dropping logs from block that was reorged out",
+				"blockNumber", blockNumber,
+				"blockHash", blockHash,
+				"canonicalHash", canonicalHash,
+			)
+			delete(b.pending, blockHash)
+			continue
+		} else if !known && blockNumber > head.Number {
+			// The head is behind the log's block, so it can't be confirmed
+			// or orphaned yet
+			continue
+		}
+
+		remaining := pls[:0]
+		for _, pl := range pls {
+			if HasConfirmations(b.headChain, head.Number, blockNumber, pl.minConfirmations) {
+				ready = append(ready, pl)
+			} else {
+				remaining = append(remaining, pl)
+			}
+		}
+		b.setPending(blockHash, remaining)
+	}
+
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].rawLog.BlockNumber != ready[j].rawLog.BlockNumber {
+			return ready[i].rawLog.BlockNumber < ready[j].rawLog.BlockNumber
+		}
+		return ready[i].rawLog.Index < ready[j].rawLog.Index
+	})
+	for _, pl := range ready {
+		b.deliver(pl.rawLog, pl.listener)
 	}
 }
 
@@ -433,28 +1006,33 @@ func copyLog(l types.Log) types.Log {
 }
 
 func (b *logBroadcaster) onAddListener(r registration) (needsResubscribe bool) {
-	_, knownAddress := b.listeners[r.address]
-	if !knownAddress {
-		b.listeners[r.address] = make(map[LogListener]struct{})
+	before := b.filterQuery()
+
+	if _, knownAddress := b.listeners[r.address]; !knownAddress {
+		b.listeners[r.address] = make(map[LogListener][][]common.Hash)
 	}
 	if _, exists := b.listeners[r.address][r.listener]; exists {
 		panic("registration already exists")
 	}
-	b.listeners[r.address][r.listener] = struct{}{}
+	b.listeners[r.address][r.listener] = r.topics
 
-	// Recreate the subscription with the new contract address
-	return !knownAddress
+	// Recreate the subscription if the new listener needs logs that the
+	// current one doesn't include
+	return !reflect.DeepEqual(before, b.filterQuery())
 }
 
 func (b *logBroadcaster) onRemoveListener(r registration) (needsResubscribe bool) {
+	before := b.filterQuery()
+
 	r.listener.OnDisconnect()
 	delete(b.listeners[r.address], r.listener)
+	b.removePendingLogsFor(r.listener)
 	if len(b.listeners[r.address]) == 0 {
 		delete(b.listeners, r.address)
-		// Recreate the subscription without this contract address
-		return true
 	}
-	return false
+
+	// Recreate the subscription without the logs that are no longer needed
+	return !reflect.DeepEqual(before, b.filterQuery())
 }
 
 // createSubscription creates a new log subscription starting at the current block.  If previous logs
@@ -469,9 +1047,7 @@ func (b *logBroadcaster) createSubscription() (sub managedSubscription, abort bo
 	defer cancel()
 
 	utils.RetryWithBackoff(ctx, func() (retry bool) {
-		filterQuery := ethereum.FilterQuery{
-			Addresses: b.addresses(),
-		}
+		filterQuery := b.filterQuery()
 		chRawLogs := make(chan types.Log)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
@@ -544,7 +1120,7 @@ type decodingLogListener struct {
 	LogListener
 }
 
-var _ LogListener = (*decodingLogListener)(nil)
+var _ ConfirmingLogListener = (*decodingLogListener)(nil)
 
 // NewDecodingLogListener creates a new decodingLogListener
 func NewDecodingLogListener(codec ContractCodec, nativeLogTypes map[common.Hash]interface{}, innerListener LogListener) LogListener {
@@ -560,6 +1136,12 @@ func NewDecodingLogListener(codec ContractCodec, nativeLogTypes map[common.Hash]
 	}
 }
 
+// MinConfirmations passes through the inner listener's confirmation
+// requirement, if it has one
+func (l *decodingLogListener) MinConfirmations() uint64 {
+	return minConfirmations(l.LogListener)
+}
+
 func (l *decodingLogListener) HandleLog(lb LogBroadcast, err error) {
 	if err != nil {
 		l.LogListener.HandleLog(&logBroadcast{}, err)
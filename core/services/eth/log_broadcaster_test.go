@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -67,6 +68,8 @@ func TestLogBroadcaster_AwaitsInitialSubscribersOnStartup(t *testing.T) {
 
 	listener.On("OnConnect").Return()
 	listener.On("OnDisconnect").Return().Run(func(mock.Arguments) { close(chOkayToAssert) })
+	listener.On("IsV2Job").Return(false).Maybe()
+	listener.On("JobID").Return(nil).Maybe()
 
 	sub.On("Unsubscribe").Return()
 	sub.On("Err").Return(nil)
@@ -78,7 +81,7 @@ func TestLogBroadcaster_AwaitsInitialSubscribersOnStartup(t *testing.T) {
 	ethClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&models.Head{Number: blockHeight}, nil)
 	ethClient.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{}, nil)
 
-	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.Config.BlockBackfillDepth())
+	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 	lb.AddDependents(2)
 	lb.Start()
 
@@ -128,7 +131,7 @@ func TestLogBroadcaster_ResubscribesOnAddOrRemoveContract(t *testing.T) {
 		Run(func(mock.Arguments) { atomic.AddInt32(&unsubscribeCalls, 1) })
 	sub.On("Err").Return(nil)
 
-	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.Config.BlockBackfillDepth())
+	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 	lb.Start()
 
 	type registration struct {
@@ -140,6 +143,8 @@ func TestLogBroadcaster_ResubscribesOnAddOrRemoveContract(t *testing.T) {
 		listener := new(mocks.LogListener)
 		listener.On("OnConnect").Return()
 		listener.On("OnDisconnect").Return()
+		listener.On("IsV2Job").Return(false).Maybe()
+		listener.On("JobID").Return(nil).Maybe()
 		registrations[i] = registration{cltest.NewAddress(), listener}
 		lb.Register(registrations[i].Address, registrations[i].LogListener)
 	}
@@ -205,7 +210,7 @@ func TestLogBroadcaster_BroadcastsToCorrectRecipients(t *testing.T) {
 	sub.On("Err").Return(nil)
 	sub.On("Unsubscribe").Return()
 
-	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.Config.BlockBackfillDepth())
+	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 	lb.Start()
 
 	addr1 := cltest.NewAddress()
@@ -371,8 +376,12 @@ func TestLogBroadcaster_Register_ResubscribesToMostRecentlySeenBlock(t *testing.
 	listener0.On("OnDisconnect").Return().Maybe()
 	listener1.On("OnDisconnect").Return().Maybe()
 	listener2.On("OnDisconnect").Return().Maybe()
+	for _, listener := range []*mocks.LogListener{listener0, listener1, listener2} {
+		listener.On("IsV2Job").Return(false).Maybe()
+		listener.On("JobID").Return(nil).Maybe()
+	}
 
-	lb := eth.NewLogBroadcaster(ethClient, store.ORM, store.Config.BlockBackfillDepth())
+	lb := eth.NewLogBroadcaster(ethClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 	lb.AddDependents(1)
 	lb.Start() // Subscribe #0
 	lb.Register(addr0, listener0)
@@ -549,7 +558,7 @@ func TestLogBroadcaster_ReceivesAllLogsWhenResubscribing(t *testing.T) {
 			sub.On("Err").Return(nil)
 			sub.On("Unsubscribe").Return()
 
-			lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.Config.BlockBackfillDepth())
+			lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 			lb.Start()
 
 			recvdMutex := new(sync.RWMutex)
@@ -656,7 +665,7 @@ func TestLogBroadcaster_AppendLogChannel(t *testing.T) {
 	ch2 := make(chan types.Log)
 	ch3 := make(chan types.Log)
 
-	lb := eth.NewLogBroadcaster(nil, nil, 0)
+	lb := eth.NewLogBroadcaster(nil, nil, nil, 0, 0)
 	type exportedAppendLogChanneler interface {
 		ExportedAppendLogChannel(ch1, ch2 <-chan types.Log) chan types.Log
 	}
@@ -692,6 +701,406 @@ func TestLogBroadcaster_AppendLogChannel(t *testing.T) {
 	}
 }
 
+func TestLogBroadcaster_FiltersByTopics(t *testing.T) {
+	t.Parallel()
+
+	type exportedTopicFilterer interface {
+		ExportedOnAddListener(address common.Address, topics [][]common.Hash, listener eth.LogListener) bool
+		ExportedOnRemoveListener(address common.Address, listener eth.LogListener) bool
+		ExportedFilterQuery() ethereum.FilterQuery
+		ExportedOnRawLog(rawLog types.Log)
+	}
+	lb := eth.NewLogBroadcaster(nil, nil, nil, 0, 0).(exportedTopicFilterer)
+
+	addr1 := common.Address{1}
+	addr2 := common.Address{2}
+	eventA := common.Hash{0xa}
+	eventB := common.Hash{0xb}
+	eventC := common.Hash{0xc}
+	indexedArg := common.Hash{0x1}
+
+	var received1, received2, received3 []types.Log
+	listener1 := &simpleLogListener{handler: func(lb eth.LogBroadcast, err error) { received1 = append(received1, lb.RawLog()) }}
+	listener2 := &simpleLogListener{handler: func(lb eth.LogBroadcast, err error) { received2 = append(received2, lb.RawLog()) }}
+	listener3 := &simpleLogListener{handler: func(lb eth.LogBroadcast, err error) { received3 = append(received3, lb.RawLog()) }}
+
+	require.True(t, lb.ExportedOnAddListener(addr1, [][]common.Hash{{eventA}}, listener1))
+	assert.Equal(t, ethereum.FilterQuery{
+		Addresses: []common.Address{addr1},
+		Topics:    [][]common.Hash{{eventA}},
+	}, lb.ExportedFilterQuery())
+
+	// The query uses the union of the topics at each position, and indexed
+	// argument filters only apply if every listener has one
+	require.True(t, lb.ExportedOnAddListener(addr2, [][]common.Hash{{eventB}, {}, {indexedArg}}, listener2))
+	assert.Equal(t, ethereum.FilterQuery{
+		Addresses: []common.Address{addr1, addr2},
+		Topics:    [][]common.Hash{{eventA, eventB}},
+	}, lb.ExportedFilterQuery())
+
+	// Adding a listener whose logs are already included doesn't resubscribe
+	require.False(t, lb.ExportedOnAddListener(addr1, [][]common.Hash{{eventA}}, listener3))
+
+	lb.ExportedOnRawLog(types.Log{Address: addr1, Topics: []common.Hash{eventA}, Index: 1})
+	lb.ExportedOnRawLog(types.Log{Address: addr1, Topics: []common.Hash{eventB}, Index: 2})
+	lb.ExportedOnRawLog(types.Log{Address: addr2, Topics: []common.Hash{eventB, {}, indexedArg}, Index: 3})
+	lb.ExportedOnRawLog(types.Log{Address: addr2, Topics: []common.Hash{eventB, {}, {0x2}}, Index: 4})
+	lb.ExportedOnRawLog(types.Log{Address: addr2, Topics: []common.Hash{eventB}, Index: 5})
+	lb.ExportedOnRawLog(types.Log{Address: addr2, Topics: []common.Hash{eventA, {}, indexedArg}, Index: 6})
+
+	require.Len(t, received1, 1)
+	assert.Equal(t, uint(1), received1[0].Index)
+	require.Len(t, received2, 1)
+	assert.Equal(t, uint(3), received2[0].Index)
+	require.Len(t, received3, 1)
+	assert.Equal(t, uint(1), received3[0].Index)
+
+	// A listener for any event needs every log from its address
+	listener4 := &simpleLogListener{handler: func(lb eth.LogBroadcast, err error) {}}
+	require.True(t, lb.ExportedOnAddListener(addr2, [][]common.Hash{{}, {eventC}}, listener4))
+	assert.Equal(t, ethereum.FilterQuery{
+		Addresses: []common.Address{addr1, addr2},
+	}, lb.ExportedFilterQuery())
+
+	require.True(t, lb.ExportedOnRemoveListener(addr2, listener4))
+	require.False(t, lb.ExportedOnRemoveListener(addr1, listener3))
+	require.True(t, lb.ExportedOnRemoveListener(addr2, listener2))
+	assert.Equal(t, ethereum.FilterQuery{
+		Addresses: []common.Address{addr1},
+		Topics:    [][]common.Hash{{eventA}},
+	}, lb.ExportedFilterQuery())
+}
+
+type confirmingLogListener struct {
+	simpleLogListener
+	minConfirmations uint64
+}
+
+func (listener *confirmingLogListener) MinConfirmations() uint64 {
+	return listener.minConfirmations
+}
+
+// headChain returns a chain of heads from 0 to the given number, using hashes
+// from the given fork for blocks after forkedAt
+func headChain(number int64, forkedAt int64, fork byte) *models.Head {
+	var head *models.Head
+	for i := int64(0); i <= number; i++ {
+		hash := common.BigToHash(big.NewInt(i))
+		if i > forkedAt {
+			hash[0] = fork
+		}
+		head = &models.Head{Number: i, Hash: hash, Parent: head}
+	}
+	return head
+}
+
+func TestLogBroadcaster_WaitsForConfirmations(t *testing.T) {
+	t.Parallel()
+
+	type exportedConfirmer interface {
+		ExportedOnAddListener(address common.Address, topics [][]common.Hash, listener eth.LogListener) bool
+		ExportedOnRemoveListener(address common.Address, listener eth.LogListener) bool
+		ExportedOnRawLog(rawLog types.Log)
+		ExportedOnNewHead(head *models.Head)
+	}
+	hc := eth.NewHeadChain(100)
+	lb := eth.NewLogBroadcaster(nil, nil, hc, 0, 0).(exportedConfirmer)
+	onNewHead := func(head *models.Head) {
+		hc.SetLongestChain(*head)
+		lb.ExportedOnNewHead(head)
+	}
+
+	addr := common.Address{1}
+	var immediate, confirmed, unregistered []types.Log
+	immediateListener := &simpleLogListener{handler: func(lb eth.LogBroadcast, err error) { immediate = append(immediate, lb.RawLog()) }}
+	confirmingListener := &confirmingLogListener{
+		simpleLogListener: simpleLogListener{handler: func(lb eth.LogBroadcast, err error) { confirmed = append(confirmed, lb.RawLog()) }},
+		minConfirmations:  3,
+	}
+	unregisteredListener := &confirmingLogListener{
+		simpleLogListener: simpleLogListener{handler: func(lb eth.LogBroadcast, err error) { unregistered = append(unregistered, lb.RawLog()) }},
+		minConfirmations:  1,
+	}
+	lb.ExportedOnAddListener(addr, nil, immediateListener)
+	lb.ExportedOnAddListener(addr, nil, confirmingListener)
+	lb.ExportedOnAddListener(addr, nil, unregisteredListener)
+
+	logAt := func(number int64, fork byte, index uint) types.Log {
+		return types.Log{
+			Address:     addr,
+			BlockNumber: uint64(number),
+			BlockHash:   headChain(number, 11, fork).Hash,
+			Index:       index,
+		}
+	}
+
+	// Block 12 on fork 0xa will be reorged out, block 12 on fork 0xb replaces it
+	orphaned := logAt(12, 0xa, 0)
+	lb.ExportedOnRawLog(orphaned)
+	lb.ExportedOnRawLog(logAt(11, 0xa, 1))
+	removed := logAt(11, 0xa, 2)
+	lb.ExportedOnRawLog(removed)
+	require.Len(t, immediate, 3)
+
+	lb.ExportedOnRemoveListener(addr, unregisteredListener)
+
+	removed.Removed = true
+	lb.ExportedOnRawLog(removed)
+	require.Len(t, immediate, 3)
+
+	// Block 11 has 2 confirmations
+	onNewHead(headChain(12, 11, 0xa))
+	assert.Len(t, confirmed, 0)
+
+	// The chain reorgs at block 12, and block 11 has 3 confirmations
+	onNewHead(headChain(13, 11, 0xb))
+	require.Len(t, confirmed, 1)
+	assert.Equal(t, uint(1), confirmed[0].Index)
+
+	lb.ExportedOnRawLog(logAt(12, 0xb, 3))
+	onNewHead(headChain(20, 11, 0xb))
+	require.Len(t, confirmed, 2)
+	assert.Equal(t, uint(3), confirmed[1].Index)
+
+	assert.Len(t, unregistered, 0)
+}
+
+func TestLogBroadcaster_WaitsForConfirmations_DecodingListener(t *testing.T) {
+	t.Parallel()
+
+	type exportedConfirmer interface {
+		ExportedOnAddListener(address common.Address, topics [][]common.Hash, listener eth.LogListener) bool
+		ExportedOnRawLog(rawLog types.Log)
+		ExportedOnNewHead(head *models.Head)
+	}
+	hc := eth.NewHeadChain(100)
+	lb := eth.NewLogBroadcaster(nil, nil, hc, 0, 0).(exportedConfirmer)
+	onNewHead := func(head *models.Head) {
+		hc.SetLongestChain(*head)
+		lb.ExportedOnNewHead(head)
+	}
+
+	contract, err := eth.GetV6ContractCodec("FluxAggregator")
+	require.NoError(t, err)
+	logTypes := map[common.Hash]interface{}{
+		eth.MustGetV6ContractEventID("FluxAggregator", "NewRound"): &LogNewRound{},
+	}
+
+	var decoded []*LogNewRound
+	listener := &confirmingLogListener{
+		simpleLogListener: simpleLogListener{handler: func(lb eth.LogBroadcast, err error) {
+			require.NoError(t, err)
+			decoded = append(decoded, lb.DecodedLog().(*LogNewRound))
+		}},
+		minConfirmations: 2,
+	}
+	decodingListener := eth.NewDecodingLogListener(contract, logTypes, listener)
+	require.Equal(t, uint64(2), decodingListener.(eth.ConfirmingLogListener).MinConfirmations())
+
+	fixture := cltest.LogFromFixture(t, "../testdata/new_round_log.json")
+	logAt := func(number int64) types.Log {
+		rawLog := fixture
+		rawLog.BlockNumber = uint64(number)
+		rawLog.BlockHash = headChain(number, number, 0).Hash
+		return rawLog
+	}
+	lb.ExportedOnAddListener(fixture.Address, nil, decodingListener)
+
+	// A log that arrives before its block's head waits for the confirmations
+	lb.ExportedOnRawLog(logAt(10))
+	onNewHead(headChain(10, 10, 0))
+	assert.Len(t, decoded, 0)
+	onNewHead(headChain(11, 11, 0))
+	require.Len(t, decoded, 1)
+	assert.Equal(t, uint64(10), decoded[0].Log.BlockNumber)
+
+	// A log that arrives after its block already has enough confirmations is
+	// delivered straight away, without waiting for another head
+	lateLog := logAt(10)
+	lateLog.Index++
+	lb.ExportedOnRawLog(lateLog)
+	require.Len(t, decoded, 2)
+	assert.Equal(t, lateLog.Index, decoded[1].Log.Index)
+
+	// A log that arrives after its block's head, but without enough
+	// confirmations yet, is delivered on the next head
+	onNewHead(headChain(12, 12, 0))
+	lb.ExportedOnRawLog(logAt(12))
+	assert.Len(t, decoded, 2)
+	onNewHead(headChain(13, 13, 0))
+	require.Len(t, decoded, 3)
+	assert.Equal(t, uint64(12), decoded[2].Log.BlockNumber)
+}
+
+// logCursorORM keeps log cursors in memory
+type logCursorORM struct {
+	cursors map[string]int64
+}
+
+func (orm *logCursorORM) HasConsumedLog(common.Hash, uint, *models.ID) (bool, error) {
+	return false, nil
+}
+func (orm *logCursorORM) HasConsumedLogV2(common.Hash, uint, int32) (bool, error) {
+	return false, nil
+}
+func (orm *logCursorORM) MarkLogConsumed(common.Hash, uint, *models.ID, uint64) error {
+	return nil
+}
+func (orm *logCursorORM) MarkLogConsumedV2(common.Hash, uint, int32, uint64) error {
+	return nil
+}
+func (orm *logCursorORM) FindLogCursors(names []string) ([]models.LogCursor, error) {
+	var cursors []models.LogCursor
+	for _, name := range names {
+		if blockIndex, exists := orm.cursors[name]; exists {
+			cursors = append(cursors, models.LogCursor{Name: name, Initialized: true, BlockIndex: blockIndex})
+		}
+	}
+	return cursors, nil
+}
+func (orm *logCursorORM) AdvanceLogCursors(names []string, blockIndex int64) error {
+	for _, name := range names {
+		if blockIndex > orm.cursors[name] {
+			orm.cursors[name] = blockIndex
+		}
+	}
+	return nil
+}
+
+func TestLogBroadcaster_Chunks(t *testing.T) {
+	t.Parallel()
+
+	type exportedChunker interface {
+		ExportedChunks(from, to int64) [][2]*big.Int
+	}
+
+	tests := []struct {
+		name      string
+		batchSize uint64
+		from, to  int64
+		expected  [][2]int64
+	}{
+		{"unlimited", 0, 10, 2000, [][2]int64{{10, 2000}}},
+		{"single block", 100, 10, 10, [][2]int64{{10, 10}}},
+		{"exact", 10, 10, 29, [][2]int64{{10, 19}, {20, 29}}},
+		{"remainder", 10, 10, 32, [][2]int64{{10, 19}, {20, 29}, {30, 32}}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			lb := eth.NewLogBroadcaster(nil, nil, nil, 0, test.batchSize).(exportedChunker)
+			chunks := lb.ExportedChunks(test.from, test.to)
+			require.Len(t, chunks, len(test.expected))
+			for i, chunk := range chunks {
+				assert.Equal(t, test.expected[i][0], chunk[0].Int64())
+				assert.Equal(t, test.expected[i][1], chunk[1].Int64())
+			}
+		})
+	}
+}
+
+func TestLogBroadcaster_AdvancesLogCursors(t *testing.T) {
+	t.Parallel()
+
+	type exportedCursorAdvancer interface {
+		ExportedOnAddListener(address common.Address, topics [][]common.Hash, listener eth.LogListener) bool
+		ExportedOnRawLog(rawLog types.Log)
+		ExportedAdvanceLogCursors(head *models.Head)
+		ExportedEarliestLogCursor() (int64, bool)
+	}
+	orm := &logCursorORM{cursors: make(map[string]int64)}
+	lb := eth.NewLogBroadcaster(nil, orm, nil, 5, 0).(exportedCursorAdvancer)
+
+	addr := common.Address{1}
+	jobID := models.NewID()
+	immediateListener := &simpleLogListener{handler: func(eth.LogBroadcast, error) {}, consumerID: jobID}
+	confirmingListener := &confirmingLogListener{
+		simpleLogListener: simpleLogListener{handler: func(eth.LogBroadcast, error) {}, consumerID: models.NewID()},
+		minConfirmations:  20,
+	}
+	// Another listener of the same job as the confirming listener, which
+	// shares its cursor
+	sameJobListener := &simpleLogListener{handler: func(eth.LogBroadcast, error) {}, consumerID: confirmingListener.consumerID}
+	anonymousListener := &simpleLogListener{handler: func(eth.LogBroadcast, error) {}}
+	lb.ExportedOnAddListener(addr, nil, immediateListener)
+	lb.ExportedOnAddListener(addr, nil, confirmingListener)
+	lb.ExportedOnAddListener(addr, nil, sameJobListener)
+	lb.ExportedOnAddListener(addr, nil, anonymousListener)
+
+	_, exists := lb.ExportedEarliestLogCursor()
+	assert.False(t, exists)
+
+	// The confirming listener's cursor stops short of its pending log, even
+	// though the other listener of its job has nothing pending
+	lb.ExportedOnRawLog(types.Log{Address: addr, BlockNumber: 12, BlockHash: headChain(12, 0, 0).Hash})
+	lb.ExportedAdvanceLogCursors(headChain(20, 0, 0))
+
+	immediateName := "log_broadcaster:job:" + jobID.String()
+	confirmingName := "log_broadcaster:job:" + confirmingListener.consumerID.String()
+	require.Len(t, orm.cursors, 2)
+	assert.Equal(t, int64(15), orm.cursors[immediateName])
+	assert.Equal(t, int64(11), orm.cursors[confirmingName])
+
+	earliest, exists := lb.ExportedEarliestLogCursor()
+	require.True(t, exists)
+	assert.Equal(t, int64(11), earliest)
+}
+
+func TestLogBroadcaster_CatchUp(t *testing.T) {
+	t.Parallel()
+
+	type exportedCatchUpper interface {
+		ExportedOnAddListener(address common.Address, topics [][]common.Hash, listener eth.LogListener) bool
+		ExportedAdvanceLogCursors(head *models.Head)
+		ExportedStartCatchUp(from, to int64, cursors map[string]int64)
+		ExportedOnCatchUpLog(rawLog types.Log)
+		ExportedFinishCatchUp()
+	}
+
+	behindID, nearlyID, newID := models.NewID(), models.NewID(), models.NewID()
+	behindName := "log_broadcaster:job:" + behindID.String()
+	nearlyName := "log_broadcaster:job:" + nearlyID.String()
+	newName := "log_broadcaster:job:" + newID.String()
+	orm := &logCursorORM{cursors: map[string]int64{behindName: 3, nearlyName: 8}}
+	lb := eth.NewLogBroadcaster(nil, orm, nil, 5, 0).(exportedCatchUpper)
+
+	addr := common.Address{1}
+	received := make(map[string][]uint64)
+	listener := func(name string, jobID *models.ID) *simpleLogListener {
+		return &simpleLogListener{
+			handler:    func(lb eth.LogBroadcast, err error) { received[name] = append(received[name], lb.RawLog().BlockNumber) },
+			consumerID: jobID,
+		}
+	}
+	lb.ExportedOnAddListener(addr, nil, listener(behindName, behindID))
+	lb.ExportedOnAddListener(addr, nil, listener(nearlyName, nearlyID))
+	lb.ExportedOnAddListener(addr, nil, listener(newName, newID))
+	lb.ExportedOnAddListener(addr, nil, listener("anonymous", nil))
+
+	// Logs are only caught up for the listeners whose cursors are behind them
+	lb.ExportedStartCatchUp(3, 9, map[string]int64{behindName: 3, nearlyName: 8})
+	lb.ExportedOnCatchUpLog(types.Log{Address: addr, BlockNumber: 5})
+	assert.Equal(t, map[string][]uint64{behindName: {5}}, received)
+
+	// Cursors of listeners being caught up don't pass the logs delivered so far
+	lb.ExportedAdvanceLogCursors(headChain(20, 0, 0))
+	assert.Equal(t, int64(4), orm.cursors[behindName])
+	assert.Equal(t, int64(8), orm.cursors[nearlyName])
+	assert.Equal(t, int64(15), orm.cursors[newName])
+
+	lb.ExportedOnCatchUpLog(types.Log{Address: addr, BlockNumber: 9})
+	assert.Equal(t, map[string][]uint64{behindName: {5, 9}, nearlyName: {9}}, received)
+	lb.ExportedAdvanceLogCursors(headChain(20, 0, 0))
+	assert.Equal(t, int64(8), orm.cursors[behindName])
+	assert.Equal(t, int64(8), orm.cursors[nearlyName])
+
+	lb.ExportedFinishCatchUp()
+	lb.ExportedAdvanceLogCursors(headChain(20, 0, 0))
+	assert.Equal(t, int64(15), orm.cursors[behindName])
+	assert.Equal(t, int64(15), orm.cursors[nearlyName])
+}
+
 func TestLogBroadcaster_InjectsLogConsumptionRecordFunctions(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -718,7 +1127,7 @@ func TestLogBroadcaster_InjectsLogConsumptionRecordFunctions(t *testing.T) {
 	sub.On("Err").Return(nil)
 	sub.On("Unsubscribe").Return()
 
-	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.Config.BlockBackfillDepth())
+	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 
 	lb.Start()
 
@@ -772,7 +1181,7 @@ func TestLogBroadcaster_ProcessesLogsFromReorgs(t *testing.T) {
 	sub.On("Unsubscribe").Return()
 	sub.On("Err").Return(nil)
 
-	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.Config.BlockBackfillDepth())
+	lb := eth.NewLogBroadcaster(store.EthClient, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 	lb.Start()
 
 	blockHash0 := cltest.NewHash()
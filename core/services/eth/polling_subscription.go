@@ -0,0 +1,189 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+// DefaultPollingInterval is how often a client connected over http(s) polls
+// for new heads and logs, if no other interval is configured
+const DefaultPollingInterval = 5 * time.Second
+
+const (
+	// maxPollFailures is the number of polls in a row that may fail before
+	// the subscription fails with the last error
+	maxPollFailures = 3
+	// maxPollLogsBlockRange is the largest range of blocks that logs are
+	// requested for in one `eth_getLogs` call
+	maxPollLogsBlockRange = 1000
+)
+
+// pollingSubscription stands in for an `eth_subscribe` subscription on nodes
+// that can only be reached over http(s).  It calls poll on every tick until
+// it is unsubscribed.  A failed poll is logged and retried on the next tick.
+// If maxPollFailures polls fail in a row, polling stops and the last error is
+// sent on Err, as a dropped websocket subscription would do, so that the
+// subscriber resubscribes.
+type pollingSubscription struct {
+	chErr    chan error
+	chStop   chan struct{}
+	wgDone   sync.WaitGroup
+	stopOnce sync.Once
+}
+
+var _ ethereum.Subscription = (*pollingSubscription)(nil)
+
+func newPollingSubscription(interval time.Duration, poll func(ctx context.Context) error) *pollingSubscription {
+	sub := &pollingSubscription{
+		chErr:  make(chan error, 1),
+		chStop: make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	sub.wgDone.Add(1)
+	go func() {
+		defer sub.wgDone.Done()
+		defer cancel()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var failures int
+		for {
+			select {
+			case <-ticker.C:
+				err := poll(ctx)
+				if err == nil || ctx.Err() != nil {
+					failures = 0
+					continue
+				}
+				failures++
+				if failures < maxPollFailures {
+					logger.Warnw("eth.Client: polling failed", "error", err, "failures", failures)
+					continue
+				}
+				logger.Errorw("eth.Client: polling failed too many times in a row, ending subscription", "error", err, "failures", failures)
+				sub.chErr <- err
+				return
+			case <-sub.chStop:
+				return
+			}
+		}
+	}()
+	go func() {
+		<-sub.chStop
+		cancel()
+	}()
+	return sub
+}
+
+// Err returns a channel that receives an error if polling keeps failing, and
+// is closed when the subscription is unsubscribed
+func (sub *pollingSubscription) Err() <-chan error {
+	return sub.chErr
+}
+
+// Unsubscribe stops polling and waits for any poll in progress to finish
+func (sub *pollingSubscription) Unsubscribe() {
+	sub.stopOnce.Do(func() {
+		close(sub.chStop)
+		sub.wgDone.Wait()
+		close(sub.chErr)
+	})
+}
+
+// blockNumber returns the number of the most recent block
+func (client *client) blockNumber(ctx context.Context) (int64, error) {
+	var number hexutil.Big
+	if err := client.RPCClient.CallContext(ctx, &number, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return number.ToInt().Int64(), nil
+}
+
+// pollNewHeads sends each new head to ch.  Only the latest head is sent if
+// several blocks were mined since the last poll, as the head tracker fetches
+// any missing parents itself.
+func (client *client) pollNewHeads(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
+	latest, err := client.blockNumber(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch latest block number")
+	}
+
+	sub := newPollingSubscription(client.pollingInterval, func(ctx context.Context) error {
+		number, err := client.blockNumber(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not fetch latest block number")
+		} else if number <= latest {
+			return nil
+		}
+
+		head, err := client.HeaderByNumber(ctx, big.NewInt(number))
+		if err != nil {
+			return errors.Wrapf(err, "could not fetch block %v", number)
+		}
+		select {
+		case ch <- head:
+			latest = number
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	return sub, nil
+}
+
+// pollFilterLogs sends the logs matching q from each new block to ch, using
+// `eth_getLogs` over the range of blocks mined since the last poll, at most
+// maxPollLogsBlockRange blocks at a time.  Unlike a websocket subscription,
+// logs that are later reorged out are not resent with Removed set.
+func (client *client) pollFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var next int64
+	if q.FromBlock != nil {
+		next = q.FromBlock.Int64()
+	} else {
+		latest, err := client.blockNumber(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not fetch latest block number")
+		}
+		next = latest + 1
+	}
+
+	sub := newPollingSubscription(client.pollingInterval, func(ctx context.Context) error {
+		latest, err := client.blockNumber(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not fetch latest block number")
+		} else if latest < next {
+			return nil
+		}
+
+		for next <= latest {
+			to := next + maxPollLogsBlockRange - 1
+			if to > latest {
+				to = latest
+			}
+			q.FromBlock, q.ToBlock = big.NewInt(next), big.NewInt(to)
+			logs, err := client.GethClient.FilterLogs(ctx, q)
+			if err != nil {
+				return errors.Wrapf(err, "could not fetch logs from block %v to %v", next, to)
+			}
+			for _, log := range logs {
+				select {
+				case ch <- log:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			next = to + 1
+		}
+		return nil
+	})
+	return sub, nil
+}
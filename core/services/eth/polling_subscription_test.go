@@ -0,0 +1,157 @@
+package eth_test
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+func newPollingClient(blockNumber *int64) (*mocks.RPCClient, *mocks.GethClient, eth.Client) {
+	rpcClient := new(mocks.RPCClient)
+	gethClient := new(mocks.GethClient)
+	rpcClient.On("CallContext", mock.Anything, mock.Anything, "eth_blockNumber").Run(func(args mock.Arguments) {
+		*args.Get(1).(*hexutil.Big) = hexutil.Big(*big.NewInt(atomic.LoadInt64(blockNumber)))
+	}).Return(nil)
+
+	c := eth.NewClientWith(rpcClient, gethClient)
+	c.ExportedSetPollingInterval(10 * time.Millisecond)
+	return rpcClient, gethClient, c
+}
+
+func TestClient_HTTPPolling_SubscribeNewHead(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := int64(10)
+	rpcClient, _, c := newPollingClient(&blockNumber)
+	rpcClient.On("CallContext", mock.Anything, mock.Anything, "eth_getBlockByNumber", "0xc", false).Run(func(args mock.Arguments) {
+		*args.Get(1).(**models.Head) = &models.Head{Number: 12}
+	}).Return(nil).Once()
+
+	ch := make(chan *models.Head)
+	sub, err := c.SubscribeNewHead(context.Background(), ch)
+	require.NoError(t, err)
+
+	// Only the latest head is sent when several blocks have been mined
+	atomic.StoreInt64(&blockNumber, 12)
+	select {
+	case head := <-ch:
+		assert.Equal(t, int64(12), head.Number)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head was received")
+	}
+
+	sub.Unsubscribe()
+	_, open := <-sub.Err()
+	assert.False(t, open)
+	rpcClient.AssertExpectations(t)
+}
+
+func TestClient_HTTPPolling_SubscribeFilterLogs(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := int64(10)
+	_, gethClient, c := newPollingClient(&blockNumber)
+
+	addr := common.Address{1}
+	gethClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Int64() == 11 && q.ToBlock.Int64() == 12 && q.Addresses[0] == addr
+	})).Return([]types.Log{{BlockNumber: 11}, {BlockNumber: 12}}, nil).Once()
+	gethClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Int64() == 13 && q.ToBlock.Int64() == 13
+	})).Return([]types.Log{{BlockNumber: 13}}, nil).Once()
+
+	ch := make(chan types.Log)
+	sub, err := c.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{addr}}, ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receive := func() types.Log {
+		select {
+		case log := <-ch:
+			return log
+		case <-time.After(5 * time.Second):
+			t.Fatal("no log was received")
+		}
+		return types.Log{}
+	}
+
+	atomic.StoreInt64(&blockNumber, 12)
+	assert.Equal(t, uint64(11), receive().BlockNumber)
+	assert.Equal(t, uint64(12), receive().BlockNumber)
+
+	atomic.StoreInt64(&blockNumber, 13)
+	assert.Equal(t, uint64(13), receive().BlockNumber)
+
+	sub.Unsubscribe()
+	gethClient.AssertExpectations(t)
+}
+
+func TestClient_HTTPPolling_SubscribeFilterLogs_LimitsBlockRange(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := int64(10)
+	_, gethClient, c := newPollingClient(&blockNumber)
+
+	for _, r := range [][2]int64{{11, 1010}, {1011, 2010}, {2011, 2500}} {
+		r := r
+		gethClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+			return q.FromBlock.Int64() == r[0] && q.ToBlock.Int64() == r[1]
+		})).Return([]types.Log{{BlockNumber: uint64(r[1])}}, nil).Once()
+	}
+
+	ch := make(chan types.Log)
+	sub, err := c.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{}, ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	atomic.StoreInt64(&blockNumber, 2500)
+	for _, expected := range []uint64{1010, 2010, 2500} {
+		select {
+		case log := <-ch:
+			assert.Equal(t, expected, log.BlockNumber)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no log was received")
+		}
+	}
+
+	sub.Unsubscribe()
+	gethClient.AssertExpectations(t)
+}
+
+func TestClient_HTTPPolling_SurfacesErrors(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := int64(10)
+	_, gethClient, c := newPollingClient(&blockNumber)
+	gethClient.On("FilterLogs", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+
+	ch := make(chan types.Log)
+	sub, err := c.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{}, ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	atomic.StoreInt64(&blockNumber, 11)
+	select {
+	case err := <-sub.Err():
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error was received")
+	}
+	gethClient.AssertNumberOfCalls(t, "FilterLogs", 3)
+}
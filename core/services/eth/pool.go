@@ -0,0 +1,657 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+)
+
+var (
+	promNodeHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_node_healthy",
+		Help: "Whether each Ethereum node passed its latest health check (1) or not (0)",
+	},
+		[]string{"node"},
+	)
+	promNodeActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_node_active",
+		Help: "Whether each primary Ethereum node is the one currently serving subscriptions and reads",
+	},
+		[]string{"node"},
+	)
+	promNodeLatestBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eth_node_latest_block",
+		Help: "The latest block number reported by each primary Ethereum node",
+	},
+		[]string{"node"},
+	)
+	promNodeFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "eth_node_failovers",
+		Help: "The number of times the active Ethereum node was switched because it became unhealthy",
+	})
+)
+
+// NodeState is the result of an Ethereum node's latest health check
+type NodeState string
+
+const (
+	// NodeStateUndialed means the node has not been checked yet
+	NodeStateUndialed NodeState = "undialed"
+	// NodeStateAlive means the node passed its latest health check
+	NodeStateAlive NodeState = "alive"
+	// NodeStateUnreachable means the node could not be dialed or did not
+	// respond
+	NodeStateUnreachable NodeState = "unreachable"
+	// NodeStateOutOfSync means the node is syncing, or its latest block is
+	// older than ETH_NODE_MAX_HEAD_AGE
+	NodeStateOutOfSync NodeState = "outOfSync"
+	// NodeStateInvalidChainID means the node is on a different chain to the
+	// one configured with ETH_CHAIN_ID
+	NodeStateInvalidChainID NodeState = "invalidChainID"
+)
+
+// NodeStatus describes the health of one of the nodes in a Pool
+type NodeStatus struct {
+	Name              string    `json:"name"`
+	SendOnly          bool      `json:"sendOnly"`
+	Active            bool      `json:"active"`
+	State             NodeState `json:"state"`
+	LatestBlockNumber int64     `json:"latestBlockNumber"`
+	LatestBlockAt     time.Time `json:"latestBlockAt"`
+	CheckedAt         time.Time `json:"checkedAt"`
+	Error             string    `json:"error"`
+}
+
+// GetID returns the jsonapi ID, which is the node's name
+func (ns NodeStatus) GetID() string {
+	return ns.Name
+}
+
+// GetName returns the jsonapi type name
+func (ns NodeStatus) GetName() string {
+	return "ethNodes"
+}
+
+// SetID is used to set the jsonapi ID
+func (ns *NodeStatus) SetID(value string) error {
+	ns.Name = value
+	return nil
+}
+
+// NodeStatusReporter is implemented by clients that talk to more than one
+// Ethereum node
+type NodeStatusReporter interface {
+	NodeStatuses() []NodeStatus
+}
+
+// PoolConfig holds the settings used by a Pool's health checks
+type PoolConfig struct {
+	ChainID             *big.Int
+	HealthCheckInterval time.Duration
+	MaxHeadAge          time.Duration
+	// PollingInterval is how often primary nodes that are reached over
+	// http(s) are polled for new heads and logs
+	PollingInterval time.Duration
+}
+
+type node struct {
+	client   Client
+	name     string
+	sendOnly bool
+	status   NodeStatus
+	mu       sync.RWMutex
+
+	// checkMu serializes health checks, so that the status can still be read
+	// while a check is waiting on the network
+	dialed  bool
+	checkMu sync.Mutex
+}
+
+// Pool is a Client backed by several Ethereum nodes.  One healthy primary
+// node at a time serves subscriptions and reads.  Primary nodes that are
+// reached over http(s) rather than websocket are polled for new heads and
+// logs.  Transactions are broadcast to every healthy node, including the
+// send-only (http) nodes.  If the active node fails its health check, the
+// pool switches to another healthy primary node and closes any open
+// subscriptions with an error, so that subscribers resubscribe to the new
+// node.
+type Pool struct {
+	primaries []*node
+	sendOnlys []*node
+	config    PoolConfig
+
+	active   *node
+	activeMu sync.RWMutex
+
+	subscriptions   map[*poolSubscription]struct{}
+	subscriptionsMu sync.Mutex
+
+	chStop chan struct{}
+	wgDone sync.WaitGroup
+}
+
+var _ Client = (*Pool)(nil)
+var _ NodeStatusReporter = (*Pool)(nil)
+
+// NewPool returns a Pool of the given primary (ws or http) and send-only
+// (http) nodes
+func NewPool(primaryURLs []string, sendOnlyURLs []string, config PoolConfig) (*Pool, error) {
+	if len(primaryURLs) == 0 {
+		return nil, errors.New("at least one primary ethereum url is required")
+	}
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = 15 * time.Second
+	}
+
+	p := &Pool{
+		config:        config,
+		subscriptions: make(map[*poolSubscription]struct{}),
+		chStop:        make(chan struct{}),
+	}
+	for _, u := range primaryURLs {
+		n, err := newNode(u, false, config.PollingInterval)
+		if err != nil {
+			return nil, err
+		}
+		p.primaries = append(p.primaries, n)
+	}
+	for _, u := range sendOnlyURLs {
+		n, err := newNode(u, true, 0)
+		if err != nil {
+			return nil, err
+		}
+		p.sendOnlys = append(p.sendOnlys, n)
+	}
+	return p, nil
+}
+
+// NewPoolWith returns a Pool of the given clients.  This alternate constructor
+// exists for testing purposes.
+func NewPoolWith(primaries []Client, sendOnlys []Client, config PoolConfig) *Pool {
+	p := &Pool{
+		config:        config,
+		subscriptions: make(map[*poolSubscription]struct{}),
+		chStop:        make(chan struct{}),
+	}
+	if p.config.HealthCheckInterval <= 0 {
+		p.config.HealthCheckInterval = 15 * time.Second
+	}
+	for i, c := range primaries {
+		name := fmt.Sprintf("primary-%d", i)
+		p.primaries = append(p.primaries, &node{client: c, name: name, status: NodeStatus{Name: name, State: NodeStateUndialed}})
+	}
+	for i, c := range sendOnlys {
+		name := fmt.Sprintf("sendonly-%d", i)
+		p.sendOnlys = append(p.sendOnlys, &node{client: c, name: name, sendOnly: true, status: NodeStatus{Name: name, SendOnly: true, State: NodeStateUndialed}})
+	}
+	return p
+}
+
+func newNode(rawURL string, sendOnly bool, pollingInterval time.Duration) (*node, error) {
+	parsed, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	isHTTP := parsed.Scheme == "http" || parsed.Scheme == "https"
+	if sendOnly && !isHTTP {
+		return nil, errors.Errorf("secondary ethereum rpc url scheme must be http(s): %s", redactURL(parsed))
+	} else if !sendOnly && !isHTTP && parsed.Scheme != "ws" && parsed.Scheme != "wss" {
+		return nil, errors.Errorf("ethereum url scheme must be websocket or http(s): %s", redactURL(parsed))
+	}
+	c := &client{url: rawURL}
+	if !sendOnly && isHTTP {
+		c.pollingInterval = pollingInterval
+		if c.pollingInterval <= 0 {
+			c.pollingInterval = DefaultPollingInterval
+		}
+	}
+	name := redactURL(parsed)
+	return &node{
+		client:   c,
+		name:     name,
+		sendOnly: sendOnly,
+		status:   NodeStatus{Name: name, SendOnly: sendOnly, State: NodeStateUndialed},
+	}, nil
+}
+
+// redactURL strips any credentials, path and query from a node's URL, since
+// these often contain API keys
+func redactURL(u *url.URL) string {
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+// Dial connects to every node, chooses the first healthy primary node as the
+// active node and starts the periodic health checks.  If no primary node is
+// healthy, the first one that is reachable but out of sync is used.
+func (p *Pool) Dial(ctx context.Context) error {
+	logger.Debugw("eth.Pool#Dial(...)")
+	p.checkAll(ctx)
+
+	p.activeMu.Lock()
+	for _, n := range p.primaries {
+		if n.State() == NodeStateAlive {
+			p.setActive(n)
+			break
+		}
+	}
+	if p.active == nil {
+		// A node that is still syncing is better than none at all, and the
+		// health checks will switch away from it once another node is healthy
+		for _, n := range p.primaries {
+			if n.State() == NodeStateOutOfSync {
+				logger.Warnw(fmt.Sprintf("eth.Pool: no healthy primary ethereum node is available, using out of sync node %s", n.name), "node", n.name)
+				p.setActive(n)
+				break
+			}
+		}
+	}
+	active := p.active
+	p.activeMu.Unlock()
+	if active == nil {
+		return errors.New("no reachable primary ethereum node on the configured chain is available")
+	}
+
+	p.wgDone.Add(1)
+	go p.runHealthChecks()
+	return nil
+}
+
+// Close stops the health checks and closes the connections to all nodes
+func (p *Pool) Close() {
+	close(p.chStop)
+	p.wgDone.Wait()
+	for _, n := range p.allNodes() {
+		n.checkMu.Lock()
+		if n.dialed {
+			n.client.Close()
+		}
+		n.checkMu.Unlock()
+	}
+}
+
+func (p *Pool) allNodes() []*node {
+	nodes := make([]*node, 0, len(p.primaries)+len(p.sendOnlys))
+	nodes = append(nodes, p.primaries...)
+	return append(nodes, p.sendOnlys...)
+}
+
+// NodeStatuses returns the status of every node in the pool
+func (p *Pool) NodeStatuses() []NodeStatus {
+	var statuses []NodeStatus
+	active := p.activeNode()
+	for _, n := range p.allNodes() {
+		status := n.Status()
+		status.Active = n == active
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (p *Pool) runHealthChecks() {
+	defer p.wgDone.Done()
+
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.chStop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), p.config.HealthCheckInterval)
+			p.CheckHealth(ctx)
+			cancel()
+		}
+	}
+}
+
+// CheckHealth checks every node immediately, and switches the active node if
+// it is no longer healthy
+func (p *Pool) CheckHealth(ctx context.Context) {
+	p.checkAll(ctx)
+	p.failoverIfUnhealthy()
+}
+
+func (p *Pool) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, n := range p.allNodes() {
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			n.check(ctx, p.config)
+		}(n)
+	}
+	wg.Wait()
+}
+
+// failoverIfUnhealthy switches to the first healthy primary node if the
+// active node failed its latest health check.  The active node is kept while
+// it is healthy, even if a preferred node recovers, to avoid flapping.
+func (p *Pool) failoverIfUnhealthy() {
+	p.activeMu.Lock()
+	defer p.activeMu.Unlock()
+
+	if p.active != nil && p.active.State() == NodeStateAlive {
+		return
+	}
+	for _, n := range p.primaries {
+		if n != p.active && n.State() == NodeStateAlive {
+			previous := p.active
+			p.setActive(n)
+			promNodeFailovers.Inc()
+			logger.Warnw(fmt.Sprintf("eth.Pool: switched active node from %s to %s", previous.name, n.name),
+				"previous", previous.name,
+				"previousState", previous.State(),
+				"active", n.name,
+			)
+			p.closeSubscriptions(errors.Errorf("eth node %s is unhealthy, switched to %s", previous.name, n.name))
+			return
+		}
+	}
+	logger.Errorw("eth.Pool: no healthy primary ethereum node is available", "active", p.active.name)
+}
+
+// setActive must be called with activeMu held
+func (p *Pool) setActive(n *node) {
+	if p.active != nil {
+		promNodeActive.WithLabelValues(p.active.name).Set(0)
+	}
+	p.active = n
+	promNodeActive.WithLabelValues(n.name).Set(1)
+}
+
+func (p *Pool) activeNode() *node {
+	p.activeMu.RLock()
+	defer p.activeMu.RUnlock()
+	return p.active
+}
+
+// activeClient returns the client of the node that serves subscriptions and
+// reads
+func (p *Pool) activeClient() Client {
+	n := p.activeNode()
+	if n == nil {
+		// Dial has not been called, or failed.  Fall back to the first node so
+		// that callers get a meaningful connection error.
+		return p.primaries[0].client
+	}
+	return n.client
+}
+
+func (n *node) State() NodeState {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.status.State
+}
+
+func (n *node) Status() NodeStatus {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.status
+}
+
+// check dials the node if necessary and records whether it is on the right
+// chain, not syncing, and (for primary nodes) has a recent head
+func (n *node) check(ctx context.Context, config PoolConfig) {
+	n.checkMu.Lock()
+	defer n.checkMu.Unlock()
+
+	state, head, err := n.checkState(ctx, config)
+
+	n.mu.Lock()
+	n.status.State = state
+	n.status.CheckedAt = time.Now()
+	n.status.Error = ""
+	if err != nil {
+		n.status.Error = err.Error()
+	}
+	if head != nil {
+		n.status.LatestBlockNumber = head.Number
+		n.status.LatestBlockAt = head.Timestamp
+	}
+	n.mu.Unlock()
+
+	if err != nil {
+		logger.Warnw(fmt.Sprintf("eth.Pool: node %s failed health check", n.name),
+			"node", n.name,
+			"state", state,
+			"error", err,
+		)
+	}
+	if head != nil {
+		promNodeLatestBlock.WithLabelValues(n.name).Set(float64(head.Number))
+	}
+	healthy := float64(0)
+	if state == NodeStateAlive {
+		healthy = 1
+	}
+	promNodeHealthy.WithLabelValues(n.name).Set(healthy)
+}
+
+// checkState must be called with n.checkMu held
+func (n *node) checkState(ctx context.Context, config PoolConfig) (NodeState, *models.Head, error) {
+	if !n.dialed {
+		if err := n.client.Dial(ctx); err != nil {
+			return NodeStateUnreachable, nil, err
+		}
+		n.dialed = true
+	}
+
+	chainID, err := n.client.ChainID(ctx)
+	if err != nil {
+		return NodeStateUnreachable, nil, err
+	} else if config.ChainID != nil && chainID.Cmp(config.ChainID) != 0 {
+		return NodeStateInvalidChainID, nil, errors.Errorf("node is on chain %s, expected chain %s", chainID, config.ChainID)
+	}
+	if n.sendOnly {
+		return NodeStateAlive, nil, nil
+	}
+
+	var syncing interface{}
+	if err = n.client.CallContext(ctx, &syncing, "eth_syncing"); err != nil {
+		return NodeStateUnreachable, nil, err
+	} else if isSyncing, is := syncing.(bool); !is || isSyncing {
+		return NodeStateOutOfSync, nil, errors.New("node is syncing")
+	}
+
+	head, err := n.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return NodeStateUnreachable, nil, err
+	} else if head == nil {
+		return NodeStateUnreachable, nil, errors.New("node returned no latest head")
+	}
+	if age := time.Since(head.Timestamp); config.MaxHeadAge > 0 && age > config.MaxHeadAge {
+		return NodeStateOutOfSync, head, errors.Errorf("latest block %v is %s old", head.Number, age)
+	}
+	return NodeStateAlive, head, nil
+}
+
+// poolSubscription wraps a subscription to the active node, so that it can be
+// closed with an error if the pool switches to a different node
+type poolSubscription struct {
+	ethereum.Subscription
+	pool   *Pool
+	chErr  chan error
+	chDone chan struct{}
+	once   sync.Once
+}
+
+func (p *Pool) newSubscription(sub ethereum.Subscription) *poolSubscription {
+	ps := &poolSubscription{
+		Subscription: sub,
+		pool:         p,
+		chErr:        make(chan error, 1),
+		chDone:       make(chan struct{}),
+	}
+	p.subscriptionsMu.Lock()
+	p.subscriptions[ps] = struct{}{}
+	p.subscriptionsMu.Unlock()
+
+	go func() {
+		select {
+		case err, open := <-sub.Err():
+			if open {
+				ps.sendErr(err)
+			}
+		case <-ps.chDone:
+		}
+	}()
+	return ps
+}
+
+func (ps *poolSubscription) Err() <-chan error {
+	return ps.chErr
+}
+
+func (ps *poolSubscription) Unsubscribe() {
+	ps.once.Do(func() {
+		close(ps.chDone)
+		ps.Subscription.Unsubscribe()
+		ps.pool.subscriptionsMu.Lock()
+		delete(ps.pool.subscriptions, ps)
+		ps.pool.subscriptionsMu.Unlock()
+	})
+}
+
+func (ps *poolSubscription) sendErr(err error) {
+	select {
+	case ps.chErr <- err:
+	default:
+	}
+}
+
+// closeSubscriptions fails every open subscription with err
+func (p *Pool) closeSubscriptions(err error) {
+	p.subscriptionsMu.Lock()
+	defer p.subscriptionsMu.Unlock()
+	for ps := range p.subscriptions {
+		ps.sendErr(err)
+		ps.Subscription.Unsubscribe()
+	}
+}
+
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	sub, err := p.activeClient().SubscribeFilterLogs(ctx, q, ch)
+	if err != nil {
+		return nil, err
+	}
+	return p.newSubscription(sub), nil
+}
+
+func (p *Pool) SubscribeNewHead(ctx context.Context, ch chan<- *models.Head) (ethereum.Subscription, error) {
+	sub, err := p.activeClient().SubscribeNewHead(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	return p.newSubscription(sub), nil
+}
+
+// SendTransaction sends the transaction to the active node, and also
+// broadcasts it to every other healthy node
+func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	active := p.activeNode()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, n := range p.allNodes() {
+		if n == active || n.State() != NodeStateAlive {
+			continue
+		}
+		wg.Add(1)
+		go func(n *node) {
+			defer wg.Done()
+			err := NewSendError(n.client.SendTransaction(ctx, tx))
+			if err == nil || err.IsNonceTooLowError() || err.IsTransactionAlreadyInMempool() {
+				// Nonce too low or transaction known errors are expected since
+				// the active node's SendTransaction may well have succeeded
+				// already
+				return
+			}
+			logger.Warnw("eth.Pool: secondary eth node returned error", "node", n.name, "err", err, "tx", tx)
+		}(n)
+	}
+
+	return p.activeClient().SendTransaction(ctx, tx)
+}
+
+func (p *Pool) GetERC20Balance(address common.Address, contractAddress common.Address) (*big.Int, error) {
+	return p.activeClient().GetERC20Balance(address, contractAddress)
+}
+
+func (p *Pool) SendRawTx(bytes []byte) (common.Hash, error) {
+	return p.activeClient().SendRawTx(bytes)
+}
+
+func (p *Pool) Call(result interface{}, method string, args ...interface{}) error {
+	return p.activeClient().Call(result, method, args...)
+}
+
+func (p *Pool) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return p.activeClient().CallContext(ctx, result, method, args...)
+}
+
+func (p *Pool) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return p.activeClient().BatchCallContext(ctx, b)
+}
+
+func (p *Pool) HeaderByNumber(ctx context.Context, n *big.Int) (*models.Head, error) {
+	return p.activeClient().HeaderByNumber(ctx, n)
+}
+
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return p.activeClient().ChainID(ctx)
+}
+
+func (p *Pool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return p.activeClient().PendingCodeAt(ctx, account)
+}
+
+func (p *Pool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return p.activeClient().PendingNonceAt(ctx, account)
+}
+
+func (p *Pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return p.activeClient().TransactionReceipt(ctx, txHash)
+}
+
+func (p *Pool) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return p.activeClient().BlockByNumber(ctx, number)
+}
+
+func (p *Pool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return p.activeClient().BalanceAt(ctx, account, blockNumber)
+}
+
+func (p *Pool) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return p.activeClient().FilterLogs(ctx, q)
+}
+
+func (p *Pool) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return p.activeClient().EstimateGas(ctx, call)
+}
+
+func (p *Pool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return p.activeClient().SuggestGasPrice(ctx)
+}
+
+func (p *Pool) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return p.activeClient().CallContract(ctx, msg, blockNumber)
+}
+
+func (p *Pool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return p.activeClient().CodeAt(ctx, account, blockNumber)
+}
@@ -2,6 +2,7 @@ package fluxmonitor
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/ratelimit"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
@@ -34,6 +36,7 @@ type httpFetcher struct {
 	url         *url.URL
 	requestData map[string]interface{}
 	sizeLimit   int64
+	limits      ratelimit.Limits
 }
 
 func newHTTPFetcher(
@@ -41,6 +44,7 @@ func newHTTPFetcher(
 	requestData map[string]interface{},
 	url *url.URL,
 	sizeLimit int64,
+	limits ratelimit.Limits,
 ) Fetcher {
 	client := &http.Client{Timeout: timeout.Duration(), Transport: http.DefaultTransport}
 	client.Transport = promhttp.InstrumentRoundTripperDuration(promFMResponseTime, client.Transport)
@@ -51,6 +55,7 @@ func newHTTPFetcher(
 		url:         url,
 		requestData: requestData,
 		sizeLimit:   sizeLimit,
+		limits:      limits,
 	}
 }
 
@@ -61,7 +66,22 @@ func (p *httpFetcher) Fetch(meta map[string]interface{}) (decimal.Decimal, error
 		return decimal.Decimal{}, errors.Wrap(err, "error encoding request body as JSON")
 	}
 
-	r, err := p.client.Post(p.url.String(), "application/json", bytes.NewReader(body))
+	// The client timeout also bounds the time spent waiting for the outbound
+	// request limits
+	ctx, cancel := context.WithTimeout(context.Background(), p.client.Timeout)
+	defer cancel()
+	release, err := ratelimit.OutboundRequests.Acquire(ctx, p.url.Host, p.limits)
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s", p.url.String()))
+	}
+	defer release()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.url.String(), bytes.NewReader(body))
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrap(err, "error creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	r, err := p.client.Do(req)
 	if err != nil {
 		return decimal.Decimal{}, errors.Wrap(err, fmt.Sprintf("unable to fetch price from %s with payload '%s'", p.url.String(), p.requestData))
 	}
@@ -136,10 +156,11 @@ func newMedianFetcherFromURLs(
 	requestData map[string]interface{},
 	priceURLs []*url.URL,
 	sizeLimit int64,
+	limits ratelimit.Limits,
 ) (Fetcher, error) {
 	fetchers := []Fetcher{}
 	for _, url := range priceURLs {
-		ps := newHTTPFetcher(timeout, requestData, url, sizeLimit)
+		ps := newHTTPFetcher(timeout, requestData, url, sizeLimit, limits)
 		fetchers = append(fetchers, ps)
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/services/ratelimit"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
@@ -73,7 +74,7 @@ func TestNewMedianFetcherFromURLs_Happy(t *testing.T) {
 				urls = append(urls, newURL)
 			}
 
-			medianFetcher, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, 32768)
+			medianFetcher, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, 32768, ratelimit.Limits{})
 			require.NoError(t, err)
 
 			medianPrice, err := medianFetcher.Fetch(emptyMeta)
@@ -88,7 +89,7 @@ func TestNewMedianFetcherFromURLs_EmptyError(t *testing.T) {
 	defer s1.Close()
 	var urls []*url.URL
 
-	_, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, 32768)
+	_, err := newMedianFetcherFromURLs(defaultHTTPTimeout, ethUSDPairing, urls, 32768, ratelimit.Limits{})
 	require.Error(t, err)
 }
 
@@ -99,7 +100,7 @@ func TestHTTPFetcher_Happy(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(s1.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, btcUSDPairing, feedURL, 32768)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, btcUSDPairing, feedURL, 32768, ratelimit.Limits{})
 	price, err := fetcher.Fetch(emptyMeta)
 	require.NoError(t, err)
 	assert.Equal(t, decimal.NewFromInt(9700), price)
@@ -133,7 +134,7 @@ func TestHTTPFetcher_Meta(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(s1.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768, ratelimit.Limits{})
 	fetcher.Fetch(request)
 }
 
@@ -153,7 +154,7 @@ func TestHTTPFetcher_ErrorMessage(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(server.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768, ratelimit.Limits{})
 	price, err := fetcher.Fetch(emptyMeta)
 	assert.Error(t, err)
 	assert.Equal(t, decimal.NewFromInt(0).String(), price.String())
@@ -173,7 +174,7 @@ func TestHTTPFetcher_OnlyErrorMessage(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(server.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768, ratelimit.Limits{})
 	price, err := fetcher.Fetch(emptyMeta)
 	assert.Error(t, err)
 	assert.Equal(t, decimal.NewFromInt(0).String(), price.String())
@@ -192,7 +193,7 @@ func TestHTTPFetcher_NoResultNorErrorMessage(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(server.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768, ratelimit.Limits{})
 	price, err := fetcher.Fetch(emptyMeta)
 	assert.Error(t, err)
 	assert.True(t, decimal.NewFromInt(0).Equal(price))
@@ -336,6 +337,6 @@ func TestHTTPFetcher_AddsArbitraryRequestID(t *testing.T) {
 	feedURL, err := url.ParseRequestURI(s1.URL)
 	require.NoError(t, err)
 
-	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768)
+	fetcher := newHTTPFetcher(defaultHTTPTimeout, ethUSDPairing, feedURL, 32768, ratelimit.Limits{})
 	fetcher.Fetch(emptyMeta)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/eth/contracts"
+	"github.com/smartcontractkit/chainlink/core/services/ratelimit"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
@@ -263,7 +264,8 @@ func (f pollingDeviationCheckerFactory) New(
 		timeout,
 		requestData,
 		urls,
-		f.store.Config.DefaultHTTPLimit())
+		f.store.Config.DefaultHTTPLimit(),
+		ratelimit.LimitsFromConfig(f.store.Config))
 	if err != nil {
 		return nil, err
 	}
@@ -495,6 +497,13 @@ func (p *PollingDeviationChecker) JobID() *models.ID { return p.initr.JobSpecID
 func (p *PollingDeviationChecker) JobIDV2() int32    { return 0 }
 func (p *PollingDeviationChecker) IsV2Job() bool     { return false }
 
+// MinConfirmations holds back round, answer and flag logs until their block
+// has MinIncomingConfirmations confirmations, so that the checker never acts
+// on a log that is reorged out
+func (p *PollingDeviationChecker) MinConfirmations() uint64 {
+	return uint64(p.store.Config.MinIncomingConfirmations())
+}
+
 func (p *PollingDeviationChecker) HandleLog(broadcast eth.LogBroadcast, err error) {
 	if err != nil {
 		logger.Errorf("got error from LogBroadcaster: %v", err)
@@ -651,6 +660,17 @@ func (p *PollingDeviationChecker) processLogs() {
 			continue
 		}
 
+		// The log may have been reorged out while it waited in the backlog
+		rawLog := broadcast.RawLog()
+		if p.store.HeadChain.IsOrphaned(rawLog.BlockHash, int64(rawLog.BlockNumber)) {
+			logger.Debugw("Log is from a block that was reorged out, skipping",
+				"blockNumber", rawLog.BlockNumber,
+				"blockHash", rawLog.BlockHash,
+				"contract", p.initr.Address.Hex(),
+			)
+			continue
+		}
+
 		switch log := broadcast.DecodedLog().(type) {
 		case *contracts.LogNewRound:
 			p.respondToNewRoundLog(*log)
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/onsi/gomega"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -70,7 +71,7 @@ func TestConcreteFluxMonitor_AddJobRemoveJob(t *testing.T) {
 
 		checkerFactory := new(mocks.DeviationCheckerFactory)
 		checkerFactory.On("New", job.Initiators[0], mock.Anything, runManager, store.ORM, store.Config.DefaultHTTPTimeout()).Return(dc, nil)
-		lb := eth.NewLogBroadcaster(store.TxManager, store.ORM, store.Config.BlockBackfillDepth())
+		lb := eth.NewLogBroadcaster(store.TxManager, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 		require.NoError(t, lb.Start())
 		fm := fluxmonitor.New(store, runManager, lb)
 		fluxmonitor.ExportedSetCheckerFactory(fm, checkerFactory)
@@ -104,7 +105,7 @@ func TestConcreteFluxMonitor_AddJobRemoveJob(t *testing.T) {
 		job := cltest.NewJobWithRunLogInitiator()
 		runManager := new(mocks.RunManager)
 		checkerFactory := new(mocks.DeviationCheckerFactory)
-		lb := eth.NewLogBroadcaster(store.TxManager, store.ORM, store.Config.BlockBackfillDepth())
+		lb := eth.NewLogBroadcaster(store.TxManager, store.ORM, store.HeadChain, store.Config.BlockBackfillDepth(), store.Config.BlockBackfillBatchSize())
 		require.NoError(t, lb.Start())
 		fm := fluxmonitor.New(store, runManager, lb)
 		fluxmonitor.ExportedSetCheckerFactory(fm, checkerFactory)
@@ -446,6 +447,7 @@ func TestPollingDeviationChecker_BuffersLogs(t *testing.T) {
 		logBroadcast := new(mocks.LogBroadcast)
 		logBroadcast.On("DecodedLog").Return(&contracts.LogNewRound{RoundId: big.NewInt(int64(i)), StartedAt: big.NewInt(0)})
 		logBroadcast.On("WasAlreadyConsumed").Return(false, nil)
+		logBroadcast.On("RawLog").Return(types.Log{})
 		logBroadcast.On("MarkConsumed").Return(nil)
 		logBroadcasts = append(logBroadcasts, logBroadcast)
 	}
@@ -543,6 +545,7 @@ func TestPollingDeviationChecker_TriggerIdleTimeThreshold(t *testing.T) {
 				decodedLog := contracts.LogNewRound{RoundId: big.NewInt(2), StartedAt: big.NewInt(0)}
 				logBroadcast.On("DecodedLog").Return(&decodedLog)
 				logBroadcast.On("WasAlreadyConsumed").Return(false, nil).Once()
+				logBroadcast.On("RawLog").Return(types.Log{})
 				logBroadcast.On("MarkConsumed").Return(nil).Once()
 				deviationChecker.HandleLog(logBroadcast, nil)
 
@@ -690,6 +693,7 @@ func TestPollingDeviationChecker_RoundTimeoutCausesPoll_timesOutNotZero(t *testi
 
 	logBroadcast := new(mocks.LogBroadcast)
 	logBroadcast.On("WasAlreadyConsumed").Return(false, nil)
+	logBroadcast.On("RawLog").Return(types.Log{})
 	logBroadcast.On("DecodedLog").Return(&contracts.LogNewRound{RoundId: big.NewInt(0), StartedAt: big.NewInt(time.Now().UTC().Unix())})
 	logBroadcast.On("MarkConsumed").Return(nil)
 	deviationChecker.HandleLog(logBroadcast, nil)
@@ -1254,6 +1258,37 @@ func TestFluxMonitor_PollingDeviationChecker_HandlesNilLogs(t *testing.T) {
 	})
 }
 
+func TestPollingDeviationChecker_MinConfirmations(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("MIN_INCOMING_CONFIRMATIONS", 4)
+
+	p := cltest.NewPollingDeviationChecker(t, store)
+	var listener eth.LogListener = p
+	require.Implements(t, (*eth.ConfirmingLogListener)(nil), listener)
+	assert.Equal(t, uint64(4), listener.(eth.ConfirmingLogListener).MinConfirmations())
+
+	// The decoding listeners that the checker is registered through keep its
+	// confirmation depth, so that round, answer and flag logs are held back
+	hasMinConfirmations := mock.MatchedBy(func(l eth.LogListener) bool {
+		cl, ok := l.(eth.ConfirmingLogListener)
+		return ok && cl.MinConfirmations() == 4
+	})
+	logBroadcaster := new(mocks.LogBroadcaster)
+	logBroadcaster.On("RegisterWithTopics", mock.Anything, mock.Anything, hasMinConfirmations).Return(true).Once()
+	fluxAggregator, err := contracts.NewFluxAggregator(cltest.NewAddress(), store.EthClient, logBroadcaster)
+	require.NoError(t, err)
+	connected, _ := fluxAggregator.SubscribeToLogs(p)
+	assert.True(t, connected)
+	logBroadcaster.AssertExpectations(t)
+
+	flagsContract, err := contracts.NewFlagsContract(cltest.NewAddress(), store.EthClient)
+	require.NoError(t, err)
+	flagsListener := contracts.NewFlagsDecodingLogListener(flagsContract, p)
+	require.Implements(t, (*eth.ConfirmingLogListener)(nil), flagsListener)
+	assert.Equal(t, uint64(4), flagsListener.(eth.ConfirmingLogListener).MinConfirmations())
+}
+
 func TestFluxMonitor_ConsumeLogBroadcast_Happy(t *testing.T) {
 	store, cleanup := cltest.NewStore(t)
 	defer cleanup()
@@ -1265,6 +1300,7 @@ func TestFluxMonitor_ConsumeLogBroadcast_Happy(t *testing.T) {
 
 	logBroadcast := new(mocks.LogBroadcast)
 	logBroadcast.On("WasAlreadyConsumed").Return(false, nil).Once()
+	logBroadcast.On("RawLog").Return(types.Log{})
 	logBroadcast.On("DecodedLog").Return(&contracts.LogAnswerUpdated{})
 	logBroadcast.On("MarkConsumed").Return(nil).Once()
 
@@ -0,0 +1,273 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	promGasUpdaterAllPercentiles = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_updater_all_gas_percetiles",
+		Help: "Gas price at given percentile",
+	},
+		[]string{"percentile"},
+	)
+
+	promGasUpdaterSetGasPrice = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_updater_set_gas_price",
+		Help: "Gas updater set gas price (in Wei)",
+	},
+		[]string{"percentile"},
+	)
+
+	promGasUpdaterSetTipCap = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gas_updater_set_tip_cap",
+		Help: "Gas updater set EIP-1559 tip cap (in Wei)",
+	},
+		[]string{"percentile"},
+	)
+
+	promGasUpdaterSetFeeCap = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "gas_updater_set_fee_cap",
+		Help: "Gas updater set EIP-1559 fee cap (in Wei)",
+	})
+)
+
+// BlockHistoryEstimator listens for new heads and updates the base gas price
+// dynamically based on the configured percentile of gas prices in recent
+// blocks. Its estimates are limited to between GAS_UPDATER_MIN_GAS_PRICE_WEI
+// and GAS_UPDATER_MAX_GAS_PRICE_WEI, and it will not set a price above
+// ETH_MAX_GAS_PRICE_WEI. If GAS_UPDATER_ENABLED is false it keeps using the
+// configured defaults.
+type BlockHistoryEstimator interface {
+	Estimator
+	RollingBlockHistory() []*types.Block
+}
+
+type blockHistoryEstimator struct {
+	store                   *store.Store
+	rollingBlockHistory     []*types.Block
+	rollingFeeHistory       []feeHistoryBlock
+	rollingBlockHistorySize int
+	// HACK: blockDelay is the number of blocks that the gas updater trails behind head.
+	// E.g. if this is set to 3, and we receive block 10, gas updater will
+	// fetch block 7.
+	// This is necessary because geth/parity send heads as soon as they get
+	// them and often the actual block is not available until later. Fetching
+	// it too early results in an empty block.
+	blockDelay int64
+	percentile int
+}
+
+// NewBlockHistoryEstimator returns a new block history estimator.
+func NewBlockHistoryEstimator(store *store.Store) BlockHistoryEstimator {
+	bhe := &blockHistoryEstimator{
+		store:                   store,
+		rollingBlockHistory:     make([]*types.Block, 0),
+		rollingBlockHistorySize: int(store.Config.GasUpdaterBlockHistorySize()),
+		blockDelay:              int64(store.Config.GasUpdaterBlockDelay()),
+		percentile:              int(store.Config.GasUpdaterTransactionPercentile()),
+	}
+	return bhe
+}
+
+func (bhe *blockHistoryEstimator) Connect(bn *models.Head) error {
+	if bhe.store.Config.GasUpdaterEnabled() {
+		logger.Debugw("BlockHistoryEstimator: dynamic gas updates are enabled", "ethGasPriceDefault", bhe.store.Config.EthGasPriceDefault())
+	} else {
+		logger.Debugw("BlockHistoryEstimator: dynamic gas updating is disabled", "ethGasPriceDefault", bhe.store.Config.EthGasPriceDefault())
+	}
+	return nil
+}
+
+func (bhe *blockHistoryEstimator) Disconnect() {
+}
+
+// OnNewLongestChain recalculates and sets global gas price on every head
+func (bhe *blockHistoryEstimator) OnNewLongestChain(ctx context.Context, head models.Head) {
+	// Bail out as early as possible if the gas updater is disabled so we avoid
+	// any potential undesired side effects. Note that in a future iteration
+	// the GasUpdaterEnabled setting could be modifiable at runtime
+	if !bhe.store.Config.GasUpdaterEnabled() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, maxEthNodeRequestTime)
+	defer cancel()
+	blockToFetch := head.Number - bhe.blockDelay
+	if blockToFetch < 0 {
+		logger.Warnf("BlockHistoryEstimator: skipping gas calculation, current block height %v is lower than GAS_UPDATER_BLOCK_DELAY of %v", head.Number, bhe.blockDelay)
+		return
+	}
+	if bhe.store.Config.EthEIP1559DynamicFees() {
+		bhe.updateDynamicFees(ctx, blockToFetch)
+		return
+	}
+	block, err := bhe.store.EthClient.BlockByNumber(ctx, big.NewInt(blockToFetch))
+	if err != nil {
+		logger.Errorf("BlockHistoryEstimator: error retrieving block %v: %s", blockToFetch, err)
+		return
+	}
+	if len(block.Transactions()) > 0 {
+		bhe.rollingBlockHistory = append(bhe.rollingBlockHistory, block)
+		if len(bhe.rollingBlockHistory) > bhe.rollingBlockHistorySize {
+			bhe.rollingBlockHistory = bhe.rollingBlockHistory[1:]
+			percentileGasPrice := bhe.percentileGasPrice()
+			err := bhe.setPercentileGasPrice(percentileGasPrice)
+			if err != nil {
+				logger.Error("BlockHistoryEstimator error setting gas price: ", err)
+				return
+			}
+			promGasUpdaterSetGasPrice.WithLabelValues(fmt.Sprintf("%v%%", bhe.percentile)).Set(float64(percentileGasPrice))
+		} else {
+			logger.Debugw(fmt.Sprintf("BlockHistoryEstimator: waiting for blocks: %v/%v", len(bhe.rollingBlockHistory), bhe.rollingBlockHistorySize), "inHistory", len(bhe.rollingBlockHistory), "required", bhe.rollingBlockHistorySize)
+		}
+	} else {
+		logger.Debugw(fmt.Sprintf("BlockHistoryEstimator: skipping empty block: %v", blockToFetch), "blockNumber", blockToFetch)
+	}
+}
+
+func (bhe *blockHistoryEstimator) percentileGasPrice() int64 {
+	gasPrices := make([]int64, 0)
+	for _, block := range bhe.rollingBlockHistory {
+		for _, tx := range block.Transactions() {
+			gasPrices = append(gasPrices, tx.GasPrice().Int64())
+		}
+	}
+	sort.Slice(gasPrices, func(i, j int) bool { return gasPrices[i] < gasPrices[j] })
+	idx := ((len(gasPrices) - 1) * bhe.percentile) / 100
+	for i := 0; i <= 100; i += 5 {
+		jdx := ((len(gasPrices) - 1) * i) / 100
+		promGasUpdaterAllPercentiles.WithLabelValues(fmt.Sprintf("%v%%", i)).Set(float64(gasPrices[jdx]))
+	}
+	return gasPrices[idx]
+}
+
+func (bhe *blockHistoryEstimator) setPercentileGasPrice(gasPrice int64) error {
+	gasPriceGwei := fmt.Sprintf("%.2f", float64(gasPrice)/1000000000)
+	bigGasPrice := big.NewInt(gasPrice)
+	if bigGasPrice.Cmp(bhe.store.Config.EthMaxGasPriceWei()) > 0 {
+		return fmt.Errorf("cannot set gas price %s because it exceeds EthMaxGasPriceWei %s", bigGasPrice.String(), bhe.store.Config.EthMaxGasPriceWei().String())
+	}
+	logger.Debugw(fmt.Sprintf("BlockHistoryEstimator: setting new default gas price: %v Gwei", gasPriceGwei), "gasPriceWei", gasPrice, "gasPriceGWei", gasPriceGwei)
+	return bhe.store.Config.SetEthGasPriceDefault(bigGasPrice)
+}
+
+func (bhe *blockHistoryEstimator) RollingBlockHistory() []*types.Block {
+	return bhe.rollingBlockHistory
+}
+
+func (bhe *blockHistoryEstimator) EstimateGasPrice() *big.Int {
+	return configuredGasPrice(bhe.store.Config, bhe.store.Config.GasUpdaterMinGasPriceWei(), bhe.store.Config.GasUpdaterMaxGasPriceWei())
+}
+
+func (bhe *blockHistoryEstimator) EstimateDynamicFee() (tipCap, feeCap *big.Int) {
+	return configuredDynamicFee(bhe.store.Config, bhe.store.Config.GasUpdaterMinGasPriceWei(), bhe.store.Config.GasUpdaterMaxGasPriceWei())
+}
+
+// feeHistoryBlock holds the parts of a block needed to estimate EIP-1559 fees.
+// go-ethereum's types.Block cannot decode blocks containing dynamic fee
+// transactions, so blocks are fetched into this instead.
+type feeHistoryBlock struct {
+	Number        hexutil.Big             `json:"number"`
+	BaseFeePerGas *hexutil.Big            `json:"baseFeePerGas"`
+	Transactions  []feeHistoryTransaction `json:"transactions"`
+}
+
+type feeHistoryTransaction struct {
+	GasPrice             *hexutil.Big `json:"gasPrice"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+}
+
+// effectiveTip returns the tip per unit of gas that the transaction paid to
+// the miner on top of the block's base fee
+func (tx feeHistoryTransaction) effectiveTip(baseFee *big.Int) *big.Int {
+	tip := new(big.Int)
+	if tx.MaxPriorityFeePerGas != nil && tx.MaxFeePerGas != nil {
+		tip.Sub(tx.MaxFeePerGas.ToInt(), baseFee)
+		if tip.Cmp(tx.MaxPriorityFeePerGas.ToInt()) > 0 {
+			tip.Set(tx.MaxPriorityFeePerGas.ToInt())
+		}
+	} else if tx.GasPrice != nil {
+		tip.Sub(tx.GasPrice.ToInt(), baseFee)
+	}
+	if tip.Sign() < 0 {
+		tip.SetInt64(0)
+	}
+	return tip
+}
+
+// updateDynamicFees sets the default tip cap to the configured percentile of
+// the tips paid in recent blocks, and the default fee cap to enough to cover
+// that tip plus a base fee of twice the latest one.  The base fee can rise by
+// at most 12.5% per block, so this stays sufficient for at least six blocks.
+func (bhe *blockHistoryEstimator) updateDynamicFees(ctx context.Context, blockToFetch int64) {
+	var block feeHistoryBlock
+	err := bhe.store.EthClient.CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeBig(big.NewInt(blockToFetch)), true)
+	if err != nil {
+		logger.Errorf("BlockHistoryEstimator: error retrieving block %v: %s", blockToFetch, err)
+		return
+	} else if block.BaseFeePerGas == nil {
+		logger.Errorf("BlockHistoryEstimator: block %v has no base fee. ETH_EIP1559_DYNAMIC_FEES should only be enabled on chains that support EIP-1559", blockToFetch)
+		return
+	} else if len(block.Transactions) == 0 {
+		logger.Debugw(fmt.Sprintf("BlockHistoryEstimator: skipping empty block: %v", blockToFetch), "blockNumber", blockToFetch)
+		return
+	}
+
+	bhe.rollingFeeHistory = append(bhe.rollingFeeHistory, block)
+	if len(bhe.rollingFeeHistory) <= bhe.rollingBlockHistorySize {
+		logger.Debugw(fmt.Sprintf("BlockHistoryEstimator: waiting for blocks: %v/%v", len(bhe.rollingFeeHistory), bhe.rollingBlockHistorySize), "inHistory", len(bhe.rollingFeeHistory), "required", bhe.rollingBlockHistorySize)
+		return
+	}
+	bhe.rollingFeeHistory = bhe.rollingFeeHistory[1:]
+
+	tipCap := bhe.percentileTip()
+	feeCap := new(big.Int).Mul(block.BaseFeePerGas.ToInt(), big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+	if err := bhe.setDynamicFees(tipCap, feeCap); err != nil {
+		logger.Error("BlockHistoryEstimator error setting dynamic fees: ", err)
+		return
+	}
+	promGasUpdaterSetTipCap.WithLabelValues(fmt.Sprintf("%v%%", bhe.percentile)).Set(float64(tipCap.Int64()))
+	promGasUpdaterSetFeeCap.Set(float64(feeCap.Int64()))
+}
+
+func (bhe *blockHistoryEstimator) percentileTip() *big.Int {
+	tips := make([]*big.Int, 0)
+	for _, block := range bhe.rollingFeeHistory {
+		for _, tx := range block.Transactions {
+			tips = append(tips, tx.effectiveTip(block.BaseFeePerGas.ToInt()))
+		}
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return tips[((len(tips)-1)*bhe.percentile)/100]
+}
+
+func (bhe *blockHistoryEstimator) setDynamicFees(tipCap, feeCap *big.Int) error {
+	maxGasPrice := bhe.store.Config.EthMaxGasPriceWei()
+	if tipCap.Cmp(maxGasPrice) > 0 {
+		return fmt.Errorf("cannot set tip cap %s because it exceeds EthMaxGasPriceWei %s", tipCap.String(), maxGasPrice.String())
+	}
+	if feeCap.Cmp(maxGasPrice) > 0 {
+		// The fee cap is only an upper bound, so capping it costs nothing
+		// unless the base fee really does rise this far
+		feeCap.Set(maxGasPrice)
+	}
+	logger.Debugw("BlockHistoryEstimator: setting new default dynamic fees", "tipCapWei", tipCap, "feeCapWei", feeCap)
+	if err := bhe.store.Config.SetEthGasTipCapDefault(tipCap); err != nil {
+		return err
+	}
+	return bhe.store.Config.SetEthGasFeeCapDefault(feeCap)
+}
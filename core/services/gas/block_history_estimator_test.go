@@ -0,0 +1,263 @@
+package gas_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHistoryEstimator_OnNewLongestChain_whenDisabledDoesNothing(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "false")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	bhe := gas.NewBlockHistoryEstimator(store)
+	head := cltest.Head(0)
+
+	bhe.OnNewLongestChain(context.TODO(), *head)
+
+	// No mock calls
+	ethClient.AssertExpectations(t)
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_WithCurrentBlockHeightLessThanBlockDelayDoesNothing(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	config.Set("GAS_UPDATER_BLOCK_DELAY", "3")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	for i := -1; i < 3; i++ {
+		head := cltest.Head(i)
+		bhe.OnNewLongestChain(context.TODO(), *head)
+	}
+
+	// No mock calls
+	ethClient.AssertExpectations(t)
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_WithErrorRetrievingBlockDoesNothing(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(0)).Return(nil, errors.New("foo"))
+
+	head := cltest.Head(3)
+
+	bhe.OnNewLongestChain(context.TODO(), *head)
+	ethClient.AssertExpectations(t)
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_AddsBlockToBlockHistory(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(0)).Return(cltest.BlockWithTransactions(), nil)
+	head := cltest.Head(3)
+	bhe.OnNewLongestChain(context.TODO(), *head)
+
+	// Empty blocks are not added
+	assert.Len(t, bhe.RollingBlockHistory(), 0)
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(1)).Return(cltest.BlockWithTransactions(20000), nil)
+	head = cltest.Head(4)
+	bhe.OnNewLongestChain(context.TODO(), *head)
+
+	// Blocks with transactions are added
+	assert.Len(t, bhe.RollingBlockHistory(), 1)
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_DoesNotOverflowBlockHistory(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	config.Set("GAS_UPDATER_BLOCK_DELAY", "3")
+	config.Set("GAS_UPDATER_BLOCK_HISTORY_SIZE", "5")
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	for i := 0; i < 5; i++ {
+		ethClient.On("BlockByNumber", mock.Anything, big.NewInt(int64(i))).Return(cltest.BlockWithTransactions(42), nil)
+		head := cltest.Head(i + 3)
+		bhe.OnNewLongestChain(context.TODO(), *head)
+		assert.Len(t, bhe.RollingBlockHistory(), i+1)
+	}
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(5)).Return(cltest.BlockWithTransactions(42), nil)
+	head := cltest.Head(8)
+	bhe.OnNewLongestChain(context.TODO(), *head)
+
+	assert.Len(t, bhe.RollingBlockHistory(), 5)
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_SetsGlobalGasPriceWhenHistoryFull(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	config.Set("GAS_UPDATER_BLOCK_DELAY", "0")
+	config.Set("GAS_UPDATER_TRANSACTION_PERCENTILE", "35")
+	config.Set("GAS_UPDATER_BLOCK_HISTORY_SIZE", "3")
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	config.SetRuntimeStore(store.ORM)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	for i := 0; i < 3; i++ {
+		ethClient.On("BlockByNumber", mock.Anything, big.NewInt(int64(i))).Return(cltest.BlockWithTransactions(int64((1+i)*100)), nil)
+		head := cltest.Head(i)
+		bhe.OnNewLongestChain(context.TODO(), *head)
+		assert.Len(t, bhe.RollingBlockHistory(), i+1)
+		assert.Equal(t, big.NewInt(42), config.EthGasPriceDefault())
+	}
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(3)).Return(cltest.BlockWithTransactions(200, 300, 100, 100, 100, 100), nil)
+	head := cltest.Head(3)
+	bhe.OnNewLongestChain(context.TODO(), *head)
+
+	assert.Len(t, bhe.RollingBlockHistory(), 3)
+	assert.Equal(t, big.NewInt(100), config.EthGasPriceDefault())
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_WillNotSetGasHigherThanEthMaxGasPriceWei(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	config.Set("GAS_UPDATER_BLOCK_DELAY", "0")
+	config.Set("GAS_UPDATER_BLOCK_HISTORY_SIZE", "1")
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	config.Set("ETH_MAX_GAS_PRICE_WEI", 100)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	config.SetRuntimeStore(store.ORM)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(0)).Return(cltest.BlockWithTransactions(9001), nil)
+	head := cltest.Head(0)
+	bhe.OnNewLongestChain(context.TODO(), *head)
+	assert.Len(t, bhe.RollingBlockHistory(), 1)
+	assert.Equal(t, big.NewInt(42), config.EthGasPriceDefault())
+
+	ethClient.On("BlockByNumber", mock.Anything, big.NewInt(1)).Return(cltest.BlockWithTransactions(9002), nil)
+	head = cltest.Head(1)
+	bhe.OnNewLongestChain(context.TODO(), *head)
+
+	assert.Equal(t, big.NewInt(42), config.EthGasPriceDefault())
+}
+
+func TestBlockHistoryEstimator_OnNewLongestChain_SetsDynamicFeesWhenHistoryFull(t *testing.T) {
+	config, _ := cltest.NewConfig(t)
+	config.Set("GAS_UPDATER_ENABLED", "true")
+	config.Set("GAS_UPDATER_BLOCK_DELAY", "0")
+	config.Set("GAS_UPDATER_TRANSACTION_PERCENTILE", "60")
+	config.Set("GAS_UPDATER_BLOCK_HISTORY_SIZE", "2")
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 1)
+	config.Set("ETH_EIP1559_DYNAMIC_FEES", true)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	config.SetRuntimeStore(store.ORM)
+	defer cleanup()
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	blocks := []string{
+		// Tips of 50 and 50, which drop out of the history
+		`{"number": "0x0", "baseFeePerGas": "0xa", "transactions": [{"gasPrice": "0x3c"}, {"gasPrice": "0x3c"}]}`,
+		// Tip of 4, as the fee cap leaves less than the max tip of 5
+		`{"number": "0x1", "baseFeePerGas": "0x14", "transactions": [{"gasPrice": "0x18", "maxPriorityFeePerGas": "0x5", "maxFeePerGas": "0x18"}]}`,
+		// Tips of 6 and 3
+		`{"number": "0x2", "baseFeePerGas": "0x1e", "transactions": [{"gasPrice": "0x24"}, {"gasPrice": "0x21", "maxPriorityFeePerGas": "0x3", "maxFeePerGas": "0x64"}]}`,
+	}
+	for i, block := range blocks {
+		block := block
+		ethClient.On("CallContext", mock.Anything, mock.Anything, "eth_getBlockByNumber", hexutil.EncodeBig(big.NewInt(int64(i))), true).
+			Run(func(args mock.Arguments) {
+				require.NoError(t, json.Unmarshal([]byte(block), args.Get(1)))
+			}).
+			Return(nil).
+			Once()
+	}
+
+	for i := 0; i < 2; i++ {
+		bhe.OnNewLongestChain(context.TODO(), *cltest.Head(i))
+		assert.Equal(t, big.NewInt(1), config.EthGasTipCapDefault())
+		assert.Equal(t, big.NewInt(42), config.EthGasFeeCapDefault())
+	}
+
+	bhe.OnNewLongestChain(context.TODO(), *cltest.Head(2))
+
+	// 60th percentile of tips 3, 4 and 6
+	assert.Equal(t, big.NewInt(4), config.EthGasTipCapDefault())
+	// Twice the latest base fee of 30, plus the tip
+	assert.Equal(t, big.NewInt(64), config.EthGasFeeCapDefault())
+	assert.Equal(t, big.NewInt(42), config.EthGasPriceDefault())
+
+	ethClient.AssertExpectations(t)
+}
+
+func TestBlockHistoryEstimator_EstimateGasPrice_Caps(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("GAS_UPDATER_MIN_GAS_PRICE_WEI", 10)
+	config.Set("GAS_UPDATER_MAX_GAS_PRICE_WEI", 100)
+	config.Set("ETH_MAX_GAS_PRICE_WEI", 1000)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+	bhe := gas.NewBlockHistoryEstimator(store)
+
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	assert.Equal(t, big.NewInt(42), bhe.EstimateGasPrice())
+
+	config.Set("ETH_GAS_PRICE_DEFAULT", 500)
+	assert.Equal(t, big.NewInt(100), bhe.EstimateGasPrice())
+
+	config.Set("ETH_GAS_PRICE_DEFAULT", 1)
+	assert.Equal(t, big.NewInt(10), bhe.EstimateGasPrice())
+
+	config.Set("ETH_GAS_FEE_CAP_DEFAULT", 500)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 5)
+	tipCap, feeCap := bhe.EstimateDynamicFee()
+	assert.Equal(t, big.NewInt(5), tipCap)
+	assert.Equal(t, big.NewInt(100), feeCap)
+}
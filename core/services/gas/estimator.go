@@ -0,0 +1,154 @@
+package gas
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+const (
+	// maxEthNodeRequestTime is the worst case time we will wait for a response
+	// from the eth node before we consider it to be an error
+	maxEthNodeRequestTime = 4 * time.Second
+)
+
+// The values of GAS_ESTIMATOR_MODE
+const (
+	ModeBlockHistory = "BlockHistory"
+	ModeFixed        = "Fixed"
+	ModeOracle       = "Oracle"
+)
+
+//go:generate mockery --name Estimator --output ../../internal/mocks/ --case=underscore
+
+// Estimator prices the first attempt of a transaction. Estimators are head
+// trackable so that they can update their estimate as new blocks arrive.
+type Estimator interface {
+	store.HeadTrackable
+	// EstimateGasPrice returns the gas price for a legacy transaction
+	EstimateGasPrice() *big.Int
+	// EstimateDynamicFee returns the tip cap and fee cap for an EIP-1559
+	// dynamic fee transaction. The tip cap never exceeds the fee cap.
+	EstimateDynamicFee() (tipCap, feeCap *big.Int)
+}
+
+// NewEstimator returns the Estimator selected by GAS_ESTIMATOR_MODE
+func NewEstimator(store *store.Store) Estimator {
+	switch mode := store.Config.GasEstimatorMode(); mode {
+	case ModeFixed:
+		return NewFixedEstimator(store.Config)
+	case ModeOracle:
+		return NewOracleEstimator(store)
+	case ModeBlockHistory:
+		return NewBlockHistoryEstimator(store)
+	default:
+		logger.Errorf("Gas: unknown GAS_ESTIMATOR_MODE %s, falling back to %s", mode, ModeBlockHistory)
+		return NewBlockHistoryEstimator(store)
+	}
+}
+
+// Estimate is an Estimator's current estimate, as reported by the API
+type Estimate struct {
+	Mode     string     `json:"mode"`
+	GasPrice *utils.Big `json:"gasPrice"`
+	TipCap   *utils.Big `json:"tipCap"`
+	FeeCap   *utils.Big `json:"feeCap"`
+}
+
+// CurrentEstimate returns the estimator's current estimate. The tip and fee
+// caps are only set when EIP-1559 dynamic fees are enabled.
+func CurrentEstimate(estimator Estimator, config orm.ConfigReader) Estimate {
+	estimate := Estimate{
+		Mode:     config.GasEstimatorMode(),
+		GasPrice: utils.NewBig(estimator.EstimateGasPrice()),
+	}
+	if config.EthEIP1559DynamicFees() {
+		tipCap, feeCap := estimator.EstimateDynamicFee()
+		estimate.TipCap = utils.NewBig(tipCap)
+		estimate.FeeCap = utils.NewBig(feeCap)
+	}
+	return estimate
+}
+
+// GetID returns the jsonapi ID, which is the estimator's mode
+func (e Estimate) GetID() string {
+	return e.Mode
+}
+
+// GetName returns the jsonapi type name
+func (e Estimate) GetName() string {
+	return "gasEstimates"
+}
+
+// SetID is used to set the jsonapi ID
+func (e *Estimate) SetID(value string) error {
+	e.Mode = value
+	return nil
+}
+
+type fixedEstimator struct {
+	config orm.ConfigReader
+}
+
+// NewFixedEstimator returns an Estimator that always uses the configured
+// defaults, ETH_GAS_PRICE_DEFAULT and ETH_GAS_TIP_CAP_DEFAULT. Its caps are
+// GAS_FIXED_MIN_GAS_PRICE_WEI and GAS_FIXED_MAX_GAS_PRICE_WEI.
+func NewFixedEstimator(config orm.ConfigReader) Estimator {
+	return &fixedEstimator{config: config}
+}
+
+func (fe *fixedEstimator) Connect(*models.Head) error {
+	return nil
+}
+
+func (fe *fixedEstimator) Disconnect() {}
+
+func (fe *fixedEstimator) OnNewLongestChain(ctx context.Context, head models.Head) {}
+
+func (fe *fixedEstimator) EstimateGasPrice() *big.Int {
+	return configuredGasPrice(fe.config, fe.config.GasFixedMinGasPriceWei(), fe.config.GasFixedMaxGasPriceWei())
+}
+
+func (fe *fixedEstimator) EstimateDynamicFee() (tipCap, feeCap *big.Int) {
+	return configuredDynamicFee(fe.config, fe.config.GasFixedMinGasPriceWei(), fe.config.GasFixedMaxGasPriceWei())
+}
+
+// configuredGasPrice returns the default gas price, which the block history
+// estimator keeps up to date, limited to the estimator's caps. It is never
+// above ETH_MAX_GAS_PRICE_WEI, which also limits gas bumping.
+func configuredGasPrice(config orm.ConfigReader, minGasPrice, maxGasPrice *big.Int) *big.Int {
+	return capGasPrice(config, config.EthGasPriceDefault(), minGasPrice, maxGasPrice)
+}
+
+// configuredDynamicFee returns the default tip and fee caps, which the block
+// history estimator keeps up to date, with the fee cap limited to the
+// estimator's caps
+func configuredDynamicFee(config orm.ConfigReader, minGasPrice, maxGasPrice *big.Int) (tipCap, feeCap *big.Int) {
+	feeCap = capGasPrice(config, config.EthGasFeeCapDefault(), minGasPrice, maxGasPrice)
+	tipCap = bigMin(config.EthGasTipCapDefault(), feeCap)
+	return tipCap, feeCap
+}
+
+func capGasPrice(config orm.ConfigReader, gasPrice, minGasPrice, maxGasPrice *big.Int) *big.Int {
+	return bigMin(bigMax(minGasPrice, bigMin(gasPrice, maxGasPrice)), config.EthMaxGasPriceWei())
+}
+
+func bigMin(a, b *big.Int) *big.Int {
+	if a.Cmp(b) > 0 {
+		return b
+	}
+	return a
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,43 @@
+package gas_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedEstimator(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 2)
+	config.Set("ETH_MAX_GAS_PRICE_WEI", 100)
+
+	fe := gas.NewFixedEstimator(config)
+	fe.OnNewLongestChain(context.TODO(), *cltest.Head(1))
+
+	assert.Equal(t, big.NewInt(42), fe.EstimateGasPrice())
+	tipCap, feeCap := fe.EstimateDynamicFee()
+	assert.Equal(t, big.NewInt(2), tipCap)
+	assert.Equal(t, big.NewInt(42), feeCap)
+
+	// Capped at ETH_MAX_GAS_PRICE_WEI, with the tip cap never above the fee cap
+	config.Set("ETH_GAS_PRICE_DEFAULT", 9001)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 500)
+	assert.Equal(t, big.NewInt(100), fe.EstimateGasPrice())
+	tipCap, feeCap = fe.EstimateDynamicFee()
+	assert.Equal(t, big.NewInt(100), tipCap)
+	assert.Equal(t, big.NewInt(100), feeCap)
+
+	// Limited to the fixed estimator's own caps
+	config.Set("GAS_FIXED_MIN_GAS_PRICE_WEI", 50)
+	config.Set("GAS_FIXED_MAX_GAS_PRICE_WEI", 80)
+	assert.Equal(t, big.NewInt(80), fe.EstimateGasPrice())
+	config.Set("ETH_GAS_PRICE_DEFAULT", 1)
+	assert.Equal(t, big.NewInt(50), fe.EstimateGasPrice())
+}
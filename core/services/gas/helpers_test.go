@@ -0,0 +1,5 @@
+package gas
+
+func (oe *oracleEstimator) ExportedWaitForFetch() {
+	oe.wgFetch.Wait()
+}
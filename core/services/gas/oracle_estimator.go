@@ -0,0 +1,185 @@
+package gas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tevino/abool"
+)
+
+var (
+	promGasOracleGasPrice = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "gas_oracle_gas_price",
+		Help: "Gas price returned by the gas oracle bridge, after applying its caps (in Wei)",
+	})
+)
+
+// oracleRequestTimeout is how long the gas oracle bridge has to answer.  It
+// is kept short so that a slow bridge's answer is not stale by the time it
+// arrives.
+const oracleRequestTimeout = 5 * time.Second
+
+// oracleEstimator asks an external adapter for the gas price on every head.
+// The adapter is called like any other bridge, with the head's number in
+// data.blockNumber, and must answer with the gas price in Wei in data.result.
+// Requests are made in the background, one at a time, so that a slow bridge
+// never holds up the head tracker.
+//
+// Answers are limited to between GAS_ORACLE_MIN_GAS_PRICE_WEI and
+// GAS_ORACLE_MAX_GAS_PRICE_WEI. Until the first successful answer, and
+// whenever the bridge cannot be reached, the last known price is used,
+// starting with ETH_GAS_PRICE_DEFAULT.
+type oracleEstimator struct {
+	store *store.Store
+
+	mutex    sync.RWMutex
+	gasPrice *big.Int
+
+	fetching *abool.AtomicBool
+	wgFetch  sync.WaitGroup
+}
+
+// NewOracleEstimator returns a new oracle estimator
+func NewOracleEstimator(store *store.Store) Estimator {
+	return &oracleEstimator{store: store, fetching: abool.New()}
+}
+
+func (oe *oracleEstimator) Connect(*models.Head) error {
+	logger.Debugw("OracleEstimator: asking bridge for gas prices", "bridge", oe.store.Config.GasOracleBridgeName())
+	return nil
+}
+
+func (oe *oracleEstimator) Disconnect() {}
+
+// OnNewLongestChain asks the bridge for a new gas price in the background.
+// Heads that arrive while a request is still in flight are skipped.
+func (oe *oracleEstimator) OnNewLongestChain(_ context.Context, head models.Head) {
+	if !oe.fetching.SetToIf(false, true) {
+		logger.Debugw("OracleEstimator: previous gas price request still in flight, skipping head", "blockNumber", head.Number)
+		return
+	}
+	oe.wgFetch.Add(1)
+	go func() {
+		defer oe.wgFetch.Done()
+		defer oe.fetching.UnSet()
+		oe.updateGasPrice(head)
+	}()
+}
+
+func (oe *oracleEstimator) updateGasPrice(head models.Head) {
+	ctx, cancel := context.WithTimeout(context.Background(), oracleRequestTimeout)
+	defer cancel()
+
+	gasPrice, err := oe.fetchGasPrice(ctx, head)
+	if err != nil {
+		logger.Errorw("OracleEstimator: could not get gas price, keeping the previous one", "err", err, "blockNumber", head.Number)
+		return
+	}
+	gasPrice = oe.applyCaps(gasPrice)
+	logger.Debugw("OracleEstimator: setting new gas price", "gasPriceWei", gasPrice, "blockNumber", head.Number)
+
+	oe.mutex.Lock()
+	oe.gasPrice = gasPrice
+	oe.mutex.Unlock()
+	promGasOracleGasPrice.Set(float64(gasPrice.Int64()))
+}
+
+type oracleRequest struct {
+	Data oracleRequestData `json:"data"`
+}
+
+type oracleRequestData struct {
+	BlockNumber int64 `json:"blockNumber"`
+}
+
+func (oe *oracleEstimator) fetchGasPrice(ctx context.Context, head models.Head) (*big.Int, error) {
+	taskType, err := models.NewTaskType(oe.store.Config.GasOracleBridgeName())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid GAS_ORACLE_BRIDGE_NAME")
+	}
+	bridge, err := oe.store.FindBridge(taskType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find bridge %s", taskType)
+	}
+
+	body, err := json.Marshal(oracleRequest{Data: oracleRequestData{BlockNumber: head.Number}})
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest("POST", bridge.URL.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "building gas oracle request")
+	}
+	request.Header.Set("Authorization", "Bearer "+bridge.OutgoingToken)
+	request.Header.Set("Content-Type", "application/json")
+
+	httpRequest := utils.HTTPRequest{
+		Request: request,
+		Config: utils.HTTPRequestConfig{
+			Timeout:     oracleRequestTimeout,
+			MaxAttempts: 1,
+			SizeLimit:   oe.store.Config.DefaultHTTPLimit(),
+			// URL is "safe" because it comes from the node's own database
+			AllowUnrestrictedNetworkAccess: true,
+		},
+	}
+	responseBody, statusCode, err := httpRequest.SendRequest(ctx)
+	if err != nil {
+		return nil, err
+	} else if statusCode >= 400 {
+		return nil, fmt.Errorf("POST request: %v %v", statusCode, string(responseBody))
+	}
+
+	var brr models.BridgeRunResult
+	if err := json.Unmarshal(responseBody, &brr); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling gas oracle response")
+	} else if brr.HasError() {
+		return nil, brr.GetError()
+	}
+	result := brr.Data.Get("result").String()
+	gasPrice, ok := new(big.Int).SetString(result, 10)
+	if !ok {
+		return nil, fmt.Errorf("gas oracle result %q is not a whole number of Wei", result)
+	}
+	return gasPrice, nil
+}
+
+func (oe *oracleEstimator) applyCaps(gasPrice *big.Int) *big.Int {
+	minGasPrice := oe.store.Config.GasOracleMinGasPriceWei()
+	maxGasPrice := oe.store.Config.GasOracleMaxGasPriceWei()
+	if gasPrice.Cmp(maxGasPrice) > 0 {
+		logger.Warnw("OracleEstimator: gas price exceeds GAS_ORACLE_MAX_GAS_PRICE_WEI", "gasPriceWei", gasPrice, "maxGasPriceWei", maxGasPrice)
+	}
+	return capGasPrice(oe.store.Config, gasPrice, minGasPrice, maxGasPrice)
+}
+
+// EstimateGasPrice returns the oracle's latest gas price
+func (oe *oracleEstimator) EstimateGasPrice() *big.Int {
+	oe.mutex.RLock()
+	defer oe.mutex.RUnlock()
+	if oe.gasPrice == nil {
+		return oe.applyCaps(oe.store.Config.EthGasPriceDefault())
+	}
+	return new(big.Int).Set(oe.gasPrice)
+}
+
+// EstimateDynamicFee uses the oracle's latest gas price as the fee cap, and
+// ETH_GAS_TIP_CAP_DEFAULT as the tip cap
+func (oe *oracleEstimator) EstimateDynamicFee() (tipCap, feeCap *big.Int) {
+	feeCap = oe.EstimateGasPrice()
+	return bigMin(oe.store.Config.EthGasTipCapDefault(), feeCap), feeCap
+}
@@ -0,0 +1,116 @@
+package gas_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOracleEstimator_OnNewLongestChain(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("GAS_ESTIMATOR_MODE", "Oracle")
+	config.Set("GAS_ORACLE_BRIDGE_NAME", "gasoracle")
+	config.Set("GAS_ORACLE_MIN_GAS_PRICE_WEI", 10)
+	config.Set("GAS_ORACLE_MAX_GAS_PRICE_WEI", 1000)
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	config.Set("ETH_GAS_TIP_CAP_DEFAULT", 5)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	var response string
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, string(body))
+		io.WriteString(w, response)
+	}))
+	defer server.Close()
+	_, bridge := cltest.NewBridgeType(t, "gasoracle", server.URL)
+	require.NoError(t, store.DB.Create(bridge).Error)
+
+	oe := gas.NewEstimator(store)
+	onNewLongestChain := func(head *models.Head) {
+		oe.OnNewLongestChain(context.TODO(), *head)
+		oe.(interface{ ExportedWaitForFetch() }).ExportedWaitForFetch()
+	}
+
+	// The default gas price is used until the oracle has answered
+	assert.Equal(t, big.NewInt(42), oe.EstimateGasPrice())
+
+	response = `{"data": {"result": "300"}}`
+	onNewLongestChain(cltest.Head(1))
+	assert.Equal(t, big.NewInt(300), oe.EstimateGasPrice())
+	tipCap, feeCap := oe.EstimateDynamicFee()
+	assert.Equal(t, big.NewInt(5), tipCap)
+	assert.Equal(t, big.NewInt(300), feeCap)
+	require.Len(t, requests, 1)
+	assert.JSONEq(t, `{"data": {"blockNumber": 1}}`, requests[0])
+
+	// Answers are limited to the oracle's caps
+	response = `{"data": {"result": 9001}}`
+	onNewLongestChain(cltest.Head(2))
+	assert.Equal(t, big.NewInt(1000), oe.EstimateGasPrice())
+
+	response = `{"data": {"result": "1"}}`
+	onNewLongestChain(cltest.Head(3))
+	assert.Equal(t, big.NewInt(10), oe.EstimateGasPrice())
+
+	// Errors keep the previous answer
+	response = `{"error": "no gas price available"}`
+	onNewLongestChain(cltest.Head(4))
+	assert.Equal(t, big.NewInt(10), oe.EstimateGasPrice())
+
+	response = `{"data": {"result": "cheap"}}`
+	onNewLongestChain(cltest.Head(5))
+	assert.Equal(t, big.NewInt(10), oe.EstimateGasPrice())
+}
+
+func TestOracleEstimator_OnNewLongestChain_DoesNotBlock(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("GAS_ESTIMATOR_MODE", "Oracle")
+	config.Set("GAS_ORACLE_BRIDGE_NAME", "gasoracle")
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42)
+	store, cleanup := cltest.NewStoreWithConfig(config)
+	defer cleanup()
+
+	var requests int32
+	chRespond := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-chRespond
+		io.WriteString(w, `{"data": {"result": "300"}}`)
+	}))
+	defer server.Close()
+	_, bridge := cltest.NewBridgeType(t, "gasoracle", server.URL)
+	require.NoError(t, store.DB.Create(bridge).Error)
+
+	oe := gas.NewEstimator(store)
+
+	// Heads are handled straight away while the bridge is slow to answer, and
+	// heads that arrive during a request don't start another one
+	oe.OnNewLongestChain(context.TODO(), *cltest.Head(1))
+	gomega.NewGomegaWithT(t).Eventually(func() int32 { return atomic.LoadInt32(&requests) }).Should(gomega.Equal(int32(1)))
+	oe.OnNewLongestChain(context.TODO(), *cltest.Head(2))
+	assert.Equal(t, big.NewInt(42), oe.EstimateGasPrice())
+
+	close(chRespond)
+	oe.(interface{ ExportedWaitForFetch() }).ExportedWaitForFetch()
+	assert.Equal(t, big.NewInt(300), oe.EstimateGasPrice())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/smartcontractkit/chainlink/core/utils"
 
 	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -46,6 +49,15 @@ var (
 		Name: "head_tracker_num_heads_dropped",
 		Help: "The total number of heads dropped",
 	})
+	promReorgDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "head_tracker_reorg_depth",
+		Help:    "The number of blocks removed from the longest chain by each reorg",
+		Buckets: []float64{1, 2, 3, 5, 10, 20, 50, 100},
+	})
+	promFinalizedHead = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "head_tracker_finalized_head",
+		Help: "The highest finalized block number",
+	})
 
 	// kovanChainID is the Chain ID for Kovan test network
 	kovanChainID = big.NewInt(42)
@@ -121,6 +133,7 @@ type HeadTracker struct {
 	outHeaders            chan models.Head
 	headSubscription      ethereum.Subscription
 	highestSeenHead       *models.Head
+	longestChain          *models.Head
 	store                 *strpkg.Store
 	headMutex             sync.RWMutex
 	connected             bool
@@ -162,6 +175,9 @@ func (ht *HeadTracker) Start() error {
 	if err := ht.setHighestSeenHeadFromDB(); err != nil {
 		return err
 	}
+	if err := ht.loadHeadChainFromDB(); err != nil {
+		return err
+	}
 	if ht.highestSeenHead != nil {
 		logger.Debug("Tracking logs from last block ", presenters.FriendlyBigInt(ht.highestSeenHead.ToInt()), " with hash ", ht.highestSeenHead.Hash.Hex())
 	}
@@ -212,6 +228,7 @@ func (ht *HeadTracker) Save(h models.Head) error {
 	if err != nil {
 		return err
 	}
+	ht.store.HeadChain.Add(h)
 	return ht.store.TrimOldHeads(ht.store.Config.EthHeadTrackerHistoryDepth())
 }
 
@@ -415,7 +432,7 @@ func (ht *HeadTracker) GetChainWithBackfill(ctx context.Context, head models.Hea
 	ctx, cancel := context.WithTimeout(ctx, ht.backfillTimeBudget())
 	defer cancel()
 
-	head, err := ht.store.Chain(head.Hash, depth)
+	head, err := ht.chain(head.Hash, depth)
 	if err != nil {
 		return head, errors.Wrap(err, "GetChainWithBackfill failed fetching chain")
 	}
@@ -430,7 +447,31 @@ func (ht *HeadTracker) GetChainWithBackfill(ctx context.Context, head models.Hea
 	if err := ht.backfill(ctx, head.EarliestInChain(), baseHeight); err != nil {
 		return head, errors.Wrap(err, "GetChainWithBackfill failed backfilling")
 	}
-	return ht.store.Chain(head.Hash, depth)
+	return ht.chain(head.Hash, depth)
+}
+
+// chain returns the chain of heads starting at hash and up to depth-1
+// parents from memory, or from the database if the chain in memory is
+// shorter, in which case the heads are kept in memory
+func (ht *HeadTracker) chain(hash common.Hash, depth uint) (models.Head, error) {
+	if head, exists := ht.store.HeadChain.Chain(hash, depth); exists && uint(head.ChainLength()) >= depth {
+		return head, nil
+	}
+	head, err := ht.store.Chain(hash, depth)
+	if err != nil {
+		return head, err
+	}
+	ht.store.HeadChain.Add(head)
+	return head, nil
+}
+
+// headByHash returns the head with the given hash from memory, or from the
+// database, or nil if none exists
+func (ht *HeadTracker) headByHash(hash common.Hash) (*models.Head, error) {
+	if head := ht.store.HeadChain.HeadByHash(hash); head != nil {
+		return head, nil
+	}
+	return ht.store.HeadByHash(hash)
 }
 
 // backfill fetches all missing heads up until the base height
@@ -451,9 +492,9 @@ func (ht *HeadTracker) backfill(ctx context.Context, head models.Head, baseHeigh
 			"toBlockHeight", head.Number-1)
 	}()
 
+	var prefetched map[int64]fetchedHead
 	for i := head.Number - 1; i >= baseHeight; i-- {
-		// NOTE: Sequential requests here mean it's a potential performance bottleneck, be aware!
-		existingHead, err := ht.store.HeadByHash(head.ParentHash)
+		existingHead, err := ht.headByHash(head.ParentHash)
 		if err != nil {
 			return errors.Wrap(err, "HeadByHash failed")
 		}
@@ -461,7 +502,13 @@ func (ht *HeadTracker) backfill(ctx context.Context, head models.Head, baseHeigh
 			head = *existingHead
 			continue
 		}
-		head, err = ht.fetchAndSaveHead(ctx, i)
+		if _, exists := prefetched[i]; !exists {
+			prefetched, err = ht.fetchHeads(ctx, i, baseHeight)
+			if err != nil {
+				return errors.Wrap(err, "fetchHeads failed")
+			}
+		}
+		head, err = ht.saveFetchedHead(prefetched[i])
 		fetched++
 		if err != nil {
 			if errors.Cause(err) == ethereum.NotFound {
@@ -477,24 +524,98 @@ func (ht *HeadTracker) backfill(ctx context.Context, head models.Head, baseHeigh
 	return nil
 }
 
-func (ht *HeadTracker) fetchAndSaveHead(ctx context.Context, n int64) (models.Head, error) {
-	logger.Debugw("HeadTracker: fetching head", "blockHeight", n)
-	head, err := ht.store.EthClient.HeaderByNumber(ctx, big.NewInt(n))
+// fetchedHead is the result of requesting a single head from the eth node
+type fetchedHead struct {
+	head *models.Head
+	err  error
+}
+
+// fetchHeads requests the heads from n downwards in a single batch, stopping
+// at baseHeight, at the first head that is already in the database, or once
+// the batch is full
+func (ht *HeadTracker) fetchHeads(ctx context.Context, n int64, baseHeight int64) (map[int64]fetchedHead, error) {
+	from := n - int64(ht.store.Config.EthRPCBatchSize()) + 1
+	if from < baseHeight {
+		from = baseHeight
+	}
+	if from > n {
+		from = n
+	}
+	existing, err := ht.store.HeadNumbers(from, n-1)
 	if err != nil {
-		return models.Head{}, err
-	} else if head == nil {
+		return nil, errors.Wrap(err, "HeadNumbers failed")
+	}
+	for _, number := range existing {
+		if number >= from {
+			from = number + 1
+		}
+	}
+
+	logger.Debugw("HeadTracker: fetching heads", "fromBlockHeight", from, "toBlockHeight", n)
+	var reqs []rpc.BatchElem
+	for i := n; i >= from; i-- {
+		reqs = append(reqs, rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeBig(big.NewInt(i)), false},
+			Result: new(*models.Head),
+		})
+	}
+	batchErr := ht.store.EthClient.BatchCallContext(ctx, reqs)
+
+	heads := make(map[int64]fetchedHead)
+	for i, req := range reqs {
+		number := n - int64(i)
+		if batchErr != nil {
+			heads[number] = fetchedHead{err: batchErr}
+		} else if req.Error != nil {
+			heads[number] = fetchedHead{err: req.Error}
+		} else if head := *req.Result.(**models.Head); head == nil {
+			heads[number] = fetchedHead{err: ethereum.NotFound}
+		} else {
+			heads[number] = fetchedHead{head: head}
+		}
+	}
+	return heads, nil
+}
+
+func (ht *HeadTracker) saveFetchedHead(fetched fetchedHead) (models.Head, error) {
+	if fetched.err != nil {
+		return models.Head{}, fetched.err
+	} else if fetched.head == nil {
 		return models.Head{}, errors.New("got nil head")
 	}
-	if err := ht.store.IdempotentInsertHead(*head); err != nil {
+	if err := ht.store.IdempotentInsertHead(*fetched.head); err != nil {
 		return models.Head{}, err
 	}
-	return *head, nil
+	ht.store.HeadChain.Add(*fetched.head)
+	return *fetched.head, nil
 }
 
 func (ht *HeadTracker) onNewLongestChain(ctx context.Context, headWithChain models.Head) {
+	// In Tag mode this asks the eth node, so it is done before taking the
+	// lock, which would otherwise be held for the duration of the request
+	ht.updateFinality(ctx, headWithChain)
+
 	ht.headMutex.Lock()
 	defer ht.headMutex.Unlock()
 
+	var reorg *models.Reorg
+	if ht.longestChain != nil {
+		reorg = findReorg(*ht.longestChain, headWithChain)
+	}
+	ht.longestChain = &headWithChain
+	ht.store.HeadChain.SetLongestChain(headWithChain)
+	if reorg != nil {
+		promReorgDepth.Observe(float64(reorg.Depth))
+		logger.Warnw(fmt.Sprintf("HeadTracker: reorg of depth %v to head %v", reorg.Depth, headWithChain.Number),
+			"depth", reorg.Depth,
+			"blockNumber", headWithChain.Number,
+			"removed", reorg.Removed,
+			"added", reorg.Added,
+			"id", "head_tracker",
+		)
+	}
+
 	logger.Debugw("HeadTracker initiating callbacks",
 		"headNum", headWithChain.Number,
 		"chainLength", headWithChain.ChainLength(),
@@ -502,20 +623,104 @@ func (ht *HeadTracker) onNewLongestChain(ctx context.Context, headWithChain mode
 	)
 
 	if ht.store.Config.EnableBulletproofTxManager() {
-		ht.concurrentlyExecuteCallbacks(ctx, headWithChain)
+		ht.concurrentlyExecuteCallbacks(ctx, headWithChain, reorg)
 	} else {
 		// NOTE: Legacy tx manager probably has implicit ordering requirements, so it's not safe to parallelise
-		ht.seriallyExecuteCallbacks(ctx, headWithChain)
+		ht.seriallyExecuteCallbacks(ctx, headWithChain, reorg)
 	}
 }
 
-func (ht *HeadTracker) concurrentlyExecuteCallbacks(ctx context.Context, headWithChain models.Head) {
+// updateFinality sets the finalized and safe blocks of the store's HeadChain,
+// either by depth below the head, or from the node's "finalized" and "safe"
+// block tags. If the node cannot be asked, the previous blocks are kept.
+func (ht *HeadTracker) updateFinality(ctx context.Context, head models.Head) {
+	if ht.store.Config.EthFinalityMode() != "Tag" {
+		ht.store.HeadChain.SetFinality(
+			head.Number-int64(ht.store.Config.EthFinalityDepth()),
+			head.Number-int64(ht.store.Config.EthSafeDepth()),
+		)
+	} else {
+		reqs := []rpc.BatchElem{
+			{Method: "eth_getBlockByNumber", Args: []interface{}{"finalized", false}, Result: new(*models.Head)},
+			{Method: "eth_getBlockByNumber", Args: []interface{}{"safe", false}, Result: new(*models.Head)},
+		}
+		if err := ht.store.EthClient.BatchCallContext(ctx, reqs); err != nil {
+			logger.Warnw("HeadTracker: could not fetch finalized and safe blocks", "err", err)
+			return
+		}
+		numbers := make([]int64, len(reqs))
+		for i, req := range reqs {
+			numbers[i] = -1
+			if req.Error != nil {
+				logger.Warnw("HeadTracker: could not fetch block by tag", "tag", req.Args[0], "err", req.Error)
+			} else if tagged := *req.Result.(**models.Head); tagged != nil {
+				numbers[i] = tagged.Number
+			}
+		}
+		ht.store.HeadChain.SetTaggedFinality(numbers[0], numbers[1])
+	}
+	if finalized, known := ht.store.HeadChain.FinalizedBlockNumber(); known {
+		promFinalizedHead.Set(float64(finalized))
+	}
+}
+
+// findReorg returns the blocks of the previous longest chain that are not in
+// the new one, or nil if the new one extends it. If the chains have no block
+// in common, every block of the previous chain is treated as removed, unless
+// the new chain starts above the previous head, in which case it cannot be
+// told whether there was a reorg.
+func findReorg(prev, next models.Head) *models.Reorg {
+	prevHashes := make(map[common.Hash]struct{})
+	for h := &prev; h != nil; h = h.Parent {
+		prevHashes[h.Hash] = struct{}{}
+	}
+
+	var added []common.Hash
+	var ancestor *models.Head
+	for h := &next; h != nil; h = h.Parent {
+		if _, exists := prevHashes[h.Hash]; exists {
+			ancestor = h
+			break
+		}
+		added = append(added, h.Hash)
+	}
+	if ancestor != nil && ancestor.Hash == prev.Hash {
+		return nil
+	}
+	if ancestor == nil && next.EarliestInChain().Number > prev.Number {
+		return nil
+	}
+
+	var removed []common.Hash
+	for h := &prev; h != nil; h = h.Parent {
+		if ancestor != nil && h.Hash == ancestor.Hash {
+			break
+		}
+		removed = append(removed, h.Hash)
+	}
+	return &models.Reorg{
+		Depth:   int64(len(removed)),
+		Removed: removed,
+		Added:   added,
+	}
+}
+
+// executeCallback calls OnReorg first if there was a reorg and the callback
+// implements it
+func executeCallback(ctx context.Context, t strpkg.HeadTrackable, headWithChain models.Head, reorg *models.Reorg) {
+	if rt, ok := t.(strpkg.ReorgTrackable); ok && reorg != nil {
+		rt.OnReorg(ctx, *reorg)
+	}
+	t.OnNewLongestChain(ctx, headWithChain)
+}
+
+func (ht *HeadTracker) concurrentlyExecuteCallbacks(ctx context.Context, headWithChain models.Head, reorg *models.Reorg) {
 	wg := sync.WaitGroup{}
 	wg.Add(len(ht.callbacks))
 	for idx, trackable := range ht.callbacks {
 		go func(i int, t strpkg.HeadTrackable) {
 			start := time.Now()
-			t.OnNewLongestChain(ctx, headWithChain)
+			executeCallback(ctx, t, headWithChain, reorg)
 			elapsed := time.Since(start)
 			logger.Debugw(fmt.Sprintf("HeadTracker: finished callback %v in %s", i, elapsed), "callbackType", reflect.TypeOf(t), "callbackIdx", i, "blockNumber", headWithChain.Number, "time", elapsed, "id", "head_tracker")
 			wg.Done()
@@ -524,10 +729,10 @@ func (ht *HeadTracker) concurrentlyExecuteCallbacks(ctx context.Context, headWit
 	wg.Wait()
 }
 
-func (ht *HeadTracker) seriallyExecuteCallbacks(ctx context.Context, headWithChain models.Head) {
+func (ht *HeadTracker) seriallyExecuteCallbacks(ctx context.Context, headWithChain models.Head, reorg *models.Reorg) {
 	for i, t := range ht.callbacks {
 		start := time.Now()
-		t.OnNewLongestChain(ctx, headWithChain)
+		executeCallback(ctx, t, headWithChain, reorg)
 		elapsed := time.Since(start)
 		logger.Debugw(fmt.Sprintf("HeadTracker: finished callback %v in %s", i, elapsed), "callbackType", reflect.TypeOf(t), "callbackIdx", i, "blockNumber", headWithChain.Number, "time", elapsed, "id", "head_tracker")
 	}
@@ -587,6 +792,24 @@ func (ht *HeadTracker) setHighestSeenHeadFromDB() error {
 	return nil
 }
 
+// loadHeadChainFromDB fills the in-memory chain with the heads kept in the
+// database, taking the highest seen head as the tip of the longest chain
+func (ht *HeadTracker) loadHeadChainFromDB() error {
+	heads, err := ht.store.LastHeads(ht.store.Config.EthHeadTrackerHistoryDepth())
+	if err != nil {
+		return errors.Wrap(err, "LastHeads failed")
+	}
+	for _, head := range heads {
+		ht.store.HeadChain.Add(head)
+	}
+	if ht.highestSeenHead != nil {
+		if chain, exists := ht.store.HeadChain.Chain(ht.highestSeenHead.Hash, ht.store.Config.EthFinalityDepth()); exists {
+			ht.store.HeadChain.SetLongestChain(chain)
+		}
+	}
+	return nil
+}
+
 // chainIDVerify checks whether or not the ChainID from the Chainlink config
 // matches the ChainID reported by the ETH node connected to this Chainlink node.
 func verifyEthereumChainID(ht *HeadTracker) error {
@@ -15,9 +15,10 @@ import (
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 
-	"github.com/ethereum/go-ethereum"
 	gethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -32,6 +33,45 @@ func firstHead(t *testing.T, store *strpkg.Store) models.Head {
 	return h
 }
 
+// mockBatchHeadersByNumber answers batched requests for heads by calling
+// HeaderByNumber on the same mock, so that its expectations still apply
+func mockBatchHeadersByNumber(ethClient *mocks.Client) {
+	ethClient.On("BatchCallContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		b := args.Get(1).([]rpc.BatchElem)
+		for i := range b {
+			n, err := hexutil.DecodeBig(b[i].Args[0].(string))
+			if err != nil {
+				b[i].Error = err
+				continue
+			}
+			*b[i].Result.(**models.Head), b[i].Error = ethClient.HeaderByNumber(ctx, n)
+		}
+	}).Return(nil)
+}
+
+// mockBatchHeads expects a single batched request for the heads from n down
+// to base inclusive.  The given heads are returned and all others are not
+// found.
+func mockBatchHeads(ethClient *mocks.Client, n, base int64, heads ...*models.Head) *mock.Call {
+	return ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		if int64(len(b)) != n-base+1 {
+			return false
+		}
+		for i, req := range b {
+			if req.Method != "eth_getBlockByNumber" || req.Args[0] != hexutil.EncodeBig(big.NewInt(n-int64(i))) {
+				return false
+			}
+		}
+		return true
+	})).Run(func(args mock.Arguments) {
+		b := args.Get(1).([]rpc.BatchElem)
+		for _, head := range heads {
+			*b[n-head.Number].Result.(**models.Head) = head
+		}
+	})
+}
+
 func TestHeadTracker_New(t *testing.T) {
 	t.Parallel()
 
@@ -123,6 +163,7 @@ func TestHeadTracker_Get(t *testing.T) {
 				num := args.Get(1).(*big.Int)
 				fnCall.ReturnArguments = mock.Arguments{cltest.Head(num.Int64()), nil}
 			}
+			mockBatchHeadersByNumber(ethClient)
 
 			if test.initial != nil {
 				assert.Nil(t, store.IdempotentInsertHead(*test.initial))
@@ -188,6 +229,7 @@ func TestHeadTracker_CallsHeadTrackableCallbacks(t *testing.T) {
 		}).
 		Return(sub, nil)
 	ethClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(cltest.Head(1), nil)
+	mockBatchHeadersByNumber(ethClient)
 
 	sub.On("Unsubscribe").Return()
 	sub.On("Err").Return(nil)
@@ -212,6 +254,59 @@ func TestHeadTracker_CallsHeadTrackableCallbacks(t *testing.T) {
 	assert.Equal(t, int32(1), checker.OnNewLongestChainCount())
 }
 
+func TestHeadTracker_TaggedFinality(t *testing.T) {
+	t.Parallel()
+	g := gomega.NewGomegaWithT(t)
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("ETH_FINALITY_MODE", "Tag")
+
+	sub := new(mocks.Subscription)
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	chchHeaders := make(chan chan<- *models.Head, 1)
+	ethClient.On("ChainID", mock.Anything).Return(store.Config.ChainID(), nil)
+	ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			chchHeaders <- args.Get(1).(chan<- *models.Head)
+		}).
+		Return(sub, nil)
+	ethClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(cltest.Head(100), nil)
+	ethClient.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == 2 &&
+			b[0].Method == "eth_getBlockByNumber" && b[0].Args[0] == "finalized" &&
+			b[1].Method == "eth_getBlockByNumber" && b[1].Args[0] == "safe"
+	})).Run(func(args mock.Arguments) {
+		b := args.Get(1).([]rpc.BatchElem)
+		*b[0].Result.(**models.Head) = cltest.Head(36)
+		*b[1].Result.(**models.Head) = cltest.Head(90)
+	}).Return(nil)
+	mockBatchHeadersByNumber(ethClient)
+
+	sub.On("Unsubscribe").Return()
+	sub.On("Err").Return(nil)
+
+	checker := &cltest.MockHeadTrackable{}
+	ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{checker}, cltest.NeverSleeper{})
+	require.NoError(t, ht.Start())
+	defer ht.Stop()
+
+	headers := <-chchHeaders
+	headers <- cltest.Head(100)
+	g.Eventually(func() int32 { return checker.OnNewLongestChainCount() }).Should(gomega.Equal(int32(1)))
+
+	// The finalized and safe blocks are the node's, not a depth below the head
+	assert.True(t, store.HeadChain.TaggedFinality())
+	finalized, known := store.HeadChain.FinalizedBlockNumber()
+	require.True(t, known)
+	assert.Equal(t, int64(36), finalized)
+	safe, known := store.HeadChain.SafeBlockNumber()
+	require.True(t, known)
+	assert.Equal(t, int64(90), safe)
+}
+
 func TestHeadTracker_ReconnectOnError(t *testing.T) {
 	t.Parallel()
 	g := gomega.NewGomegaWithT(t)
@@ -278,6 +373,7 @@ func TestHeadTracker_StartConnectsFromLastSavedHeader(t *testing.T) {
 		}
 		fnCall.ReturnArguments = mock.Arguments{head, nil}
 	}
+	mockBatchHeadersByNumber(ethClient)
 
 	sub.On("Unsubscribe").Return()
 	sub.On("Err").Return(nil)
@@ -421,6 +517,7 @@ func TestHeadTracker_SwitchesToLongestChain(t *testing.T) {
 		}
 		fnCall.ReturnArguments = mock.Arguments{head, nil}
 	}
+	mockBatchHeadersByNumber(ethClient)
 	for _, h := range blockHeaders {
 		latestHeadByNumberMu.Lock()
 		latestHeadByNumber[h.Number] = h
@@ -444,6 +541,75 @@ func TestHeadTracker_SwitchesToLongestChain(t *testing.T) {
 	checker.AssertExpectations(t)
 }
 
+// reorgTrackable is a HeadTrackable that also records reorgs
+type reorgTrackable struct {
+	*mocks.HeadTrackable
+	reorgs chan models.Reorg
+}
+
+func (rt reorgTrackable) OnReorg(_ context.Context, reorg models.Reorg) {
+	rt.reorgs <- reorg
+}
+
+func TestHeadTracker_OnReorg(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("ETH_HEAD_TRACKER_MAX_BUFFER_SIZE", 42)
+
+	sub := new(mocks.Subscription)
+	ethClient := new(mocks.Client)
+	store.EthClient = ethClient
+
+	checker := reorgTrackable{new(mocks.HeadTrackable), make(chan models.Reorg, 1)}
+	ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{checker}, cltest.NeverSleeper{})
+
+	chchHeaders := make(chan chan<- *models.Head, 1)
+	ethClient.On("ChainID", mock.Anything).Return(store.Config.ChainID(), nil)
+	ethClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { chchHeaders <- args.Get(1).(chan<- *models.Head) }).
+		Return(sub, nil)
+	ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(0)).Return(cltest.Head(0), nil)
+	mockBatchHeadersByNumber(ethClient)
+	sub.On("Unsubscribe").Return()
+	sub.On("Err").Return(nil)
+	checker.On("Connect", mock.Anything).Return(nil).Once()
+	checker.On("Disconnect").Return(nil).Once()
+
+	head1 := &models.Head{Number: 1, Hash: cltest.NewHash(), ParentHash: cltest.NewHash()}
+	head2 := &models.Head{Number: 2, Hash: cltest.NewHash(), ParentHash: head1.Hash}
+	head3 := &models.Head{Number: 3, Hash: cltest.NewHash(), ParentHash: head2.Hash}
+	// Reorg forking from block 1
+	head2b := &models.Head{Number: 2, Hash: cltest.NewHash(), ParentHash: head1.Hash}
+	head3b := &models.Head{Number: 3, Hash: cltest.NewHash(), ParentHash: head2b.Hash}
+	head4b := &models.Head{Number: 4, Hash: cltest.NewHash(), ParentHash: head3b.Hash}
+
+	longestChains := make(chan models.Head, 6)
+	checker.On("OnNewLongestChain", mock.Anything, mock.Anything).Return().Run(func(args mock.Arguments) {
+		longestChains <- args.Get(1).(models.Head)
+	})
+
+	require.NoError(t, ht.Start())
+	headers := <-chchHeaders
+	for _, h := range []*models.Head{head1, head2, head3} {
+		headers <- h
+		assert.Equal(t, h.Hash, (<-longestChains).Hash)
+	}
+	for _, h := range []*models.Head{head2b, head3b, head4b} {
+		headers <- h
+	}
+	assert.Equal(t, head4b.Hash, (<-longestChains).Hash)
+	require.NoError(t, ht.Stop())
+
+	require.Len(t, checker.reorgs, 1)
+	reorg := <-checker.reorgs
+	assert.Equal(t, int64(2), reorg.Depth)
+	assert.Equal(t, []gethCommon.Hash{head3.Hash, head2.Hash}, reorg.Removed)
+	assert.Equal(t, []gethCommon.Hash{head4b.Hash, head3b.Hash, head2b.Hash}, reorg.Added)
+	checker.AssertExpectations(t)
+}
+
 func TestHeadTracker_GetChainWithBackfill(t *testing.T) {
 	t.Parallel()
 
@@ -551,8 +717,7 @@ func TestHeadTracker_GetChainWithBackfill(t *testing.T) {
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
 
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(10)).
-			Return(&head10, nil)
+		mockBatchHeads(ethClient, 10, 10, &head10).Return(nil).Once()
 
 		ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{}, cltest.NeverSleeper{})
 
@@ -585,10 +750,8 @@ func TestHeadTracker_GetChainWithBackfill(t *testing.T) {
 
 		ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{}, cltest.NeverSleeper{})
 
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(10)).
-			Return(&head10, nil)
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(8)).
-			Return(&head8, nil)
+		mockBatchHeads(ethClient, 10, 10, &head10).Return(nil).Once()
+		mockBatchHeads(ethClient, 8, 8, &head8).Return(nil).Once()
 
 		// Needs to be 8 because there are 8 heads in chain (15,14,13,12,11,10,9,8)
 		h, err := ht.GetChainWithBackfill(ctx, h15, 8)
@@ -651,8 +814,7 @@ func TestHeadTracker_GetChainWithBackfill(t *testing.T) {
 
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(0)).
-			Return(&head0, nil)
+		mockBatchHeads(ethClient, 0, 0, &head0).Return(nil).Once()
 
 		ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{}, cltest.NeverSleeper{})
 
@@ -675,12 +837,9 @@ func TestHeadTracker_GetChainWithBackfill(t *testing.T) {
 
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(10)).
-			Return(&head10, nil).
-			Once()
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(8)).
-			Return(nil, ethereum.NotFound).
-			Once()
+		mockBatchHeads(ethClient, 10, 10, &head10).Return(nil).Once()
+		// Head 8 is not found
+		mockBatchHeads(ethClient, 8, 0).Return(nil).Once()
 
 		ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{}, cltest.NeverSleeper{})
 
@@ -703,10 +862,8 @@ func TestHeadTracker_GetChainWithBackfill(t *testing.T) {
 
 		ethClient := new(mocks.Client)
 		store.EthClient = ethClient
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(10)).
-			Return(&head10, nil)
-		ethClient.On("HeaderByNumber", mock.Anything, big.NewInt(8)).
-			Return(nil, context.DeadlineExceeded)
+		mockBatchHeads(ethClient, 10, 10, &head10).Return(nil).Once()
+		mockBatchHeads(ethClient, 8, 0).Return(context.DeadlineExceeded).Once()
 
 		ht := services.NewHeadTracker(store, []strpkg.HeadTrackable{}, cltest.NeverSleeper{})
 
@@ -762,6 +919,7 @@ func TestHeadTracker_RingBuffer(t *testing.T) {
 			Return(sub, nil)
 		// We don't care about this since we're not testing backfilling, just return anything
 		ethClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(cltest.Head(42), nil)
+		mockBatchHeadersByNumber(ethClient)
 
 		sub.On("Unsubscribe").Return()
 		sub.On("Err").Return(nil)
@@ -105,7 +105,7 @@ func (oc *OCRContract) SubscribeToNewConfigs(context.Context) (ocrtypes.Contract
 		sync.Once{},
 		make(chan struct{}),
 	}
-	connected := oc.logBroadcaster.Register(oc.contractAddress, sub)
+	connected := oc.logBroadcaster.RegisterWithTopics(oc.contractAddress, [][]gethCommon.Hash{{OCRContractConfigSet}}, sub)
 	if !connected {
 		return nil, errors.New("Failed to register with logBroadcaster")
 	}
@@ -312,3 +312,27 @@ func getConfigSetHash() gethCommon.Hash {
 	}
 	return abi.Events["ConfigSet"].ID
 }
+
+// finalityConfigTracker reports a block height high enough for a config that
+// was changed in a block the node reported as finalized to have the job's
+// contract config confirmations, since that config can never be reorged out.
+// libocr only uses the height to count the confirmations of the latest config.
+type finalityConfigTracker struct {
+	ocrtypes.ContractConfigTracker
+	finality      eth.Finality
+	confirmations uint16
+}
+
+func (t finalityConfigTracker) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	height, err := t.ContractConfigTracker.LatestBlockHeight(ctx)
+	if err != nil {
+		return height, err
+	}
+	if !t.finality.TaggedFinality() {
+		return height, nil
+	}
+	if finalized, known := t.finality.FinalizedBlockNumber(); known && uint64(finalized)+uint64(t.confirmations) > height {
+		return uint64(finalized) + uint64(t.confirmations), nil
+	}
+	return height, nil
+}
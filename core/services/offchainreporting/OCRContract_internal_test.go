@@ -0,0 +1,49 @@
+package offchainreporting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blockHeightTracker struct {
+	ocrtypes.ContractConfigTracker
+	height uint64
+}
+
+func (t blockHeightTracker) LatestBlockHeight(context.Context) (uint64, error) {
+	return t.height, nil
+}
+
+func TestFinalityConfigTracker_LatestBlockHeight(t *testing.T) {
+	headChain := eth.NewHeadChain(10)
+	tracker := finalityConfigTracker{blockHeightTracker{height: 100}, headChain, 80}
+
+	// Without a finalized block, the height is the chain's
+	height, err := tracker.LatestBlockHeight(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), height)
+
+	// A finalized block at a depth below the head is not enough
+	headChain.SetFinality(50, 90)
+	height, err = tracker.LatestBlockHeight(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), height)
+
+	// A config changed in a block the node reported as finalized has enough
+	// confirmations
+	headChain.SetTaggedFinality(50, 90)
+	height, err = tracker.LatestBlockHeight(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(130), height)
+
+	// Deep enough configs are unaffected
+	tracker.confirmations = 10
+	height, err = tracker.LatestBlockHeight(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), height)
+}
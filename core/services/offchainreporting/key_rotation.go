@@ -0,0 +1,218 @@
+package offchainreporting
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+// KeyRotationJob is an OCR job that uses a rotated key, and that will switch
+// to its successor
+type KeyRotationJob struct {
+	JobID           int32               `json:"jobID"`
+	ContractAddress models.EIP55Address `json:"contractAddress"`
+}
+
+// RotateOCRKeyBundle generates a successor to the OCR key bundle with the
+// given ID, and returns it along with the jobs that use the rotated bundle.
+// Those jobs keep signing with the rotated bundle until the on-chain config
+// of their contract lists the signing address of the successor.
+func (ks KeyStore) RotateOCRKeyBundle(id models.Sha256Hash) (ocrkey.EncryptedKeyBundle, []KeyRotationJob, error) {
+	_, next, err := ks.GenerateEncryptedOCRKeyBundle()
+	if err != nil {
+		return ocrkey.EncryptedKeyBundle{}, nil, err
+	}
+	err = ks.Exec(`
+		UPDATE offchainreporting_oracle_specs SET next_encrypted_ocr_key_bundle_id = ?, updated_at = NOW()
+		WHERE encrypted_ocr_key_bundle_id = ?
+	`, next.ID, id).Error
+	if err != nil {
+		return ocrkey.EncryptedKeyBundle{}, nil, errors.Wrap(err, "while setting next OCR key bundle of jobs")
+	}
+	jobs, err := ks.findKeyRotationJobs("encrypted_ocr_key_bundle_id", id)
+	return next, jobs, err
+}
+
+// RotateP2PKey generates a successor to the P2P key with the given peer ID,
+// and returns it along with the jobs that use the rotated key. A job only
+// watches the on-chain config for the signing address of its next OCR key
+// bundle, so it switches to the successor together with that bundle, if it is
+// being rotated as well, and otherwise the next time the job starts.
+func (ks KeyStore) RotateP2PKey(peerID models.PeerID) (p2pkey.EncryptedP2PKey, []KeyRotationJob, error) {
+	_, next, err := ks.GenerateEncryptedP2PKey()
+	if err != nil {
+		return p2pkey.EncryptedP2PKey{}, nil, err
+	}
+	err = ks.Exec(`
+		UPDATE offchainreporting_oracle_specs SET next_p2p_peer_id = ?, updated_at = NOW()
+		WHERE p2p_peer_id = ?
+	`, next.PeerID, peerID).Error
+	if err != nil {
+		return p2pkey.EncryptedP2PKey{}, nil, errors.Wrap(err, "while setting next P2P key of jobs")
+	}
+	jobs, err := ks.findKeyRotationJobs("p2p_peer_id", peerID)
+	return next, jobs, err
+}
+
+func (ks KeyStore) findKeyRotationJobs(column string, value interface{}) ([]KeyRotationJob, error) {
+	jobs := []KeyRotationJob{}
+	err := ks.Raw(`
+		SELECT jobs.id AS job_id, offchainreporting_oracle_specs.contract_address
+		FROM jobs
+		INNER JOIN offchainreporting_oracle_specs ON offchainreporting_oracle_specs.id = jobs.offchainreporting_oracle_spec_id
+		WHERE offchainreporting_oracle_specs.`+column+` = ?
+		ORDER BY jobs.id ASC
+	`, value).Scan(&jobs).Error
+	return jobs, errors.Wrap(err, "while finding jobs using rotated key")
+}
+
+// nextOCRKeyBundle returns the next OCR key bundle of the spec, if any
+func (ks KeyStore) nextOCRKeyBundle(specID int32) (*ocrkey.KeyBundle, error) {
+	var spec models.OffchainReportingOracleSpec
+	if err := ks.Where("id = ?", specID).First(&spec).Error; err != nil {
+		return nil, errors.Wrap(err, "while loading next OCR key bundle")
+	}
+	if spec.NextEncryptedOCRKeyBundleID == nil {
+		return nil, nil
+	}
+	key, exists := ks.DecryptedOCRKey(*spec.NextEncryptedOCRKeyBundleID)
+	if !exists {
+		return nil, errors.Errorf("next OCR key '%v' does not exist", *spec.NextEncryptedOCRKeyBundleID)
+	}
+	return &key, nil
+}
+
+// promoteNextOCRKeyBundle makes the next OCR key bundle of the spec, and its
+// next P2P key if any, its current keys. It returns the peer ID of the P2P key
+// switched to, if any.
+func (ks KeyStore) promoteNextOCRKeyBundle(specID int32) (*models.PeerID, error) {
+	var spec models.OffchainReportingOracleSpec
+	if err := ks.Where("id = ?", specID).First(&spec).Error; err != nil {
+		return nil, errors.Wrap(err, "while switching to next OCR key bundle")
+	}
+	err := ks.Exec(`
+		UPDATE offchainreporting_oracle_specs SET
+			encrypted_ocr_key_bundle_id = next_encrypted_ocr_key_bundle_id,
+			next_encrypted_ocr_key_bundle_id = NULL,
+			p2p_peer_id = COALESCE(next_p2p_peer_id, p2p_peer_id),
+			next_p2p_peer_id = NULL,
+			updated_at = NOW()
+		WHERE id = ? AND next_encrypted_ocr_key_bundle_id IS NOT NULL
+	`, specID).Error
+	return spec.NextP2PPeerID, errors.Wrap(err, "while switching to next OCR key bundle")
+}
+
+// promoteNextP2PKey makes the next P2P key of the spec its current P2P key
+func (ks KeyStore) promoteNextP2PKey(specID int32) error {
+	return ks.Exec(`
+		UPDATE offchainreporting_oracle_specs SET
+			p2p_peer_id = next_p2p_peer_id,
+			next_p2p_peer_id = NULL,
+			updated_at = NOW()
+		WHERE id = ? AND next_p2p_peer_id IS NOT NULL
+	`, specID).Error
+}
+
+// rotatingKeyBundle signs with the current OCR key bundle of a job, until a
+// config of its contract lists the signing address of the next bundle. The
+// next bundle is looked up on every config change, since the job may have
+// started before the bundle was rotated.
+type rotatingKeyBundle struct {
+	current  *ocrkey.KeyBundle
+	next     *ocrkey.KeyBundle
+	findNext func() (*ocrkey.KeyBundle, error)
+	onRotate func()
+	mu       sync.RWMutex
+}
+
+var _ ocrtypes.PrivateKeys = (*rotatingKeyBundle)(nil)
+
+func newRotatingKeyBundle(current *ocrkey.KeyBundle, findNext func() (*ocrkey.KeyBundle, error), onRotate func()) *rotatingKeyBundle {
+	return &rotatingKeyBundle{current: current, findNext: findNext, onRotate: onRotate}
+}
+
+func (kb *rotatingKeyBundle) active() *ocrkey.KeyBundle {
+	kb.mu.RLock()
+	defer kb.mu.RUnlock()
+	if kb.next != nil {
+		return kb.next
+	}
+	return kb.current
+}
+
+// configChanged switches to the next bundle, once and for all, if the config
+// lists its signing address
+func (kb *rotatingKeyBundle) configChanged(config ocrtypes.ContractConfig) error {
+	kb.mu.RLock()
+	rotated := kb.next != nil
+	kb.mu.RUnlock()
+	if rotated {
+		return nil
+	}
+
+	next, err := kb.findNext()
+	if err != nil || next == nil {
+		return err
+	}
+	nextSigner := common.Address(next.PublicKeyAddressOnChain())
+	listed := false
+	for _, signer := range config.Signers {
+		listed = listed || signer == nextSigner
+	}
+	if !listed {
+		return nil
+	}
+
+	kb.mu.Lock()
+	kb.next = next
+	kb.mu.Unlock()
+	kb.onRotate()
+	return nil
+}
+
+func (kb *rotatingKeyBundle) SignOnChain(msg []byte) ([]byte, error) {
+	return kb.active().SignOnChain(msg)
+}
+
+func (kb *rotatingKeyBundle) SignOffChain(msg []byte) ([]byte, error) {
+	return kb.active().SignOffChain(msg)
+}
+
+func (kb *rotatingKeyBundle) ConfigDiffieHellman(base *[curve25519.ScalarSize]byte) (*[curve25519.PointSize]byte, error) {
+	return kb.active().ConfigDiffieHellman(base)
+}
+
+func (kb *rotatingKeyBundle) PublicKeyAddressOnChain() ocrtypes.OnChainSigningAddress {
+	return kb.active().PublicKeyAddressOnChain()
+}
+
+func (kb *rotatingKeyBundle) PublicKeyOffChain() ocrtypes.OffchainPublicKey {
+	return kb.active().PublicKeyOffChain()
+}
+
+func (kb *rotatingKeyBundle) PublicKeyConfig() [curve25519.PointSize]byte {
+	return kb.active().PublicKeyConfig()
+}
+
+// rotatingConfigTracker passes every config of the contract it tracks to the
+// rotatingKeyBundle, before the oracle looks for its keys in the config
+type rotatingConfigTracker struct {
+	ocrtypes.ContractConfigTracker
+	keys *rotatingKeyBundle
+}
+
+func (t rotatingConfigTracker) ConfigFromLogs(ctx context.Context, changedInBlock uint64) (ocrtypes.ContractConfig, error) {
+	config, err := t.ContractConfigTracker.ConfigFromLogs(ctx, changedInBlock)
+	if err != nil {
+		return config, err
+	}
+	return config, errors.Wrap(t.keys.configChanged(config), "could not check for next OCR key")
+}
@@ -0,0 +1,55 @@
+package offchainreporting
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingKeyBundle_ConfigChanged(t *testing.T) {
+	current, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	next, err := ocrkey.NewKeyBundle()
+	require.NoError(t, err)
+	currentSigner := common.Address(current.PublicKeyAddressOnChain())
+	nextSigner := common.Address(next.PublicKeyAddressOnChain())
+
+	var findNextErr error
+	var nextKey *ocrkey.KeyBundle
+	rotations := 0
+	kb := newRotatingKeyBundle(current, func() (*ocrkey.KeyBundle, error) {
+		return nextKey, findNextErr
+	}, func() {
+		rotations++
+	})
+
+	require.NoError(t, kb.configChanged(ocrtypes.ContractConfig{Signers: []common.Address{currentSigner}}))
+	assert.Equal(t, current.PublicKeyAddressOnChain(), kb.PublicKeyAddressOnChain())
+
+	// The bundle has been rotated, but the config does not list the successor
+	nextKey = next
+	require.NoError(t, kb.configChanged(ocrtypes.ContractConfig{Signers: []common.Address{currentSigner}}))
+	assert.Equal(t, current.PublicKeyAddressOnChain(), kb.PublicKeyAddressOnChain())
+	assert.Equal(t, 0, rotations)
+
+	findNextErr = errors.New("database is down")
+	require.Error(t, kb.configChanged(ocrtypes.ContractConfig{Signers: []common.Address{nextSigner}}))
+	assert.Equal(t, current.PublicKeyAddressOnChain(), kb.PublicKeyAddressOnChain())
+
+	findNextErr = nil
+	require.NoError(t, kb.configChanged(ocrtypes.ContractConfig{Signers: []common.Address{nextSigner}}))
+	assert.Equal(t, next.PublicKeyAddressOnChain(), kb.PublicKeyAddressOnChain())
+	assert.Equal(t, next.PublicKeyOffChain(), kb.PublicKeyOffChain())
+	assert.Equal(t, next.PublicKeyConfig(), kb.PublicKeyConfig())
+	assert.Equal(t, 1, rotations)
+
+	// The switch is final
+	require.NoError(t, kb.configChanged(ocrtypes.ContractConfig{Signers: []common.Address{currentSigner}}))
+	assert.Equal(t, next.PublicKeyAddressOnChain(), kb.PublicKeyAddressOnChain())
+	assert.Equal(t, 1, rotations)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/services/job"
 	"github.com/smartcontractkit/chainlink/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	ocrkeypkg "github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	ocrnetworking "github.com/smartcontractkit/libocr/networking"
@@ -32,9 +33,10 @@ func RegisterJobType(
 	pipelineRunner pipeline.Runner,
 	ethClient eth.Client,
 	logBroadcaster eth.LogBroadcaster,
+	finality eth.Finality,
 ) {
 	jobSpawner.RegisterDelegate(
-		NewJobSpawnerDelegate(db, jobORM, config, keyStore, pipelineRunner, ethClient, logBroadcaster),
+		NewJobSpawnerDelegate(db, jobORM, config, keyStore, pipelineRunner, ethClient, logBroadcaster, finality),
 	)
 }
 
@@ -46,6 +48,7 @@ type jobSpawnerDelegate struct {
 	pipelineRunner pipeline.Runner
 	ethClient      eth.Client
 	logBroadcaster eth.LogBroadcaster
+	finality       eth.Finality
 }
 
 func NewJobSpawnerDelegate(
@@ -56,8 +59,9 @@ func NewJobSpawnerDelegate(
 	pipelineRunner pipeline.Runner,
 	ethClient eth.Client,
 	logBroadcaster eth.LogBroadcaster,
+	finality eth.Finality,
 ) *jobSpawnerDelegate {
-	return &jobSpawnerDelegate{db, jobORM, config, keyStore, pipelineRunner, ethClient, logBroadcaster}
+	return &jobSpawnerDelegate{db, jobORM, config, keyStore, pipelineRunner, ethClient, logBroadcaster, finality}
 }
 
 func (d jobSpawnerDelegate) JobType() job.Type {
@@ -89,6 +93,8 @@ func (d jobSpawnerDelegate) ServicesForSpec(spec job.Spec) ([]job.Service, error
 	}
 
 	gasLimit := d.config.EthGasLimitDefault()
+	// Transmissions can't use the key selection policy: the OCR contract only
+	// accepts them from the transmitter address configured for this oracle.
 	transmitter := NewTransmitter(d.db.DB(), concreteSpec.TransmitterAddress.Address(), gasLimit)
 
 	ocrContract, err := NewOCRContract(
@@ -103,6 +109,17 @@ func (d jobSpawnerDelegate) ServicesForSpec(spec job.Spec) ([]job.Service, error
 		return nil, err
 	}
 
+	// Only the signing addresses in the on-chain config are watched for the
+	// switch, so a P2P key rotated without the OCR key bundle is used from the
+	// next start of the job
+	if concreteSpec.NextP2PPeerID != nil && (concreteSpec.NextEncryptedOCRKeyBundleID == nil || concreteSpec.IsBootstrapPeer) {
+		if err = d.keyStore.promoteNextP2PKey(concreteSpec.ID); err != nil {
+			return nil, errors.Wrap(err, "could not switch to next P2P key")
+		}
+		concreteSpec.P2PPeerID = *concreteSpec.NextP2PPeerID
+		concreteSpec.NextP2PPeerID = nil
+	}
+
 	p2pkey, exists := d.keyStore.DecryptedP2PKey(peer.ID(concreteSpec.P2PPeerID))
 	if !exists {
 		return nil, errors.Errorf("P2P key '%v' does not exist", concreteSpec.P2PPeerID)
@@ -159,12 +176,28 @@ func (d jobSpawnerDelegate) ServicesForSpec(spec job.Spec) ([]job.Service, error
 		return nil, err
 	}
 
+	privateKeys := newRotatingKeyBundle(&ocrkey, func() (*ocrkeypkg.KeyBundle, error) {
+		return d.keyStore.nextOCRKeyBundle(concreteSpec.ID)
+	}, func() {
+		loggerWith.Info("On-chain config lists the next OCR key bundle of the job, switching to it")
+		nextP2PPeerID, err := d.keyStore.promoteNextOCRKeyBundle(concreteSpec.ID)
+		if err != nil {
+			loggerWith.Errorw("Could not save switch to next OCR key bundle", "error", err)
+		} else if nextP2PPeerID != nil {
+			loggerWith.Warnw("Switched to next OCR key bundle, restart the node to switch to next P2P key", "nextP2PPeerID", *nextP2PPeerID)
+		}
+	})
+	configTracker := rotatingConfigTracker{
+		finalityConfigTracker{ocrContract, d.finality, concreteSpec.ContractConfigConfirmations},
+		privateKeys,
+	}
+
 	var service job.Service
 	if concreteSpec.IsBootstrapPeer {
 		service, err = ocr.NewBootstrapNode(ocr.BootstrapNodeArgs{
 			BootstrapperFactory:   peer,
 			Bootstrappers:         concreteSpec.P2PBootstrapPeers,
-			ContractConfigTracker: ocrContract,
+			ContractConfigTracker: configTracker,
 			Database:              NewDB(d.db.DB(), concreteSpec.ID),
 			LocalConfig: ocrtypes.LocalConfig{
 				BlockchainTimeout:                      time.Duration(concreteSpec.BlockchainTimeout),
@@ -195,8 +228,8 @@ func (d jobSpawnerDelegate) ServicesForSpec(spec job.Spec) ([]job.Service, error
 			Database:                     NewDB(d.db.DB(), concreteSpec.ID),
 			Datasource:                   dataSource{jobID: concreteSpec.JobID(), pipelineRunner: d.pipelineRunner},
 			ContractTransmitter:          ocrContract,
-			ContractConfigTracker:        ocrContract,
-			PrivateKeys:                  &ocrkey,
+			ContractConfigTracker:        configTracker,
+			PrivateKeys:                  privateKeys,
 			BinaryNetworkEndpointFactory: peer,
 			MonitoringEndpoint:           ocrtypes.MonitoringEndpoint(nil),
 			Logger:                       ocrLogger,
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
 	gethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 )
@@ -23,11 +25,13 @@ func NewTransmitter(sqldb *sql.DB, fromAddress gethCommon.Address, gasLimit uint
 	}
 }
 
+// CreateEthTransaction queues a transmission. Transmissions are sent ahead of
+// the address's other transactions, since they are worthless once late.
 func (t *transmitter) CreateEthTransaction(ctx context.Context, toAddress gethCommon.Address, payload []byte) error {
 	_, err := t.db.ExecContext(ctx, `
-INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, state, created_at)
-VALUES ($1,$2,$3,$4,$5,'unstarted',NOW())
-`, t.fromAddress, toAddress, payload, 0, t.gasLimit)
+INSERT INTO eth_txes (from_address, to_address, encoded_payload, value, gas_limit, priority, state, created_at)
+VALUES ($1,$2,$3,$4,$5,$6,'unstarted',NOW())
+`, t.fromAddress, toAddress, payload, 0, t.gasLimit, models.EthTxPriorityHigh)
 
 	return errors.Wrap(err, "failed to create eth_tx")
 }
@@ -35,4 +35,5 @@ func Test_Transmitter_CreateEthTransaction(t *testing.T) {
 	require.Equal(t, toAddress, etx.ToAddress)
 	require.Equal(t, payload, etx.EncodedPayload)
 	require.Equal(t, assets.NewEthValue(0), etx.Value)
+	require.Equal(t, int32(models.EthTxPriorityHigh), etx.Priority)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -45,19 +46,37 @@ type (
 		DefaultHTTPTimeout() models.Duration
 		DefaultMaxHTTPAttempts() uint
 		DefaultHTTPAllowUnrestrictedNetworkAccess() bool
+		JobPipelineBridgeAsyncTimeout() time.Duration
 		JobPipelineDBPollInterval() time.Duration
 		JobPipelineMaxTaskDuration() time.Duration
 		JobPipelineParallelism() uint8
 		JobPipelineReaperInterval() time.Duration
 		JobPipelineReaperThreshold() time.Duration
+		OutboundRequestsMaxInFlight() int
+		OutboundRequestsPerSecond() float64
 	}
 )
 
 var (
 	ErrWrongInputCardinality = errors.New("wrong number of task inputs")
 	ErrBadInput              = errors.New("bad input for task")
+	ErrTaskRunNotPending     = errors.New("task run is not pending")
 )
 
+// PendingError is returned by a Task that has handed its work off to an
+// external system and is waiting to be called back.  The task run is
+// suspended until it is resumed with Runner.ResumeTaskRun, or until Timeout
+// elapses, at which point it is marked as errored.  ResumeTokenHash is the
+// hash of the token that the external system must present to resume it.
+type PendingError struct {
+	Timeout         time.Duration
+	ResumeTokenHash string
+}
+
+func (e PendingError) Error() string {
+	return fmt.Sprintf("task run is pending (timeout: %v)", e.Timeout)
+}
+
 type BaseTask struct {
 	outputTask Task
 	dotID      string `mapstructure:"-"`
@@ -117,7 +136,7 @@ const (
 
 const ResultTaskDotID = "__result__"
 
-func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, dotID string, config Config, txdb *gorm.DB) (_ Task, err error) {
+func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, dotID string, config Config, txdb *gorm.DB, secrets models.SecretResolver) (_ Task, err error) {
 	defer utils.WrapIfError(&err, "UnmarshalTaskFromMap")
 
 	switch taskMap.(type) {
@@ -131,7 +150,7 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, dotID string,
 	var task Task
 	switch taskType {
 	case TaskTypeHTTP:
-		task = &HTTPTask{config: config, BaseTask: BaseTask{dotID: dotID}}
+		task = &HTTPTask{config: config, secrets: secrets, BaseTask: BaseTask{dotID: dotID}}
 	case TaskTypeBridge:
 		task = &BridgeTask{config: config, txdb: txdb, BaseTask: BaseTask{dotID: dotID}}
 	case TaskTypeMedian:
@@ -169,6 +188,22 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, dotID string,
 					case reflect.TypeOf(decimal.Decimal{}):
 						return decimal.NewFromString(data.(string))
 
+					case reflect.TypeOf(models.HTTPAuth{}):
+						var auth models.HTTPAuth
+						err2 := json.Unmarshal([]byte(data.(string)), &auth)
+						return auth, err2
+					case reflect.TypeOf(&models.HTTPAuth{}):
+						var auth models.HTTPAuth
+						err2 := json.Unmarshal([]byte(data.(string)), &auth)
+						return &auth, err2
+
+					case reflect.TypeOf(models.Duration{}):
+						d, err2 := time.ParseDuration(data.(string))
+						if err2 != nil {
+							return nil, err2
+						}
+						return models.MakeDuration(d)
+
 					case reflect.TypeOf(int32(0)):
 						i, err2 := strconv.ParseInt(data.(string), 10, 32)
 						return int32(i), err2
@@ -4,6 +4,8 @@ import (
 	"reflect"
 
 	"github.com/jinzhu/gorm"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
 )
 
 func NewBaseTask(dotID string, t Task, index int32) BaseTask {
@@ -19,6 +21,10 @@ func (t *HTTPTask) HelperSetConfig(config Config) {
 	t.config = config
 }
 
+func (t *HTTPTask) HelperSetSecrets(secrets models.SecretResolver) {
+	t.secrets = secrets
+}
+
 func (t ResultTask) ExportedEquals(otherTask Task) bool {
 	other, ok := otherTask.(*ResultTask)
 	if !ok {
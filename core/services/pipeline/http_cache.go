@@ -0,0 +1,168 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+var (
+	promHTTPCacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_http_cache_hits",
+		Help: "The number of pipeline HTTP/bridge task requests that were served from the response cache",
+	},
+		[]string{"task_type"},
+	)
+	promHTTPCacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_http_cache_misses",
+		Help: "The number of pipeline HTTP/bridge task requests that were not found in the response cache",
+	},
+		[]string{"task_type"},
+	)
+	promHTTPCacheCoalesced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pipeline_http_cache_coalesced",
+		Help: "The number of pipeline HTTP/bridge task requests that were de-duplicated with an identical in-flight request",
+	},
+		[]string{"task_type"},
+	)
+)
+
+// httpCacheSweepInterval is the minimum amount of time between sweeps of
+// expired entries from the cache
+const httpCacheSweepInterval = 1 * time.Minute
+
+// sharedHTTPResponseCache is shared by every HTTPTask and BridgeTask that
+// opts in to caching, so that identical requests made by different jobs are
+// only sent once per TTL window
+var sharedHTTPResponseCache = newHTTPResponseCache()
+
+type (
+	// httpResponseCache caches successful HTTP responses for a per-request
+	// TTL, and coalesces identical concurrent requests into a single outbound
+	// request.
+	httpResponseCache struct {
+		entries   map[string]httpCacheEntry
+		lastSwept time.Time
+		mu        sync.RWMutex
+		inFlight  singleflight.Group
+	}
+
+	httpCacheEntry struct {
+		responseBytes []byte
+		expiresAt     time.Time
+	}
+
+	httpResponse struct {
+		responseBytes []byte
+		statusCode    int
+	}
+
+	httpFetchFunc func(ctx context.Context) (responseBytes []byte, statusCode int, err error)
+)
+
+func newHTTPResponseCache() *httpResponseCache {
+	return &httpResponseCache{
+		entries:   make(map[string]httpCacheEntry),
+		lastSwept: time.Now(),
+	}
+}
+
+// httpCacheKey identifies a request by its method, URL, normalized JSON body
+// and the identity of the credentials it is authenticated with, so that
+// requests with semantically identical bodies share an entry, but responses
+// are never served to a request made with other credentials
+func httpCacheKey(method, url string, requestData HttpRequestData, authIdentity string) (string, error) {
+	var body string
+	if requestData != nil {
+		bodyBytes, err := json.Marshal(requestData)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to encode request body as JSON")
+		}
+		body, err = utils.NormalizedJSON(bodyBytes)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to normalize request body")
+		}
+	}
+	hash := sha256.Sum256([]byte(method + "\n" + url + "\n" + authIdentity + "\n" + body))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Fetch returns the cached response for key if there is one.  Otherwise it
+// calls fetch, de-duplicating concurrent calls with the same key, and caches
+// the response for ttl if the request succeeded.
+//
+// The shared fetch is not tied to any one caller's context, so that a caller
+// giving up does not fail the others waiting on it.  It is instead cancelled
+// after fetchTimeout, and each caller stops waiting once its ctx is done.
+func (c *httpResponseCache) Fetch(ctx context.Context, taskType TaskType, key string, ttl, fetchTimeout time.Duration, fetch httpFetchFunc) ([]byte, int, error) {
+	if responseBytes, exists := c.get(key); exists {
+		promHTTPCacheHits.WithLabelValues(string(taskType)).Inc()
+		return responseBytes, 200, nil
+	}
+	promHTTPCacheMisses.WithLabelValues(string(taskType)).Inc()
+
+	chResult := c.inFlight.DoChan(key, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+		responseBytes, statusCode, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		if statusCode < 400 {
+			c.set(key, responseBytes, ttl)
+		}
+		return httpResponse{responseBytes, statusCode}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	case result := <-chResult:
+		if result.Shared {
+			promHTTPCacheCoalesced.WithLabelValues(string(taskType)).Inc()
+		}
+		if result.Err != nil {
+			return nil, 0, result.Err
+		}
+		response := result.Val.(httpResponse)
+		return response.responseBytes, response.statusCode, nil
+	}
+}
+
+func (c *httpResponseCache) get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, exists := c.entries[key]
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.responseBytes, true
+}
+
+func (c *httpResponseCache) set(key string, responseBytes []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.entries[key] = httpCacheEntry{responseBytes, now.Add(ttl)}
+
+	if now.Sub(c.lastSwept) < httpCacheSweepInterval {
+		return
+	}
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.lastSwept = now
+}
@@ -0,0 +1,184 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPResponseCache_Fetch(t *testing.T) {
+	t.Parallel()
+
+	cache := newHTTPResponseCache()
+
+	var calls int32
+	fetch := func(context.Context) ([]byte, int, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte("foo"), 200, nil
+	}
+
+	bs, statusCode, err := cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Minute, time.Minute, fetch)
+	require.NoError(t, err)
+	require.Equal(t, []byte("foo"), bs)
+	require.Equal(t, 200, statusCode)
+
+	bs, _, err = cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Minute, time.Minute, fetch)
+	require.NoError(t, err)
+	require.Equal(t, []byte("foo"), bs)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, _, err = cache.Fetch(context.Background(), TaskTypeHTTP, "other key", time.Minute, time.Minute, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHTTPResponseCache_Fetch_Expiry(t *testing.T) {
+	t.Parallel()
+
+	cache := newHTTPResponseCache()
+
+	var calls int32
+	fetch := func(context.Context) ([]byte, int, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte("foo"), 200, nil
+	}
+
+	_, _, err := cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Nanosecond, time.Minute, fetch)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, _, err = cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Nanosecond, time.Minute, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHTTPResponseCache_Fetch_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := newHTTPResponseCache()
+
+	var calls int32
+	fetch := func(context.Context) ([]byte, int, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte(`{"error":"too many requests"}`), 429, nil
+	}
+
+	_, statusCode, err := cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Minute, time.Minute, fetch)
+	require.NoError(t, err)
+	require.Equal(t, 429, statusCode)
+	_, _, err = cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Minute, time.Minute, fetch)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHTTPResponseCache_Fetch_CoalescesInFlightRequests(t *testing.T) {
+	t.Parallel()
+
+	cache := newHTTPResponseCache()
+
+	var calls int32
+	chRelease := make(chan struct{})
+	fetch := func(context.Context) ([]byte, int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-chRelease
+		return []byte("foo"), 200, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bs, _, err := cache.Fetch(context.Background(), TaskTypeBridge, "key", time.Minute, time.Minute, fetch)
+			require.NoError(t, err)
+			require.Equal(t, []byte("foo"), bs)
+		}()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	close(chRelease)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestHTTPResponseCache_Fetch_CancelledCallerDoesNotFailOthers(t *testing.T) {
+	t.Parallel()
+
+	cache := newHTTPResponseCache()
+
+	chFetching := make(chan struct{})
+	chRelease := make(chan struct{})
+	var once sync.Once
+	fetch := func(ctx context.Context) ([]byte, int, error) {
+		once.Do(func() { close(chFetching) })
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-chRelease:
+			return []byte("foo"), 200, nil
+		}
+	}
+
+	// The first caller starts the fetch and then gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	chFirstDone := make(chan error)
+	go func() {
+		_, _, err := cache.Fetch(ctx, TaskTypeHTTP, "key", time.Minute, time.Minute, fetch)
+		chFirstDone <- err
+	}()
+	<-chFetching
+
+	chSecondDone := make(chan []byte)
+	go func() {
+		bs, _, err := cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Minute, time.Minute, fetch)
+		require.NoError(t, err)
+		chSecondDone <- bs
+	}()
+
+	cancel()
+	require.True(t, errors.Is(<-chFirstDone, context.Canceled))
+
+	close(chRelease)
+	require.Equal(t, []byte("foo"), <-chSecondDone)
+}
+
+func TestHTTPResponseCache_Fetch_Timeout(t *testing.T) {
+	t.Parallel()
+
+	cache := newHTTPResponseCache()
+
+	fetch := func(ctx context.Context) ([]byte, int, error) {
+		<-ctx.Done()
+		return nil, 0, ctx.Err()
+	}
+
+	_, _, err := cache.Fetch(context.Background(), TaskTypeHTTP, "key", time.Minute, 10*time.Millisecond, fetch)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestHTTPCacheKey(t *testing.T) {
+	t.Parallel()
+
+	key1, err := httpCacheKey("POST", "https://example.com", HttpRequestData{"a": 1, "b": "two"}, "")
+	require.NoError(t, err)
+	key2, err := httpCacheKey("POST", "https://example.com", HttpRequestData{"b": "two", "a": 1}, "")
+	require.NoError(t, err)
+	require.Equal(t, key1, key2)
+
+	key3, err := httpCacheKey("GET", "https://example.com", HttpRequestData{"a": 1, "b": "two"}, "")
+	require.NoError(t, err)
+	require.NotEqual(t, key1, key3)
+
+	key4, err := httpCacheKey("POST", "https://example.com/foo", HttpRequestData{"a": 1, "b": "two"}, "")
+	require.NoError(t, err)
+	require.NotEqual(t, key1, key4)
+
+	key5, err := httpCacheKey("POST", "https://example.com", HttpRequestData{"a": 1, "b": "two"}, "identity")
+	require.NoError(t, err)
+	require.NotEqual(t, key1, key5)
+}
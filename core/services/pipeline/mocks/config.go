@@ -116,6 +116,20 @@ func (_m *Config) DefaultMaxHTTPAttempts() uint {
 	return r0
 }
 
+// JobPipelineBridgeAsyncTimeout provides a mock function with given fields:
+func (_m *Config) JobPipelineBridgeAsyncTimeout() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // JobPipelineDBPollInterval provides a mock function with given fields:
 func (_m *Config) JobPipelineDBPollInterval() time.Duration {
 	ret := _m.Called()
@@ -185,3 +199,31 @@ func (_m *Config) JobPipelineReaperThreshold() time.Duration {
 
 	return r0
 }
+
+// OutboundRequestsMaxInFlight provides a mock function with given fields:
+func (_m *Config) OutboundRequestsMaxInFlight() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// OutboundRequestsPerSecond provides a mock function with given fields:
+func (_m *Config) OutboundRequestsPerSecond() float64 {
+	ret := _m.Called()
+
+	var r0 float64
+	if rf, ok := ret.Get(0).(func() float64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	return r0
+}
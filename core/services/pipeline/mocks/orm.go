@@ -178,6 +178,20 @@ func (_m *ORM) ResultsForRun(ctx context.Context, runID int64) ([]pipeline.Resul
 	return r0, r1
 }
 
+// ResumeTaskRun provides a mock function with given fields: ctx, taskRunID, result
+func (_m *ORM) ResumeTaskRun(ctx context.Context, taskRunID int64, result pipeline.Result) error {
+	ret := _m.Called(ctx, taskRunID, result)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, pipeline.Result) error); ok {
+		r0 = rf(ctx, taskRunID, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RunFinished provides a mock function with given fields: runID
 func (_m *ORM) RunFinished(runID int64) (bool, error) {
 	ret := _m.Called(runID)
@@ -198,3 +212,40 @@ func (_m *ORM) RunFinished(runID int64) (bool, error) {
 
 	return r0, r1
 }
+
+// SubscribeRunEvents provides a mock function with given fields: jobID
+func (_m *ORM) SubscribeRunEvents(jobID int32) (pipeline.RunEventSubscription, error) {
+	ret := _m.Called(jobID)
+
+	var r0 pipeline.RunEventSubscription
+	if rf, ok := ret.Get(0).(func(int32) pipeline.RunEventSubscription); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pipeline.RunEventSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int32) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TimeoutPendingTaskRuns provides a mock function with given fields:
+func (_m *ORM) TimeoutPendingTaskRuns() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
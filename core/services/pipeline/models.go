@@ -49,6 +49,8 @@ type (
 		PipelineTaskSpecID int32             `json:"-"`
 		PipelineTaskSpec   TaskSpec          `json:"taskSpec" gorm:"foreignkey:PipelineTaskSpecID;association_autoupdate:false;association_autocreate:false"`
 		CreatedAt          time.Time         `json:"createdAt"`
+		PendingUntil       *time.Time        `json:"pendingUntil"`
+		ResumeTokenHash    null.String       `json:"-"`
 		FinishedAt         *time.Time        `json:"finishedAt"`
 	}
 )
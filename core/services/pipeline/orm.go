@@ -22,6 +22,9 @@ type ORM interface {
 	CreateRun(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 	ProcessNextUnclaimedTaskRun(ctx context.Context, fn ProcessTaskRunFunc) (bool, error)
 	ListenForNewRuns() (postgres.Subscription, error)
+	SubscribeRunEvents(jobID int32) (RunEventSubscription, error)
+	ResumeTaskRun(ctx context.Context, taskRunID int64, result Result) error
+	TimeoutPendingTaskRuns() error
 	AwaitRun(ctx context.Context, runID int64) error
 	RunFinished(runID int64) (bool, error)
 	ResultsForRun(ctx context.Context, runID int64) ([]Result, error)
@@ -34,12 +37,13 @@ type orm struct {
 	db               *gorm.DB
 	config           Config
 	eventBroadcaster postgres.EventBroadcaster
+	runEvents        *runEventBroadcaster
 }
 
 var _ ORM = (*orm)(nil)
 
 func NewORM(db *gorm.DB, config Config, eventBroadcaster postgres.EventBroadcaster) *orm {
-	return &orm{db, config, eventBroadcaster}
+	return &orm{db, config, eventBroadcaster, newRunEventBroadcaster(db, eventBroadcaster)}
 }
 
 func (o *orm) CreateSpec(ctx context.Context, taskDAG TaskDAG) (int32, error) {
@@ -140,7 +144,10 @@ func (o *orm) CreateRun(ctx context.Context, jobID int32, meta map[string]interf
             SELECT ? AS pipeline_run_id, id AS pipeline_task_spec_id, type, index, NOW() AS created_at
             FROM pipeline_task_specs
             WHERE pipeline_spec_id = ?`, run.ID, run.PipelineSpecID).Error
-		return errors.Wrap(err, "could not create pipeline task runs")
+		if err != nil {
+			return errors.Wrap(err, "could not create pipeline task runs")
+		}
+		return o.notifyRunEvent(tx, RunEventCreated, run.ID)
 	})
 	return runID, errors.WithStack(err)
 }
@@ -198,6 +205,7 @@ func (o *orm) processNextUnclaimedTaskRun(ctx context.Context, fn ProcessTaskRun
                     LEFT JOIN pipeline_task_runs AS predecessor_unfinished_runs ON predecessor_specs.id = predecessor_unfinished_runs.pipeline_task_spec_id
                           AND pipeline_task_runs.pipeline_run_id = predecessor_unfinished_runs.pipeline_run_id
                 WHERE pipeline_task_runs.finished_at IS NULL
+                AND pipeline_task_runs.pending_until IS NULL
                 GROUP BY (pipeline_task_runs.id)
                 HAVING (
                     bool_and(predecessor_unfinished_runs.finished_at IS NOT NULL)
@@ -256,6 +264,14 @@ func (o *orm) processNextUnclaimedTaskRun(ctx context.Context, fn ProcessTaskRun
 		// Call the callback
 		result := fn(ctx, tx, job.ID, ptRun, predecessors)
 
+		// Suspend the task run if it is waiting on an external system
+		if pending, is := result.Error.(PendingError); is {
+			err = tx.Exec(`UPDATE pipeline_task_runs SET pending_until = ?, resume_token_hash = ? WHERE id = ?`,
+				time.Now().Add(pending.Timeout), null.NewString(pending.ResumeTokenHash, pending.ResumeTokenHash != ""), ptRun.ID,
+			).Error
+			return errors.Wrap(err, "could not mark pipeline_task_run as pending")
+		}
+
 		// Update the task run record with the output and error
 		var out interface{}
 		var errString null.String
@@ -272,6 +288,9 @@ func (o *orm) processNextUnclaimedTaskRun(ctx context.Context, fn ProcessTaskRun
 		if err != nil {
 			return errors.Wrap(err, "could not mark pipeline_task_run as finished")
 		}
+		if err = o.notifyRunEvent(tx, RunEventTaskCompleted, ptRun.ID); err != nil {
+			return err
+		}
 
 		if ptRun.PipelineTaskSpec.IsFinalPipelineOutput() {
 			err = tx.Exec(`UPDATE pipeline_runs SET finished_at = ?, outputs = ?, errors = ? WHERE id = ?`, time.Now(), out, errString, ptRun.PipelineRunID).Error
@@ -283,6 +302,9 @@ func (o *orm) processNextUnclaimedTaskRun(ctx context.Context, fn ProcessTaskRun
 			if err != nil {
 				return errors.Wrap(err, "could not notify pipeline_run_completed")
 			}
+			if err = o.notifyRunEvent(tx, RunEventFinished, ptRun.PipelineRunID); err != nil {
+				return err
+			}
 			logger.Infow("Pipeline run completed", "runID", ptRun.PipelineRunID)
 		}
 		return nil
@@ -297,6 +319,62 @@ func (o *orm) ListenForNewRuns() (postgres.Subscription, error) {
 	return o.eventBroadcaster.Subscribe(postgres.ChannelRunStarted, "")
 }
 
+// ResumeTaskRun completes a pending task run with the given result, allowing
+// its successors to be processed.  It returns ErrTaskRunNotPending if the task
+// run is not suspended (e.g. because it already timed out).
+func (o *orm) ResumeTaskRun(ctx context.Context, taskRunID int64, result Result) error {
+	ctx, cancel := utils.CombinedContext(ctx, o.config.DatabaseMaximumTxDuration())
+	defer cancel()
+
+	var out interface{}
+	var errString null.String
+	if result.Value != nil {
+		out = &JSONSerializable{Val: result.Value}
+	}
+	if result.Error != nil {
+		errString = null.StringFrom(result.Error.Error())
+	}
+
+	return postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) error {
+		res := tx.Exec(`
+            UPDATE pipeline_task_runs SET output = ?, error = ?, finished_at = ?
+            WHERE id = ? AND pending_until IS NOT NULL AND finished_at IS NULL`,
+			out, errString, time.Now(), taskRunID,
+		)
+		if res.Error != nil {
+			return errors.Wrap(res.Error, "could not resume pipeline_task_run")
+		} else if res.RowsAffected == 0 {
+			return errors.Wrapf(ErrTaskRunNotPending, "could not resume pipeline_task_run %v", taskRunID)
+		}
+		return o.notifyRunEvent(tx, RunEventTaskCompleted, taskRunID)
+	})
+}
+
+// TimeoutPendingTaskRuns marks any pending task runs that have not been
+// resumed within their timeout as errored
+func (o *orm) TimeoutPendingTaskRuns() error {
+	ctx, cancel := utils.CombinedContext(context.Background(), o.config.DatabaseMaximumTxDuration())
+	defer cancel()
+
+	return postgres.GormTransaction(ctx, o.db, func(tx *gorm.DB) error {
+		var taskRunIDs []int64
+		err := tx.Raw(`
+            UPDATE pipeline_task_runs SET error = 'timed out waiting for asynchronous response', finished_at = NOW()
+            WHERE pending_until < NOW() AND finished_at IS NULL
+            RETURNING id
+        `).Pluck("id", &taskRunIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "could not time out pending pipeline_task_runs")
+		}
+		for _, taskRunID := range taskRunIDs {
+			if err = o.notifyRunEvent(tx, RunEventTaskCompleted, taskRunID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // AwaitRun waits until a run has completed (either successfully or with errors)
 // and then returns.  It uses two distinct methods to determine when a job run
 // has completed:
@@ -111,7 +111,7 @@ func TestORM(t *testing.T) {
 
 		for _, taskSpec := range taskSpecs {
 			taskSpec.JSON.Val.(map[string]interface{})["index"] = taskSpec.Index
-			taskSpec.JSON.Val, err = pipeline.UnmarshalTaskFromMap(taskSpec.Type, taskSpec.JSON.Val, taskSpec.DotID, nil, nil)
+			taskSpec.JSON.Val, err = pipeline.UnmarshalTaskFromMap(taskSpec.Type, taskSpec.JSON.Val, taskSpec.DotID, nil, nil, nil)
 			require.NoError(t, err)
 
 			var found bool
@@ -422,3 +422,91 @@ func TestORM(t *testing.T) {
 	})
 
 }
+
+func TestORM_PendingTaskRuns(t *testing.T) {
+	config, oldORM, cleanupDB := cltest.BootstrapThrowawayORM(t, "pipeline_orm_pending", true, true)
+	defer cleanupDB()
+	db := oldORM.DB
+
+	orm, eventBroadcaster, cleanup := cltest.NewPipelineORM(t, config, db)
+	defer cleanup()
+	jobORM := job.NewORM(db, config, orm, eventBroadcaster, &postgres.NullAdvisoryLocker{})
+	defer jobORM.Close()
+
+	ocrSpec, dbSpec := makeVoterTurnoutOCRJobSpec(t, db)
+	require.NoError(t, jobORM.CreateJob(context.Background(), dbSpec, ocrSpec.TaskDAG()))
+	runID, err := orm.CreateRun(context.Background(), dbSpec.ID, nil)
+	require.NoError(t, err)
+
+	// ds1 waits for an hour, while ds2 is already past its timeout
+	answers := map[string]pipeline.Result{
+		"ds1": {Error: pipeline.PendingError{Timeout: time.Hour, ResumeTokenHash: "deadbeef"}},
+		"ds2": {Error: pipeline.PendingError{Timeout: -time.Minute}},
+	}
+	processed := make(map[string]int)
+	anyRemaining := true
+	for anyRemaining {
+		anyRemaining, err = orm.ProcessNextUnclaimedTaskRun(context.Background(), func(_ context.Context, db *gorm.DB, jobID int32, taskRun pipeline.TaskRun, predecessorRuns []pipeline.TaskRun) pipeline.Result {
+			processed[taskRun.DotID()]++
+			if answer, exists := answers[taskRun.DotID()]; exists {
+				return answer
+			}
+			return pipeline.Result{Value: float64(1)}
+		})
+		require.NoError(t, err)
+	}
+
+	// Pending task runs are not processed again, and neither are their successors
+	assert.Equal(t, 1, processed["ds1"])
+	assert.Equal(t, 1, processed["ds2"])
+	assert.NotContains(t, processed, "ds1_parse")
+	assert.NotContains(t, processed, "ds2_parse")
+
+	taskRunByDotID := func(dotID string) pipeline.TaskRun {
+		var taskRun pipeline.TaskRun
+		err := db.
+			Joins("INNER JOIN pipeline_task_specs ON pipeline_task_runs.pipeline_task_spec_id = pipeline_task_specs.id").
+			Where("pipeline_task_runs.pipeline_run_id = ? AND pipeline_task_specs.dot_id = ?", runID, dotID).
+			First(&taskRun).Error
+		require.NoError(t, err)
+		return taskRun
+	}
+
+	ds1 := taskRunByDotID("ds1")
+	require.NotNil(t, ds1.PendingUntil)
+	assert.Nil(t, ds1.FinishedAt)
+	assert.Equal(t, null.StringFrom("deadbeef"), ds1.ResumeTokenHash)
+	assert.False(t, taskRunByDotID("ds2").ResumeTokenHash.Valid)
+
+	t.Run("times out task runs that are past their timeout", func(t *testing.T) {
+		require.NoError(t, orm.TimeoutPendingTaskRuns())
+
+		ds2 := taskRunByDotID("ds2")
+		require.NotNil(t, ds2.FinishedAt)
+		assert.Equal(t, "timed out waiting for asynchronous response", ds2.Error.ValueOrZero())
+		assert.Nil(t, taskRunByDotID("ds1").FinishedAt)
+
+		err := orm.ResumeTaskRun(context.Background(), ds2.ID, pipeline.Result{Value: float64(2)})
+		assert.Equal(t, pipeline.ErrTaskRunNotPending, errors.Cause(err))
+	})
+
+	t.Run("resumes a pending task run once", func(t *testing.T) {
+		require.NoError(t, orm.ResumeTaskRun(context.Background(), ds1.ID, pipeline.Result{Value: float64(3)}))
+
+		ds1 := taskRunByDotID("ds1")
+		require.NotNil(t, ds1.FinishedAt)
+		require.NotNil(t, ds1.Output)
+		assert.Equal(t, float64(3), ds1.Output.Val)
+		assert.True(t, ds1.Error.IsZero())
+
+		err := orm.ResumeTaskRun(context.Background(), ds1.ID, pipeline.Result{Value: float64(4)})
+		assert.Equal(t, pipeline.ErrTaskRunNotPending, errors.Cause(err))
+
+		// The successors of the resumed task run can now be processed
+		_, err = orm.ProcessNextUnclaimedTaskRun(context.Background(), func(_ context.Context, db *gorm.DB, jobID int32, taskRun pipeline.TaskRun, predecessorRuns []pipeline.TaskRun) pipeline.Result {
+			assert.Equal(t, "ds1_parse", taskRun.DotID())
+			return pipeline.Result{Value: float64(5)}
+		})
+		require.NoError(t, err)
+	})
+}
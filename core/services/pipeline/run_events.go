@@ -0,0 +1,303 @@
+package pipeline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// RunEventType describes what happened to a pipeline run
+type RunEventType string
+
+const (
+	// RunEventCreated is emitted when a new pipeline run is created
+	RunEventCreated RunEventType = "runCreated"
+	// RunEventTaskCompleted is emitted when a task run finishes, either
+	// successfully or with an error
+	RunEventTaskCompleted RunEventType = "taskCompleted"
+	// RunEventFinished is emitted when the final task of a pipeline run
+	// finishes
+	RunEventFinished RunEventType = "runFinished"
+)
+
+// runEventBufferSize is the number of events that are buffered for each
+// subscriber.  A subscriber that falls further behind than this is closed, so
+// that it cannot hold up the others.
+const runEventBufferSize = 100
+
+// RunEvent is a change to a pipeline run, as pushed to run event subscribers.
+// Task fields are only set for RunEventTaskCompleted, and Outputs/Errors are
+// only set for RunEventFinished.  Seq increases by one with every event the
+// node sees, in the order the changes were committed, so that subscribers can
+// order events and tell whether any were skipped.
+type RunEvent struct {
+	Seq       uint64            `json:"seq"`
+	Type      RunEventType      `json:"type"`
+	JobID     int32             `json:"jobID"`
+	RunID     int64             `json:"runID"`
+	TaskRunID int64             `json:"taskRunID,omitempty"`
+	DotID     string            `json:"dotID,omitempty"`
+	TaskType  TaskType          `json:"taskType,omitempty"`
+	Output    *JSONSerializable `json:"output,omitempty"`
+	Error     null.String       `json:"error"`
+	Outputs   *JSONSerializable `json:"outputs,omitempty"`
+	Errors    *JSONSerializable `json:"errors,omitempty"`
+	At        time.Time         `json:"at"`
+}
+
+// RunEventSubscription delivers the run events for one job, or for all jobs.
+// Its Events channel is closed if the subscriber falls too far behind.
+type RunEventSubscription interface {
+	Events() <-chan RunEvent
+	Close()
+}
+
+// runEventBroadcaster listens for run events on a single Postgres channel,
+// loads each event once, and fans it out to every subscriber.  It only
+// listens while there are subscribers.
+type runEventBroadcaster struct {
+	db               *gorm.DB
+	eventBroadcaster postgres.EventBroadcaster
+	mu               sync.Mutex
+	subscriptions    map[*runEventSubscription]struct{}
+	chStop           chan struct{}
+	wgDone           *sync.WaitGroup
+	seq              uint64
+}
+
+type runEventSubscription struct {
+	broadcaster *runEventBroadcaster
+	jobID       int32
+	chEvents    chan RunEvent
+	closed      bool
+}
+
+var _ RunEventSubscription = (*runEventSubscription)(nil)
+
+func newRunEventBroadcaster(db *gorm.DB, eventBroadcaster postgres.EventBroadcaster) *runEventBroadcaster {
+	return &runEventBroadcaster{
+		db:               db,
+		eventBroadcaster: eventBroadcaster,
+		subscriptions:    make(map[*runEventSubscription]struct{}),
+	}
+}
+
+// SubscribeRunEvents streams the run events for jobID, or for every job if
+// jobID is 0.  Callers must Close the subscription when they are done with it.
+func (o *orm) SubscribeRunEvents(jobID int32) (RunEventSubscription, error) {
+	return o.runEvents.subscribe(jobID)
+}
+
+// notifyRunEvent publishes a run event on the single run events channel.
+// Notifications on one channel are delivered in the order their transactions
+// commit, and in the order they were sent within a transaction.
+func (o *orm) notifyRunEvent(tx *gorm.DB, eventType RunEventType, id int64) error {
+	err := o.eventBroadcaster.NotifyInsideGormTx(tx, postgres.ChannelRunEvent, fmt.Sprintf("%s:%d", eventType, id))
+	return errors.Wrapf(err, "could not notify %s", eventType)
+}
+
+func (b *runEventBroadcaster) subscribe(jobID int32) (*runEventSubscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscriptions) == 0 {
+		pgSub, err := b.eventBroadcaster.Subscribe(postgres.ChannelRunEvent, "")
+		if err != nil {
+			return nil, err
+		}
+		b.chStop = make(chan struct{})
+		b.wgDone = &sync.WaitGroup{}
+		b.wgDone.Add(1)
+		go b.run(pgSub, b.chStop, b.wgDone)
+	}
+
+	sub := &runEventSubscription{
+		broadcaster: b,
+		jobID:       jobID,
+		chEvents:    make(chan RunEvent, runEventBufferSize),
+	}
+	b.subscriptions[sub] = struct{}{}
+	return sub, nil
+}
+
+func (b *runEventBroadcaster) unsubscribe(sub *runEventSubscription) {
+	b.mu.Lock()
+	b.remove(sub)
+	if len(b.subscriptions) > 0 || b.chStop == nil {
+		b.mu.Unlock()
+		return
+	}
+	chStop, wgDone := b.chStop, b.wgDone
+	b.chStop, b.wgDone = nil, nil
+	b.mu.Unlock()
+
+	close(chStop)
+	wgDone.Wait()
+}
+
+// remove must be called with mu held
+func (b *runEventBroadcaster) remove(sub *runEventSubscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(b.subscriptions, sub)
+	close(sub.chEvents)
+}
+
+func (b *runEventBroadcaster) run(pgSub postgres.Subscription, chStop chan struct{}, wgDone *sync.WaitGroup) {
+	defer wgDone.Done()
+	defer pgSub.Close()
+
+	for {
+		var pgEvent postgres.Event
+		select {
+		case <-chStop:
+			return
+		case pgEvent = <-pgSub.Events():
+		}
+
+		event, err := b.loadEvent(pgEvent.Payload)
+		if err != nil {
+			logger.Errorw("Pipeline run events: could not load event",
+				"payload", pgEvent.Payload,
+				"error", err,
+			)
+			continue
+		}
+		b.publish(event)
+	}
+}
+
+func (b *runEventBroadcaster) publish(event RunEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event.Seq = b.seq
+	for sub := range b.subscriptions {
+		if sub.jobID != 0 && event.JobID != sub.jobID {
+			continue
+		}
+		select {
+		case sub.chEvents <- event:
+		default:
+			logger.Warnw("Pipeline run events: subscriber fell too far behind, closing its subscription",
+				"jobID", sub.jobID,
+				"seq", event.Seq,
+			)
+			b.remove(sub)
+		}
+	}
+}
+
+func (sub *runEventSubscription) Events() <-chan RunEvent {
+	return sub.chEvents
+}
+
+func (sub *runEventSubscription) Close() {
+	sub.broadcaster.unsubscribe(sub)
+}
+
+func (b *runEventBroadcaster) loadEvent(payload string) (RunEvent, error) {
+	parts := strings.SplitN(payload, ":", 2)
+	if len(parts) != 2 {
+		return RunEvent{}, errors.Errorf("invalid run event notification payload %s", payload)
+	}
+	eventType := RunEventType(parts[0])
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return RunEvent{}, errors.Wrapf(err, "invalid %s notification payload", eventType)
+	}
+
+	var event RunEvent
+	switch eventType {
+	case RunEventCreated:
+		event, err = loadRunCreatedEvent(b.db, id)
+	case RunEventTaskCompleted:
+		event, err = loadTaskCompletedEvent(b.db, id)
+	case RunEventFinished:
+		event, err = loadRunFinishedEvent(b.db, id)
+	default:
+		return RunEvent{}, errors.Errorf("unknown run event type %s", eventType)
+	}
+	return event, errors.Wrapf(err, "could not load %s event for id %v", eventType, id)
+}
+
+func loadRunCreatedEvent(tx *gorm.DB, runID int64) (RunEvent, error) {
+	var run Run
+	if err := tx.Where("id = ?", runID).First(&run).Error; err != nil {
+		return RunEvent{}, err
+	}
+	jobID, err := jobIDForRun(tx, run.ID)
+	return RunEvent{
+		Type:  RunEventCreated,
+		JobID: jobID,
+		RunID: run.ID,
+		At:    run.CreatedAt,
+	}, err
+}
+
+func loadTaskCompletedEvent(tx *gorm.DB, taskRunID int64) (RunEvent, error) {
+	var taskRun TaskRun
+	err := tx.
+		Preload("PipelineTaskSpec").
+		Where("id = ?", taskRunID).
+		First(&taskRun).Error
+	if err != nil {
+		return RunEvent{}, err
+	}
+	jobID, err := jobIDForRun(tx, taskRun.PipelineRunID)
+	event := RunEvent{
+		Type:      RunEventTaskCompleted,
+		JobID:     jobID,
+		RunID:     taskRun.PipelineRunID,
+		TaskRunID: taskRun.ID,
+		DotID:     taskRun.PipelineTaskSpec.DotID,
+		TaskType:  taskRun.Type,
+		Output:    taskRun.Output,
+		Error:     taskRun.Error,
+	}
+	if taskRun.FinishedAt != nil {
+		event.At = *taskRun.FinishedAt
+	}
+	return event, err
+}
+
+func loadRunFinishedEvent(tx *gorm.DB, runID int64) (RunEvent, error) {
+	var run Run
+	if err := tx.Where("id = ?", runID).First(&run).Error; err != nil {
+		return RunEvent{}, err
+	}
+	jobID, err := jobIDForRun(tx, run.ID)
+	event := RunEvent{
+		Type:    RunEventFinished,
+		JobID:   jobID,
+		RunID:   run.ID,
+		Outputs: run.Outputs,
+		Errors:  run.Errors,
+	}
+	if run.FinishedAt != nil {
+		event.At = *run.FinishedAt
+	}
+	return event, err
+}
+
+func jobIDForRun(tx *gorm.DB, runID int64) (int32, error) {
+	var job struct{ ID int32 }
+	err := tx.Raw(`
+        SELECT jobs.id FROM pipeline_runs
+        INNER JOIN jobs ON jobs.pipeline_spec_id = pipeline_runs.pipeline_spec_id
+        WHERE pipeline_runs.id = ?
+    `, runID).Scan(&job).Error
+	return job.ID, errors.Wrap(err, "error finding job ID")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
@@ -22,11 +23,14 @@ type (
 		CreateRun(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 		AwaitRun(ctx context.Context, runID int64) error
 		ResultsForRun(ctx context.Context, runID int64) ([]Result, error)
+		ResumeTaskRun(ctx context.Context, taskRunID int64, result Result) error
+		SubscribeRunEvents(jobID int32) (RunEventSubscription, error)
 	}
 
 	runner struct {
 		orm                             ORM
 		config                          Config
+		secrets                         models.SecretResolver
 		processIncompleteTaskRunsWorker utils.SleeperTask
 		runReaperWorker                 utils.SleeperTask
 
@@ -36,12 +40,13 @@ type (
 	}
 )
 
-func NewRunner(orm ORM, config Config) *runner {
+func NewRunner(orm ORM, config Config, secrets models.SecretResolver) *runner {
 	r := &runner{
-		orm:    orm,
-		config: config,
-		chStop: make(chan struct{}),
-		chDone: make(chan struct{}),
+		orm:     orm,
+		config:  config,
+		secrets: secrets,
+		chStop:  make(chan struct{}),
+		chDone:  make(chan struct{}),
 	}
 	r.processIncompleteTaskRunsWorker = utils.NewSleeperTask(
 		utils.SleeperTaskFuncWorker(r.processIncompleteTaskRuns),
@@ -130,15 +135,41 @@ func (r *runner) AwaitRun(ctx context.Context, runID int64) error {
 	return r.orm.AwaitRun(ctx, runID)
 }
 
+// SubscribeRunEvents streams run events for jobID, or for every job if jobID
+// is 0
+func (r *runner) SubscribeRunEvents(jobID int32) (RunEventSubscription, error) {
+	return r.orm.SubscribeRunEvents(jobID)
+}
+
 func (r *runner) ResultsForRun(ctx context.Context, runID int64) ([]Result, error) {
 	ctx, cancel := utils.CombinedContext(r.chStop, ctx)
 	defer cancel()
 	return r.orm.ResultsForRun(ctx, runID)
 }
 
+// ResumeTaskRun completes a task run that was suspended waiting for an
+// asynchronous response (e.g. from an external adapter), and wakes up the
+// runner so that its successors are processed promptly.
+func (r *runner) ResumeTaskRun(ctx context.Context, taskRunID int64, result Result) error {
+	ctx, cancel := utils.CombinedContext(r.chStop, ctx)
+	defer cancel()
+
+	err := r.orm.ResumeTaskRun(ctx, taskRunID, result)
+	if err != nil {
+		return err
+	}
+	logger.Infow("Pipeline task run resumed", "taskRunID", taskRunID)
+	r.processIncompleteTaskRunsWorker.WakeUp()
+	return nil
+}
+
 // NOTE: This could potentially run on a different machine in the cluster than
 // the one that originally added the task runs.
 func (r *runner) processIncompleteTaskRuns() {
+	if err := r.orm.TimeoutPendingTaskRuns(); err != nil {
+		logger.Errorw("Pipeline runner could not time out pending task runs", "error", err)
+	}
+
 	threads := int(r.config.JobPipelineParallelism())
 
 	var wg sync.WaitGroup
@@ -193,6 +224,7 @@ func (r *runner) processTaskRun() (anyRemaining bool, err error) {
 			taskRun.PipelineTaskSpec.DotID,
 			r.config,
 			txdb,
+			r.secrets,
 		)
 		if err != nil {
 			logger.Errorw("Pipeline task run could not be unmarshaled", append(loggerFields, "error", err)...)
@@ -200,7 +232,9 @@ func (r *runner) processTaskRun() (anyRemaining bool, err error) {
 		}
 
 		result := task.Run(ctx, taskRun, inputs)
-		if _, is := result.Error.(FinalErrors); !is && result.Error != nil {
+		if pending, is := result.Error.(PendingError); is {
+			logger.Infow("Pipeline task run is pending", append(loggerFields, "timeout", pending.Timeout)...)
+		} else if _, is := result.Error.(FinalErrors); !is && result.Error != nil {
 			logger.Errorw("Pipeline task run errored", append(loggerFields, "error", result.Error)...)
 		} else {
 			f := append(loggerFields, "result", result.Value)
@@ -30,7 +30,7 @@ func TestRunner(t *testing.T) {
 	defer eventBroadcaster.Stop()
 
 	pipelineORM := pipeline.NewORM(db, config, eventBroadcaster)
-	runner := pipeline.NewRunner(pipelineORM, config)
+	runner := pipeline.NewRunner(pipelineORM, config, nil)
 	jobORM := job.NewORM(db, config, pipelineORM, eventBroadcaster, &postgres.NullAdvisoryLocker{})
 	defer jobORM.Close()
 
@@ -294,6 +294,53 @@ func TestRunner(t *testing.T) {
 		}
 	})
 
+	t.Run("streams run events filtered by job ID", func(t *testing.T) {
+		mockHTTP, cleanupHTTP := cltest.NewHTTPMockServer(t, http.StatusOK, "GET", `{"USD": 1}`)
+		defer cleanupHTTP()
+
+		ocrSpec, dbSpec := makeSimpleFetchOCRJobSpecWithHTTPURL(t, db, mockHTTP.URL, false)
+		err := jobORM.CreateJob(context.Background(), dbSpec, ocrSpec.TaskDAG())
+		require.NoError(t, err)
+		otherOCRSpec, otherDBSpec := makeSimpleFetchOCRJobSpecWithHTTPURL(t, db, mockHTTP.URL, false)
+		err = jobORM.CreateJob(context.Background(), otherDBSpec, otherOCRSpec.TaskDAG())
+		require.NoError(t, err)
+
+		sub, err := runner.SubscribeRunEvents(dbSpec.ID)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		otherRunID, err := runner.CreateRun(context.Background(), otherDBSpec.ID, nil)
+		require.NoError(t, err)
+		runID, err := runner.CreateRun(context.Background(), dbSpec.ID, nil)
+		require.NoError(t, err)
+
+		var events []pipeline.RunEvent
+		timeout := time.After(10 * time.Second)
+		for len(events) == 0 || events[len(events)-1].Type != pipeline.RunEventFinished {
+			select {
+			case event := <-sub.Events():
+				events = append(events, event)
+			case <-timeout:
+				t.Fatalf("timed out waiting for run events, got %v", events)
+			}
+		}
+
+		require.Greater(t, len(events), 2)
+		assert.Equal(t, pipeline.RunEventCreated, events[0].Type)
+		for _, event := range events {
+			assert.Equal(t, dbSpec.ID, event.JobID)
+			assert.Equal(t, runID, event.RunID)
+			assert.NotEqual(t, otherRunID, event.RunID)
+		}
+		for i := 1; i < len(events); i++ {
+			assert.Greater(t, events[i].Seq, events[i-1].Seq)
+		}
+		for _, event := range events[1 : len(events)-1] {
+			assert.Equal(t, pipeline.RunEventTaskCompleted, event.Type)
+			assert.NotEmpty(t, event.DotID)
+		}
+	})
+
 	t.Run("test job spec error is created", func(t *testing.T) {
 		// Create a keystore with an ocr key bundle and p2p key.
 		keyStore := offchainreporting.NewKeyStore(db, utils.GetScryptParams(config.Config))
@@ -320,6 +367,7 @@ func TestRunner(t *testing.T) {
 			keyStore,
 			nil,
 			nil,
+			nil,
 			nil)
 		service, err := sd.ServicesForSpec(sd.FromDBRow(jb))
 		require.NoError(t, err)
@@ -2,21 +2,31 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
+// ResumeTokenParam is the query parameter of an async bridge task's response
+// URL that holds the token needed to resume it
+const ResumeTokenParam = "resume_token"
+
 type BridgeTask struct {
 	BaseTask `mapstructure:",squash"`
 
-	Name        string          `json:"name"`
-	RequestData HttpRequestData `json:"requestData"`
+	Name         string          `json:"name"`
+	RequestData  HttpRequestData `json:"requestData"`
+	Async        bool            `json:"async"`
+	AsyncTimeout models.Duration `json:"asyncTimeout"`
+	CacheTTL     models.Duration `json:"cacheTTL"`
 
 	txdb   *gorm.DB
 	config Config
@@ -52,15 +62,49 @@ func (t *BridgeTask) Run(ctx context.Context, taskRun TaskRun, inputs []Result)
 		)
 	}
 
-	result = (&HTTPTask{
+	requestData := withIDAndMeta(t.RequestData, taskRun.PipelineRunID, meta)
+	var resumeTokenHash string
+	if t.Async {
+		resumeToken := utils.NewSecret(24)
+		resumeTokenHash, err = utils.Sha256(resumeToken)
+		if err != nil {
+			return Result{Error: err}
+		}
+		responseURL, err := t.responseURL(taskRun.ID, resumeToken)
+		if err != nil {
+			return Result{Error: err}
+		}
+		requestData["responseURL"] = responseURL.String()
+	}
+
+	httpTask := &HTTPTask{
 		URL:         models.WebURL(url),
 		Method:      "POST",
-		RequestData: withIDAndMeta(t.RequestData, taskRun.PipelineRunID, meta),
+		RequestData: requestData,
 		config:      t.config,
-	}).Run(ctx, taskRun, inputs)
+		limiterKey:  "bridge:" + t.Name,
+	}
+	// Async responses depend on the task run they are sent to, so they are
+	// never cached.  The cache key omits the run ID so that identical
+	// requests from different runs and jobs share an entry, but includes the
+	// meta, which the adapter may use to answer.
+	if !t.Async {
+		httpTask.CacheTTL = t.CacheTTL
+		httpTask.cacheKeyData = withMeta(t.RequestData, meta)
+		httpTask.cacheTaskType = t.Type()
+	}
+
+	result = httpTask.Run(ctx, taskRun, inputs)
 	if result.Error != nil {
 		return result
 	}
+	if t.Async && isPendingBridgeResponse(result.Value.([]byte)) {
+		logger.Debugw("Bridge task: external adapter will respond asynchronously",
+			"taskRunID", taskRun.ID,
+			"url", url.String(),
+		)
+		return Result{Error: PendingError{Timeout: t.asyncTimeout(), ResumeTokenHash: resumeTokenHash}}
+	}
 	logger.Debugw("Bridge task: fetched answer",
 		"answer", string(result.Value.([]byte)),
 		"url", url.String(),
@@ -78,12 +122,48 @@ func (t BridgeTask) getBridgeURLFromName() (url.URL, error) {
 	return bridgeURL, nil
 }
 
+// responseURL is the URL that the external adapter must PATCH with its answer
+// in order to resume the task run.  It carries the task run's resume token, so
+// that only the adapter that was sent the request can resume it.
+func (t BridgeTask) responseURL(taskRunID int64, resumeToken string) (*url.URL, error) {
+	responseURL := t.config.BridgeResponseURL()
+	if responseURL == nil || *responseURL == (url.URL{}) {
+		return nil, errors.New("BRIDGE_RESPONSE_URL must be set to use async bridge tasks")
+	}
+	u := *responseURL
+	u.Path += fmt.Sprintf("/v2/pipeline/task_runs/%d", taskRunID)
+	u.RawQuery = url.Values{ResumeTokenParam: []string{resumeToken}}.Encode()
+	return &u, nil
+}
+
+func (t BridgeTask) asyncTimeout() time.Duration {
+	if !t.AsyncTimeout.IsInstant() {
+		return t.AsyncTimeout.Duration()
+	}
+	return t.config.JobPipelineBridgeAsyncTimeout()
+}
+
+// isPendingBridgeResponse returns true if the external adapter responded with
+// `{"pending": true}`, indicating that it will call back with the answer later
+func isPendingBridgeResponse(responseBytes []byte) bool {
+	var brr models.BridgeRunResult
+	if err := json.Unmarshal(responseBytes, &brr); err != nil {
+		return false
+	}
+	return !brr.HasError() && brr.ExternalPending
+}
+
 func withIDAndMeta(request HttpRequestData, runID int64, meta HttpRequestData) HttpRequestData {
+	output := withMeta(request, meta)
+	output["id"] = fmt.Sprintf("%d", runID)
+	return output
+}
+
+func withMeta(request HttpRequestData, meta HttpRequestData) HttpRequestData {
 	output := make(HttpRequestData)
 	for k, v := range request {
 		output[k] = v
 	}
-	output["id"] = fmt.Sprintf("%d", runID)
 	output["meta"] = meta
 	return output
 }
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
@@ -274,3 +276,116 @@ func TestBridgeTask_AddsID(t *testing.T) {
 		},
 	}, nil)
 }
+
+func TestBridgeTask_Async(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("BRIDGE_RESPONSE_URL", "https://chainlink.example.com")
+
+	var resumeToken string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		body, _ := ioutil.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &req))
+		responseURL, err := url.Parse(req["responseURL"].(string))
+		require.NoError(t, err)
+		require.Equal(t, "https://chainlink.example.com/v2/pipeline/task_runs/42", responseURL.Scheme+"://"+responseURL.Host+responseURL.Path)
+		resumeToken = responseURL.Query().Get(pipeline.ResumeTokenParam)
+		require.NotEmpty(t, resumeToken)
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"pending": true}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+	feedWebURL := (*models.WebURL)(feedURL)
+
+	timeout, err := models.MakeDuration(5 * time.Minute)
+	require.NoError(t, err)
+	task := pipeline.BridgeTask{
+		Name:         "foo",
+		RequestData:  pipeline.HttpRequestData(ethUSDPairing),
+		Async:        true,
+		AsyncTimeout: timeout,
+	}
+	task.HelperSetConfigAndTxDB(store.Config, store.DB)
+
+	_, bridge := cltest.NewBridgeType(t, task.Name)
+	bridge.URL = *feedWebURL
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	result := task.Run(context.Background(), pipeline.TaskRun{ID: 42}, nil)
+	require.Nil(t, result.Value)
+	resumeTokenHash, err := utils.Sha256(resumeToken)
+	require.NoError(t, err)
+	require.Equal(t, pipeline.PendingError{Timeout: 5 * time.Minute, ResumeTokenHash: resumeTokenHash}, result.Error)
+}
+
+func TestBridgeTask_Async_RequiresBridgeResponseURL(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store.Config.Set("BRIDGE_RESPONSE_URL", "")
+
+	task := pipeline.BridgeTask{
+		Name:        "foo",
+		RequestData: pipeline.HttpRequestData(ethUSDPairing),
+		Async:       true,
+	}
+	task.HelperSetConfigAndTxDB(store.Config, store.DB)
+
+	_, bridge := cltest.NewBridgeType(t, task.Name)
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	result := task.Run(context.Background(), pipeline.TaskRun{ID: 42}, nil)
+	require.Nil(t, result.Value)
+	require.EqualError(t, result.Error, "BRIDGE_RESPONSE_URL must be set to use async bridge tasks")
+}
+
+func TestBridgeTask_CacheKeyIncludesMeta(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	var calls int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":{"result":"1"}}`))
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	ttl, err := models.MakeDuration(time.Minute)
+	require.NoError(t, err)
+	task := pipeline.BridgeTask{
+		Name:        "cached",
+		RequestData: pipeline.HttpRequestData(ethUSDPairing),
+		CacheTTL:    ttl,
+	}
+	task.HelperSetConfigAndTxDB(store.Config, store.DB)
+
+	_, bridge := cltest.NewBridgeType(t, task.Name)
+	bridge.URL = *(*models.WebURL)(feedURL)
+	require.NoError(t, store.ORM.DB.Create(&bridge).Error)
+
+	runWithMeta := func(runID int64, meta map[string]interface{}) {
+		result := task.Run(context.Background(), pipeline.TaskRun{
+			PipelineRunID: runID,
+			PipelineRun:   pipeline.Run{Meta: pipeline.JSONSerializable{meta}},
+		}, nil)
+		require.NoError(t, result.Error)
+	}
+
+	// Runs differ in their ID, which is not part of the key
+	runWithMeta(1, map[string]interface{}{"roundId": 1})
+	runWithMeta(2, map[string]interface{}{"roundId": 1})
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	runWithMeta(3, map[string]interface{}{"roundId": 2})
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
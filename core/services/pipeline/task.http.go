@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/ratelimit"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
@@ -18,9 +19,20 @@ type HTTPTask struct {
 	BaseTask    `mapstructure:",squash"`
 	Method      string
 	URL         models.WebURL
-	RequestData HttpRequestData `json:"requestData"`
-
-	config Config
+	RequestData HttpRequestData  `json:"requestData"`
+	CacheTTL    models.Duration  `json:"cacheTTL"`
+	Auth        *models.HTTPAuth `json:"auth,omitempty"`
+
+	config  Config
+	secrets models.SecretResolver
+	// cacheKeyData is used in place of RequestData when computing the cache
+	// key, so that callers can exclude fields that differ between runs
+	cacheKeyData HttpRequestData
+	// cacheTaskType is the task type that cache metrics are reported against
+	cacheTaskType TaskType
+	// limiterKey is the key the outbound request limits are applied to.  It
+	// defaults to the URL's host.
+	limiterKey string
 }
 
 type PossibleErrorResponses struct {
@@ -40,8 +52,10 @@ func (t *HTTPTask) Run(ctx context.Context, taskRun TaskRun, inputs []Result) Re
 	}
 
 	var bodyReader io.Reader
+	var bodyBytes []byte
 	if t.RequestData != nil {
-		bodyBytes, err := json.Marshal(t.RequestData)
+		var err error
+		bodyBytes, err = json.Marshal(t.RequestData)
 		if err != nil {
 			return Result{Error: errors.Wrap(err, "failed to encode request body as JSON")}
 		}
@@ -53,6 +67,11 @@ func (t *HTTPTask) Run(ctx context.Context, taskRun TaskRun, inputs []Result) Re
 		return Result{Error: errors.Wrap(err, "failed to create http.Request")}
 	}
 	request.Header.Set("Content-Type", "application/json")
+	if t.Auth != nil {
+		if err = t.Auth.Apply(request, bodyBytes, t.secrets); err != nil {
+			return Result{Error: errors.Wrap(err, "failed to authenticate http.Request")}
+		}
+	}
 
 	config := utils.HTTPRequestConfig{
 		Timeout:                        t.config.DefaultHTTPTimeout().Duration(),
@@ -66,7 +85,7 @@ func (t *HTTPTask) Run(ctx context.Context, taskRun TaskRun, inputs []Result) Re
 		Config:  config,
 	}
 
-	responseBytes, statusCode, err := httpRequest.SendRequest(ctx)
+	responseBytes, statusCode, err := t.sendRequest(ctx, httpRequest)
 	if err != nil {
 		return Result{Error: errors.Wrapf(err, "error making http request")}
 	}
@@ -83,6 +102,54 @@ func (t *HTTPTask) Run(ctx context.Context, taskRun TaskRun, inputs []Result) Re
 	return Result{Value: responseBytes}
 }
 
+// sendRequest sends the request, or, if the task has opted in to caching,
+// serves it from the shared response cache
+func (t *HTTPTask) sendRequest(ctx context.Context, httpRequest utils.HTTPRequest) ([]byte, int, error) {
+	if t.CacheTTL.IsInstant() {
+		return t.sendLimitedRequest(ctx, httpRequest)
+	}
+
+	keyData := t.RequestData
+	if t.cacheKeyData != nil {
+		keyData = t.cacheKeyData
+	}
+	var authIdentity string
+	if t.Auth != nil {
+		var err error
+		authIdentity, err = t.Auth.Identity(t.secrets)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to identify http auth")
+		}
+	}
+	key, err := httpCacheKey(t.Method, t.URL.String(), keyData, authIdentity)
+	if err != nil {
+		return nil, 0, err
+	}
+	taskType := t.cacheTaskType
+	if taskType == "" {
+		taskType = t.Type()
+	}
+	fetchTimeout := t.config.JobPipelineMaxTaskDuration()
+	return sharedHTTPResponseCache.Fetch(ctx, taskType, key, t.CacheTTL.Duration(), fetchTimeout, func(fetchCtx context.Context) ([]byte, int, error) {
+		return t.sendLimitedRequest(fetchCtx, httpRequest)
+	})
+}
+
+// sendLimitedRequest waits for the node-wide outbound request limits before
+// sending the request
+func (t *HTTPTask) sendLimitedRequest(ctx context.Context, httpRequest utils.HTTPRequest) ([]byte, int, error) {
+	key := t.limiterKey
+	if key == "" {
+		key = t.URL.Host
+	}
+	release, err := ratelimit.OutboundRequests.Acquire(ctx, key, ratelimit.LimitsFromConfig(t.config))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+	return httpRequest.SendRequest(ctx)
+}
+
 func bestEffortExtractError(responseBytes []byte) string {
 	var resp PossibleErrorResponses
 	err := json.Unmarshal(responseBytes, &resp)
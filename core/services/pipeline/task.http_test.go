@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
@@ -120,3 +123,169 @@ func TestHTTPTask_OnlyErrorMessage(t *testing.T) {
 	require.Contains(t, result.Error.Error(), "RequestId")
 	require.Nil(t, result.Value)
 }
+
+func TestHTTPTask_CacheTTL(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	var requests int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":{"result":9700}}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	ttl, err := models.MakeDuration(time.Minute)
+	require.NoError(t, err)
+
+	// Key order differs, but the normalized bodies are identical
+	task1 := pipeline.HTTPTask{
+		Method:      "POST",
+		URL:         models.WebURL(*feedURL),
+		RequestData: utils.MustUnmarshalToMap(`{"coin":"BTC","market":"USD"}`),
+		CacheTTL:    ttl,
+	}
+	task1.HelperSetConfig(config)
+	task2 := pipeline.HTTPTask{
+		Method:      "POST",
+		URL:         models.WebURL(*feedURL),
+		RequestData: utils.MustUnmarshalToMap(`{"market":"USD","coin":"BTC"}`),
+		CacheTTL:    ttl,
+	}
+	task2.HelperSetConfig(config)
+
+	result1 := task1.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.NoError(t, result1.Error)
+	result2 := task2.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.NoError(t, result2.Error)
+
+	require.Equal(t, result1.Value, result2.Value)
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// Tasks that don't opt in always hit the server
+	task3 := pipeline.HTTPTask{
+		Method:      "POST",
+		URL:         models.WebURL(*feedURL),
+		RequestData: utils.MustUnmarshalToMap(`{"coin":"BTC","market":"USD"}`),
+	}
+	task3.HelperSetConfig(config)
+
+	result3 := task3.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.NoError(t, result3.Error)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+type staticSecrets map[string]string
+
+func (ss staticSecrets) Secret(name string) (string, error) {
+	value, exists := ss[name]
+	if !exists {
+		return "", errors.Errorf("secret %s does not exist", name)
+	}
+	return value, nil
+}
+
+func TestHTTPTask_Auth(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":{"result":9700}}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	task := pipeline.HTTPTask{
+		Method: "GET",
+		URL:    models.WebURL(*feedURL),
+		Auth: &models.HTTPAuth{
+			Headers: map[string]string{"Authorization": `Bearer {{secret "api_key"}}`},
+		},
+	}
+	task.HelperSetConfig(config)
+
+	task.HelperSetSecrets(staticSecrets{"api_key": "s3cr3t"})
+	result := task.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.NoError(t, result.Error)
+
+	task.HelperSetSecrets(staticSecrets{})
+	result = task.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.Error(t, result.Error)
+}
+
+func TestHTTPTask_CacheTTL_Auth(t *testing.T) {
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+
+	var requests int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":{"result":9700}}`))
+		require.NoError(t, err)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	feedURL, err := url.ParseRequestURI(server.URL)
+	require.NoError(t, err)
+
+	ttl, err := models.MakeDuration(time.Minute)
+	require.NoError(t, err)
+
+	newTask := func(secrets staticSecrets) pipeline.HTTPTask {
+		task := pipeline.HTTPTask{
+			Method:   "GET",
+			URL:      models.WebURL(*feedURL),
+			CacheTTL: ttl,
+			Auth: &models.HTTPAuth{
+				Headers: map[string]string{"Authorization": `Bearer {{secret "api_key"}}`},
+			},
+		}
+		task.HelperSetConfig(config)
+		task.HelperSetSecrets(secrets)
+		return task
+	}
+
+	authorized := newTask(staticSecrets{"api_key": "s3cr3t"})
+	result := authorized.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.NoError(t, result.Error)
+
+	// A task with other credentials is not served the cached response
+	unauthorized := newTask(staticSecrets{"api_key": "wrong"})
+	result = unauthorized.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.Error(t, result.Error)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// Nor is a task without credentials
+	anonymous := newTask(nil)
+	anonymous.Auth = nil
+	result = anonymous.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.Error(t, result.Error)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// The same credentials share the entry
+	again := newTask(staticSecrets{"api_key": "s3cr3t"})
+	result = again.Run(context.Background(), pipeline.TaskRun{}, nil)
+	require.NoError(t, result.Error)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
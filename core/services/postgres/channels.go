@@ -5,6 +5,10 @@ const (
 	ChannelRunStarted   = "pipeline_run_started"
 	ChannelRunCompleted = "pipeline_run_completed"
 
+	// Postgres channel to listen for pipeline run events, in the order they
+	// were committed
+	ChannelRunEvent = "pipeline_run_event"
+
 	// Postgres channel to listen for new eth_txes
 	ChannelInsertOnEthTx = "insert_on_eth_txes"
 )
@@ -0,0 +1,176 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+var (
+	promOutboundQueueWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "outbound_request_queue_wait_seconds",
+		Help:    "The amount of time outbound data source requests spent waiting for the per-host rate and concurrency limits",
+		Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+	},
+		[]string{"key"},
+	)
+	promOutboundQueueTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbound_request_queue_timeouts",
+		Help: "The number of outbound data source requests whose context expired before the per-host limits allowed them to be sent",
+	},
+		[]string{"key"},
+	)
+	promOutboundInFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "outbound_requests_in_flight",
+		Help: "The number of outbound data source requests currently in flight",
+	},
+		[]string{"key"},
+	)
+)
+
+// OutboundRequests is the node-wide limiter shared by every HTTP task, bridge
+// task and flux monitor price fetcher
+var OutboundRequests = NewKeyedLimiter()
+
+// Config holds the settings for the outbound request limits
+type Config interface {
+	OutboundRequestsMaxInFlight() int
+	OutboundRequestsPerSecond() float64
+}
+
+// Limits are the rate and concurrency limits applied to each key.  A zero
+// value means no limit.
+type Limits struct {
+	PerSecond   float64
+	MaxInFlight int
+}
+
+// LimitsFromConfig returns the limits described by config
+func LimitsFromConfig(config Config) Limits {
+	return Limits{
+		PerSecond:   config.OutboundRequestsPerSecond(),
+		MaxInFlight: config.OutboundRequestsMaxInFlight(),
+	}
+}
+
+// KeyedLimiter limits the rate and concurrency of requests independently for
+// each key (e.g. a host or bridge name).  The limiter for a key is created the
+// first time the key is seen, and its limits are updated whenever they change.
+type KeyedLimiter struct {
+	limiters map[string]*keyLimiter
+	mu       sync.Mutex
+}
+
+type keyLimiter struct {
+	rate        *rate.Limiter
+	inFlight    int
+	maxInFlight int
+	released    chan struct{}
+	mu          sync.Mutex
+}
+
+// NewKeyedLimiter returns an empty KeyedLimiter
+func NewKeyedLimiter() *KeyedLimiter {
+	return &KeyedLimiter{limiters: make(map[string]*keyLimiter)}
+}
+
+// Acquire blocks until a request to key is allowed by limits, or until ctx is
+// done.  On success, the caller must call release once the request has
+// completed.
+func (kl *KeyedLimiter) Acquire(ctx context.Context, key string, limits Limits) (release func(), err error) {
+	if limits.PerSecond <= 0 && limits.MaxInFlight <= 0 {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	l := kl.limiterFor(key, limits)
+	defer func() {
+		promOutboundQueueWait.WithLabelValues(key).Observe(time.Since(start).Seconds())
+		if err != nil {
+			promOutboundQueueTimeouts.WithLabelValues(key).Inc()
+		}
+	}()
+
+	if err = l.rate.Wait(ctx); err != nil {
+		return nil, errors.Wrapf(err, "while waiting for rate limit on %s", key)
+	}
+	if err = l.acquireSlot(ctx); err != nil {
+		return nil, errors.Wrapf(err, "while waiting for an in-flight slot on %s", key)
+	}
+
+	promOutboundInFlight.WithLabelValues(key).Inc()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			promOutboundInFlight.WithLabelValues(key).Dec()
+			l.releaseSlot()
+		})
+	}, nil
+}
+
+func (kl *KeyedLimiter) limiterFor(key string, limits Limits) *keyLimiter {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+
+	l, exists := kl.limiters[key]
+	if !exists {
+		l = &keyLimiter{
+			rate:     rate.NewLimiter(rateLimit(limits.PerSecond), burst(limits.PerSecond)),
+			released: make(chan struct{}),
+		}
+		kl.limiters[key] = l
+	} else if l.rate.Limit() != rateLimit(limits.PerSecond) {
+		l.rate.SetLimit(rateLimit(limits.PerSecond))
+		l.rate.SetBurst(burst(limits.PerSecond))
+	}
+	l.mu.Lock()
+	l.maxInFlight = limits.MaxInFlight
+	l.mu.Unlock()
+	return l
+}
+
+func (l *keyLimiter) acquireSlot(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.maxInFlight <= 0 || l.inFlight < l.maxInFlight {
+			l.inFlight++
+			l.mu.Unlock()
+			return nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *keyLimiter) releaseSlot() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	// Wake up all waiters, who will then race for the free slot
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+func rateLimit(perSecond float64) rate.Limit {
+	if perSecond <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(perSecond)
+}
+
+// burst allows up to one second's worth of requests to be sent at once
+func burst(perSecond float64) int {
+	return int(math.Max(1, math.Floor(perSecond)))
+}
@@ -0,0 +1,88 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/core/services/ratelimit"
+)
+
+func TestKeyedLimiter_Unlimited(t *testing.T) {
+	limiter := ratelimit.NewKeyedLimiter()
+
+	for i := 0; i < 100; i++ {
+		_, err := limiter.Acquire(context.Background(), "example.com", ratelimit.Limits{})
+		require.NoError(t, err)
+	}
+}
+
+func TestKeyedLimiter_MaxInFlight(t *testing.T) {
+	limiter := ratelimit.NewKeyedLimiter()
+	limits := ratelimit.Limits{MaxInFlight: 2}
+
+	release1, err := limiter.Acquire(context.Background(), "example.com", limits)
+	require.NoError(t, err)
+	release2, err := limiter.Acquire(context.Background(), "example.com", limits)
+	require.NoError(t, err)
+
+	// Other keys are limited independently
+	releaseOther, err := limiter.Acquire(context.Background(), "other.com", limits)
+	require.NoError(t, err)
+	defer releaseOther()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx, "example.com", limits)
+	require.Error(t, err)
+	assert.Equal(t, context.DeadlineExceeded, errors.Cause(err))
+
+	acquired := make(chan struct{})
+	go func() {
+		release3, err := limiter.Acquire(context.Background(), "example.com", limits)
+		require.NoError(t, err)
+		release3()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired more than the maximum number of in-flight slots")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release1()
+	// Releasing twice has no effect
+	release1()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a released slot")
+	}
+	release2()
+}
+
+func TestKeyedLimiter_PerSecond(t *testing.T) {
+	limiter := ratelimit.NewKeyedLimiter()
+	limits := ratelimit.Limits{PerSecond: 1}
+
+	release, err := limiter.Acquire(context.Background(), "example.com", limits)
+	require.NoError(t, err)
+	release()
+
+	// The next token won't be available for a second, which is after the
+	// context's deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx, "example.com", limits)
+	require.Error(t, err)
+
+	// Raising the limit takes effect immediately
+	release, err = limiter.Acquire(context.Background(), "example.com", ratelimit.Limits{PerSecond: 1000})
+	require.NoError(t, err)
+	release()
+}
@@ -66,7 +66,7 @@ func (re *runExecutor) Execute(runID *models.ID) error {
 			continue
 		}
 
-		if !meetsMinRequiredIncomingConfirmations(&run, taskRun, run.ObservedHeight) {
+		if !meetsMinRequiredIncomingConfirmations(&run, taskRun, run.ObservedHeight, re.store.HeadChain) {
 			logger.Debugw("Pausing run pending incoming confirmations",
 				run.ForLogger("required_height", taskRun.MinRequiredIncomingConfirmations)...,
 			)
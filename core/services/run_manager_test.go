@@ -327,6 +327,7 @@ func TestRunManager_Create_fromRunLog_Happy(t *testing.T) {
 			)
 			gethClient.On("ChainID", mock.Anything).Return(app.Config.ChainID(), nil)
 			gethClient.On("BalanceAt", mock.Anything, mock.Anything, mock.Anything).Return(big.NewInt(1), nil)
+			cltest.MockBatchCallContext(rpcClient, gethClient)
 			gethClient.On("SubscribeFilterLogs", mock.Anything, mock.Anything, mock.Anything).Return(sub, nil)
 			rpcClient.On("EthSubscribe", mock.Anything, mock.Anything, "newHeads").Return(sub, nil)
 			sub.On("Err").Return(nil)
@@ -44,14 +44,16 @@ func validateOnMainChain(run *models.JobRun, taskRun *models.TaskRun, ethClient
 func meetsMinRequiredIncomingConfirmations(
 	run *models.JobRun,
 	taskRun *models.TaskRun,
-	currentHeight *utils.Big) bool {
+	currentHeight *utils.Big,
+	finality eth.Finality) bool {
 
 	if !taskRun.MinRequiredIncomingConfirmations.Valid || run.CreationHeight == nil || currentHeight == nil {
 		return true
 	}
 
 	diff := blockConfirmations(currentHeight, run.CreationHeight)
-	return diff.Cmp(big.NewInt(int64(taskRun.MinRequiredIncomingConfirmations.Uint32))) >= 0
+	return diff.Cmp(big.NewInt(int64(taskRun.MinRequiredIncomingConfirmations.Uint32))) >= 0 ||
+		eth.IsNodeFinal(finality, run.CreationHeight.ToInt().Int64())
 }
 
 func blockConfirmations(currentHeight, creationHeight *utils.Big) *big.Int {
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// topUpKeys sends ETH from the treasury key to every key whose balance has
+// fallen below its low watermark, bringing it back up to its high watermark.
+// No key is sent a second top up until the first has been confirmed, and the
+// treasury never sends more than ETH_TREASURY_DAILY_LIMIT_WEI in 24 hours.
+//
+// It must be called after the balances of the keys have been updated.
+func (bm *balanceMonitor) topUpKeys(keys []models.Key) {
+	config := bm.store.Config
+	treasury := config.EthTreasuryAddress()
+	if treasury == utils.ZeroAddress {
+		return
+	}
+	if !config.EnableBulletproofTxManager() {
+		logger.Warn("BalanceMonitor: ETH_TREASURY_ADDRESS is set but keys can only be topped up with ENABLE_BULLETPROOF_TX_MANAGER")
+		return
+	}
+
+	treasuryBalance := bm.GetEthBalance(treasury)
+	if treasuryBalance == nil {
+		logger.Warnw("BalanceMonitor: balance of treasury key is unknown, not topping up keys", "treasury", treasury.Hex())
+		return
+	}
+	// Gas fees are not included, so this is only a best effort check
+	available := new(big.Int).Set(treasuryBalance.ToInt())
+
+	spent, err := bm.store.TreasurySpentSince(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		logger.Errorw("BalanceMonitor: error loading treasury spend", "error", err)
+		return
+	}
+	remaining := new(big.Int).Sub(config.EthTreasuryDailyLimitWei(), spent.ToInt())
+
+	for _, key := range keys {
+		address := key.Address.Address()
+		if address == treasury {
+			continue
+		}
+
+		low, high := bm.watermarks(key)
+		balance := bm.GetEthBalance(address)
+		if low.Sign() == 0 || balance == nil || balance.ToInt().Cmp(low) >= 0 {
+			continue
+		}
+
+		pending, err := bm.store.HasPendingTreasuryTransfer(address)
+		if err != nil {
+			logger.Errorw("BalanceMonitor: error checking for pending top up", "address", address.Hex(), "error", err)
+			continue
+		} else if pending {
+			continue
+		}
+
+		amount := new(big.Int).Sub(high, balance.ToInt())
+		loggerFields := []interface{}{
+			"address", address.Hex(),
+			"treasury", treasury.Hex(),
+			"amount", (*assets.Eth)(amount).String(),
+			"ethBalance", balance.String(),
+		}
+		if amount.Cmp(remaining) > 0 {
+			logger.Warnw(fmt.Sprintf("BalanceMonitor: not topping up %s, treasury daily limit of %s would be exceeded", address.Hex(), (*assets.Eth)(config.EthTreasuryDailyLimitWei()).String()), loggerFields...)
+			continue
+		}
+		if amount.Cmp(available) > 0 {
+			logger.Warnw(fmt.Sprintf("BalanceMonitor: not topping up %s, treasury balance is too low", address.Hex()), loggerFields...)
+			continue
+		}
+
+		etx, err := bulletprooftxmanager.NewSendEtherTx(config, treasury, address, assets.Eth(*amount))
+		if err != nil {
+			logger.Errorw(fmt.Sprintf("BalanceMonitor: error topping up %s", address.Hex()), append(loggerFields, "error", err)...)
+			continue
+		}
+		transfer := models.TreasuryTransfer{
+			FromAddress: treasury,
+			ToAddress:   address,
+			Amount:      assets.Eth(*amount),
+			ToBalance:   *balance,
+		}
+		if err := bm.store.CreateTreasuryTransfer(&etx, &transfer); err != nil {
+			logger.Errorw(fmt.Sprintf("BalanceMonitor: error topping up %s", address.Hex()), append(loggerFields, "error", err)...)
+			continue
+		}
+		remaining.Sub(remaining, amount)
+		available.Sub(available, amount)
+
+		logger.Infow(fmt.Sprintf("Topping up %s from treasury with %s", address.Hex(), (*assets.Eth)(amount).String()),
+			append(loggerFields, "ethTxID", etx.ID, "id", "treasury_transfer")...)
+	}
+}
+
+// watermarks returns the low and high watermarks of the key, falling back to
+// the node's configuration for any the key does not set
+func (bm *balanceMonitor) watermarks(key models.Key) (low, high *big.Int) {
+	low = bm.store.Config.EthKeyLowWatermarkWei()
+	if key.EthLowWatermark != nil {
+		low = key.EthLowWatermark.ToInt()
+	}
+	high = bm.store.Config.EthKeyHighWatermarkWei()
+	if key.EthHighWatermark != nil {
+		high = key.EthHighWatermark.ToInt()
+	}
+	if high.Cmp(low) < 0 {
+		high = low
+	}
+	return low, high
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// NewMnemonic returns a random 24 word BIP-39 mnemonic
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// hdSeedFromMnemonic returns the BIP-39 seed of the mnemonic, without a
+// passphrase
+func hdSeedFromMnemonic(mnemonic string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid BIP-39 mnemonic")
+	}
+	return bip39.NewSeedWithErrorChecking(mnemonic, "")
+}
+
+// deriveHDKey derives the Ethereum key at the given index on the standard
+// path m/44'/60'/0'/0 from a BIP-32 seed
+func deriveHDKey(seed []byte, index uint32) (*ecdsa.PrivateKey, error) {
+	if index >= 0x80000000 {
+		return nil, errors.Errorf("derivation index %d is out of range", index)
+	}
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	path := append(accounts.DerivationPath{}, accounts.DefaultRootDerivationPath...)
+	var err error
+	for _, i := range append(path, index) {
+		key, chainCode, err = deriveHDChild(key, chainCode, i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while deriving key %d", index)
+		}
+	}
+	return crypto.ToECDSA(key)
+}
+
+// deriveHDChild derives the i-th child of a BIP-32 private key
+func deriveHDChild(key, chainCode []byte, i uint32) ([]byte, []byte, error) {
+	var data []byte
+	if i >= 0x80000000 {
+		data = append([]byte{0}, key...)
+	} else {
+		privateKey, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	data = append(data, make([]byte, 4)...)
+	binary.BigEndian.PutUint32(data[len(data)-4:], i)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, nil, errors.New("invalid child key")
+	}
+	child := tweak.Add(tweak, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, errors.New("invalid child key")
+	}
+	return math.PaddedBigBytes(child, 32), sum[32:], nil
+}
+
+// InitHDSeed saves the seed of the mnemonic, encrypted with password, as the
+// seed that new Ethereum keys of the node are derived from
+func (s *Store) InitHDSeed(mnemonic, password string) error {
+	if s.Config.EthRemoteSignerURL() != "" {
+		return ErrRemoteSigner
+	}
+	existing, err := s.FindEncryptedHDSeed()
+	if err != nil {
+		return err
+	} else if existing != nil {
+		return errors.New("the node already has a seed")
+	}
+	seed, err := hdSeedFromMnemonic(mnemonic)
+	if err != nil {
+		return err
+	}
+	scryptParams := utils.GetScryptParams(s.Config)
+	cryptoJSON, err := keystore.EncryptDataV3(seed, []byte(password), scryptParams.N, scryptParams.P)
+	if err != nil {
+		return errors.Wrap(err, "while encrypting seed")
+	}
+	encrypted, err := json.Marshal(cryptoJSON)
+	if err != nil {
+		return errors.Wrap(err, "while encrypting seed")
+	}
+	return s.CreateEncryptedHDSeed(&models.EncryptedHDSeed{
+		EncryptedSeed: models.JSON{Result: gjson.ParseBytes(encrypted)},
+	})
+}
+
+// UnlockHDSeed decrypts the seed of the node, if it has one, so that new
+// Ethereum keys are derived from it
+func (s *Store) UnlockHDSeed(password string) error {
+	encrypted, err := s.FindEncryptedHDSeed()
+	if err != nil || encrypted == nil {
+		return err
+	}
+	ks, ok := s.KeyStore.(*KeyStore)
+	if !ok {
+		return errors.Errorf("cannot derive keys with keystore of type %T", s.KeyStore)
+	}
+	var cryptoJSON keystore.CryptoJSON
+	if err = json.Unmarshal([]byte(encrypted.EncryptedSeed.Raw), &cryptoJSON); err != nil {
+		return errors.Wrap(err, "while decrypting seed")
+	}
+	seed, err := keystore.DecryptDataV3(cryptoJSON, password)
+	if err != nil {
+		return errors.Wrap(err, "while decrypting seed")
+	}
+	nextIndex, err := s.NextHDIndex()
+	if err != nil {
+		return errors.Wrap(err, "while finding next derivation index")
+	}
+	ks.useHDSeed(seed, nextIndex)
+	return nil
+}
@@ -0,0 +1,43 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_InitHDSeed(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	require.Error(t, store.InitHDSeed("abandon abandon abandon", cltest.Password))
+	require.NoError(t, store.InitHDSeed(mnemonic, cltest.Password))
+	require.Error(t, store.InitHDSeed(mnemonic, cltest.Password))
+
+	require.Error(t, store.UnlockHDSeed("wrong password"))
+	require.NoError(t, store.UnlockHDSeed(cltest.Password))
+
+	first, err := store.KeyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"), first.Address)
+	second, err := store.KeyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"), second.Address)
+
+	require.NoError(t, store.SyncDiskKeyStoreToDB())
+	key, err := store.KeyByAddress(first.Address)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), key.HDIndex.Int64)
+	key, err = store.KeyByAddress(second.Address)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), key.HDIndex.Int64)
+
+	next, err := store.NextHDIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), next)
+}
@@ -0,0 +1,416 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/vrfkey"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+)
+
+// KeyBackupVersion is the version of the KeyBackup format written by this
+// node. Backups with a later version cannot be imported.
+const KeyBackupVersion = 1
+
+// KeyBackup holds every ETH, OCR, P2P and VRF key of a node, each encrypted
+// with the password of the backup rather than that of the node, so that it
+// can be restored to a node with a different password.
+type KeyBackup struct {
+	Version          int            `json:"version"`
+	ChainlinkVersion string         `json:"chainlinkVersion"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	ETHKeys          []BackupETHKey `json:"ethKeys"`
+	OCRKeyBundles    []BackupOCRKey `json:"ocrKeyBundles"`
+	P2PKeys          []BackupP2PKey `json:"p2pKeys"`
+	VRFKeys          []BackupVRFKey `json:"vrfKeys"`
+}
+
+// BackupETHKey is an Ethereum key in a KeyBackup, in the geth keystore format
+type BackupETHKey struct {
+	Address   common.Address  `json:"address"`
+	IsFunding bool            `json:"isFunding"`
+	JSON      json.RawMessage `json:"json"`
+}
+
+// BackupOCRKey is an OCR key bundle in a KeyBackup
+type BackupOCRKey struct {
+	ID                   models.Sha256Hash `json:"id"`
+	EncryptedPrivateKeys json.RawMessage   `json:"encryptedPrivateKeys"`
+}
+
+// BackupP2PKey is a P2P key in a KeyBackup
+type BackupP2PKey struct {
+	PeerID           models.PeerID   `json:"peerId"`
+	EncryptedPrivKey json.RawMessage `json:"encryptedPrivKey"`
+}
+
+// BackupVRFKey is a VRF key in a KeyBackup
+type BackupVRFKey struct {
+	PublicKey vrfkey.PublicKey `json:"publicKey"`
+	JSON      json.RawMessage  `json:"json"`
+}
+
+// KeyBackupSummary counts the keys of each type in a KeyBackup that were
+// imported, or that would be imported in a dry run, and those that were
+// skipped because the node already has them
+type KeyBackupSummary struct {
+	ETHKeys       KeyBackupCount `json:"ethKeys"`
+	OCRKeyBundles KeyBackupCount `json:"ocrKeyBundles"`
+	P2PKeys       KeyBackupCount `json:"p2pKeys"`
+	VRFKeys       KeyBackupCount `json:"vrfKeys"`
+}
+
+// KeyBackupCount counts the keys of one type in a KeyBackupSummary
+type KeyBackupCount struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+// ExportKeyBackup decrypts every key of the node with nodePassword, and
+// returns them encrypted with backupPassword. Keys held by a remote signer
+// cannot be exported.
+func (s *Store) ExportKeyBackup(nodePassword, backupPassword string) (*KeyBackup, error) {
+	scryptParams := utils.GetScryptParams(s.Config)
+	backup := &KeyBackup{
+		Version:          KeyBackupVersion,
+		ChainlinkVersion: Version,
+		CreatedAt:        time.Now(),
+		ETHKeys:          []BackupETHKey{},
+		OCRKeyBundles:    []BackupOCRKey{},
+		P2PKeys:          []BackupP2PKey{},
+		VRFKeys:          []BackupVRFKey{},
+	}
+
+	if s.Config.EthRemoteSignerURL() == "" {
+		for _, account := range s.KeyStore.Accounts() {
+			key, err := s.KeyByAddress(account.Address)
+			if err != nil && !gorm.IsRecordNotFoundError(err) {
+				return nil, errors.Wrapf(err, "while exporting ETH key %s", account.Address.Hex())
+			}
+			keyJSON, err := s.KeyStore.Export(account, nodePassword, backupPassword)
+			if err != nil {
+				return nil, errors.Wrapf(err, "while exporting ETH key %s", account.Address.Hex())
+			}
+			backup.ETHKeys = append(backup.ETHKeys, BackupETHKey{
+				Address:   account.Address,
+				IsFunding: key.IsFunding,
+				JSON:      keyJSON,
+			})
+		}
+	}
+
+	ocrKeys, err := s.OCRKeyStore.FindEncryptedOCRKeyBundles()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading OCR key bundles")
+	}
+	for _, encrypted := range ocrKeys {
+		key, err := encrypted.Decrypt(nodePassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting OCR key bundle %s", encrypted.ID)
+		}
+		reencrypted, err := key.Encrypt(backupPassword, scryptParams)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting OCR key bundle %s", encrypted.ID)
+		}
+		backup.OCRKeyBundles = append(backup.OCRKeyBundles, BackupOCRKey{
+			ID:                   encrypted.ID,
+			EncryptedPrivateKeys: reencrypted.EncryptedPrivateKeys,
+		})
+	}
+
+	p2pKeys, err := s.OCRKeyStore.FindEncryptedP2PKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading P2P keys")
+	}
+	for _, encrypted := range p2pKeys {
+		key, err := encrypted.Decrypt(nodePassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting P2P key %s", encrypted.PeerID)
+		}
+		reencrypted, err := key.ToEncryptedP2PKey(backupPassword, scryptParams)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting P2P key %s", encrypted.PeerID)
+		}
+		backup.P2PKeys = append(backup.P2PKeys, BackupP2PKey{
+			PeerID:           encrypted.PeerID,
+			EncryptedPrivKey: reencrypted.EncryptedPrivKey,
+		})
+	}
+
+	vrfKeys, err := s.VRFKeyStore.Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading VRF keys")
+	}
+	for _, encrypted := range vrfKeys {
+		key, err := encrypted.Decrypt(nodePassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting VRF key %s", encrypted.PublicKey)
+		}
+		reencrypted, err := key.Encrypt(backupPassword, scryptParams)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting VRF key %s", encrypted.PublicKey)
+		}
+		keyJSON, err := reencrypted.JSON()
+		if err != nil {
+			return nil, errors.Wrapf(err, "while exporting VRF key %s", encrypted.PublicKey)
+		}
+		backup.VRFKeys = append(backup.VRFKeys, BackupVRFKey{
+			PublicKey: encrypted.PublicKey,
+			JSON:      keyJSON,
+		})
+	}
+
+	return backup, nil
+}
+
+// decryptedKeyBackup holds the keys of a KeyBackup after decryption
+type decryptedKeyBackup struct {
+	ethKeys []*keystore.Key
+	ocrKeys []*ocrkey.KeyBundle
+	p2pKeys []p2pkey.Key
+	vrfKeys []*vrfkey.PrivateKey
+}
+
+// decrypt checks that every key in the backup decrypts with backupPassword
+// and matches its public identifier, and returns the decrypted keys
+func (backup *KeyBackup) decrypt(backupPassword string) (*decryptedKeyBackup, error) {
+	if backup.Version < 1 || backup.Version > KeyBackupVersion {
+		return nil, errors.Errorf("unsupported key backup version %d, this node supports up to version %d", backup.Version, KeyBackupVersion)
+	}
+	decrypted := &decryptedKeyBackup{}
+
+	for _, ethKey := range backup.ETHKeys {
+		key, err := keystore.DecryptKey(ethKey.JSON, backupPassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt ETH key %s", ethKey.Address.Hex())
+		}
+		if key.Address != ethKey.Address {
+			return nil, errors.Errorf("ETH key %s decrypted to a key for %s", ethKey.Address.Hex(), key.Address.Hex())
+		}
+		decrypted.ethKeys = append(decrypted.ethKeys, key)
+	}
+
+	for _, ocrKey := range backup.OCRKeyBundles {
+		encrypted := ocrkey.EncryptedKeyBundle{ID: ocrKey.ID, EncryptedPrivateKeys: ocrKey.EncryptedPrivateKeys}
+		key, err := encrypted.Decrypt(backupPassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt OCR key bundle %s", ocrKey.ID)
+		}
+		decrypted.ocrKeys = append(decrypted.ocrKeys, key)
+	}
+
+	for _, p2pKey := range backup.P2PKeys {
+		encrypted := p2pkey.EncryptedP2PKey{PeerID: p2pKey.PeerID, EncryptedPrivKey: p2pKey.EncryptedPrivKey}
+		key, err := encrypted.Decrypt(backupPassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt P2P key %s", p2pKey.PeerID)
+		}
+		peerID, err := key.GetPeerID()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt P2P key %s", p2pKey.PeerID)
+		}
+		if peerID != p2pKey.PeerID {
+			return nil, errors.Errorf("P2P key %s decrypted to a key for %s", p2pKey.PeerID, peerID)
+		}
+		decrypted.p2pKeys = append(decrypted.p2pKeys, key)
+	}
+
+	for _, vrfKey := range backup.VRFKeys {
+		var encrypted vrfkey.EncryptedVRFKey
+		if err := json.Unmarshal(vrfKey.JSON, &encrypted); err != nil {
+			return nil, errors.Wrapf(err, "could not parse VRF key %s", vrfKey.PublicKey)
+		}
+		key, err := encrypted.Decrypt(backupPassword)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decrypt VRF key %s", vrfKey.PublicKey)
+		}
+		if key.PublicKey != vrfKey.PublicKey {
+			return nil, errors.Errorf("VRF key %s decrypted to a key for %s", vrfKey.PublicKey, key.PublicKey)
+		}
+		decrypted.vrfKeys = append(decrypted.vrfKeys, key)
+	}
+
+	return decrypted, nil
+}
+
+// ImportKeyBackup decrypts every key in the backup with backupPassword, and
+// saves those the node does not already have, encrypted with nodePassword.
+// Nothing is saved unless every key decrypts, and the keys are saved in a
+// single transaction. With dryRun set, the keys are decrypted and counted,
+// but not saved.
+//
+// A node can only have one funding key, so if it already has one, an ETH key
+// that was the funding key of the backed up node is imported as a regular
+// key.
+func (s *Store) ImportKeyBackup(backup *KeyBackup, backupPassword, nodePassword string, dryRun bool) (summary KeyBackupSummary, err error) {
+	decrypted, err := backup.decrypt(backupPassword)
+	if err != nil {
+		return summary, err
+	}
+	if len(backup.ETHKeys) > 0 && s.Config.EthRemoteSignerURL() != "" {
+		return summary, errors.Wrap(ErrRemoteSigner, "cannot import ETH keys")
+	}
+	scryptParams := utils.GetScryptParams(s.Config)
+
+	var fundingKeys int
+	if err := s.DB.Model(&models.Key{}).Where("is_funding = TRUE").Count(&fundingKeys).Error; err != nil {
+		return summary, errors.Wrap(err, "while loading funding key")
+	}
+	hasFundingKey := fundingKeys > 0
+
+	var ethKeys []models.Key
+	var ethKeyJSONs [][]byte
+	for i, key := range decrypted.ethKeys {
+		if _, err := s.KeyStore.GetAccountByAddress(key.Address); err == nil {
+			summary.ETHKeys.Skipped++
+			continue
+		}
+		summary.ETHKeys.Imported++
+		isFunding := backup.ETHKeys[i].IsFunding
+		if isFunding && hasFundingKey {
+			logger.Warnw("Node already has a funding key, importing the funding key of the backup as a regular key", "address", key.Address.Hex())
+			isFunding = false
+		}
+		hasFundingKey = hasFundingKey || isFunding
+		if dryRun {
+			continue
+		}
+		keyJSON, err := keystore.EncryptKey(key, nodePassword, scryptParams.N, scryptParams.P)
+		if err != nil {
+			return summary, errors.Wrapf(err, "while importing ETH key %s", key.Address.Hex())
+		}
+		ethKey, err := models.NewKeyFromJSON(keyJSON)
+		if err != nil {
+			return summary, errors.Wrapf(err, "while importing ETH key %s", key.Address.Hex())
+		}
+		ethKey.IsFunding = isFunding
+		ethKeys = append(ethKeys, ethKey)
+		ethKeyJSONs = append(ethKeyJSONs, keyJSON)
+	}
+
+	var ocrKeys []*ocrkey.EncryptedKeyBundle
+	for _, key := range decrypted.ocrKeys {
+		_, err := s.OCRKeyStore.FindEncryptedOCRKeyBundleByID(key.ID)
+		if err == nil {
+			summary.OCRKeyBundles.Skipped++
+			continue
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return summary, errors.Wrapf(err, "while importing OCR key bundle %s", key.ID)
+		}
+		summary.OCRKeyBundles.Imported++
+		if dryRun {
+			continue
+		}
+		encrypted, err := key.Encrypt(nodePassword, scryptParams)
+		if err != nil {
+			return summary, errors.Wrapf(err, "while importing OCR key bundle %s", key.ID)
+		}
+		ocrKeys = append(ocrKeys, encrypted)
+	}
+
+	existingP2PKeys, err := s.OCRKeyStore.FindEncryptedP2PKeys()
+	if err != nil {
+		return summary, errors.Wrap(err, "while loading P2P keys")
+	}
+	existingPeerIDs := make(map[models.PeerID]bool)
+	for _, key := range existingP2PKeys {
+		existingPeerIDs[key.PeerID] = true
+	}
+	var p2pKeys []p2pkey.EncryptedP2PKey
+	for _, key := range decrypted.p2pKeys {
+		peerID, err := key.GetPeerID()
+		if err != nil {
+			return summary, errors.Wrap(err, "while importing P2P key")
+		}
+		if existingPeerIDs[peerID] {
+			summary.P2PKeys.Skipped++
+			continue
+		}
+		summary.P2PKeys.Imported++
+		if dryRun {
+			continue
+		}
+		encrypted, err := key.ToEncryptedP2PKey(nodePassword, scryptParams)
+		if err != nil {
+			return summary, errors.Wrapf(err, "while importing P2P key %s", peerID)
+		}
+		p2pKeys = append(p2pKeys, encrypted)
+	}
+
+	var vrfKeys []*vrfkey.PrivateKey
+	var encryptedVRFKeys []*vrfkey.EncryptedVRFKey
+	for _, key := range decrypted.vrfKeys {
+		existing, err := s.VRFKeyStore.Get(key.PublicKey)
+		if err != nil {
+			return summary, errors.Wrapf(err, "while importing VRF key %s", key.PublicKey)
+		}
+		if len(existing) > 0 {
+			summary.VRFKeys.Skipped++
+			continue
+		}
+		summary.VRFKeys.Imported++
+		if dryRun {
+			continue
+		}
+		encrypted, err := key.Encrypt(nodePassword, scryptParams)
+		if err != nil {
+			return summary, errors.Wrapf(err, "while importing VRF key %s", key.PublicKey)
+		}
+		vrfKeys = append(vrfKeys, key)
+		encryptedVRFKeys = append(encryptedVRFKeys, encrypted)
+	}
+
+	if dryRun {
+		return summary, nil
+	}
+
+	err = s.Transaction(func(tx *gorm.DB) error {
+		for i := range ethKeys {
+			if err := tx.Set("gorm:insert_option", "ON CONFLICT (address) DO NOTHING").Create(&ethKeys[i]).Error; err != nil && err.Error() != "sql: no rows in result set" {
+				return errors.Wrapf(err, "while importing ETH key %s", ethKeys[i].Address.Hex())
+			}
+		}
+		for _, encrypted := range ocrKeys {
+			if err := tx.Create(encrypted).Error; err != nil {
+				return errors.Wrapf(err, "while importing OCR key bundle %s", encrypted.ID)
+			}
+		}
+		for i := range p2pKeys {
+			if err := tx.Create(&p2pKeys[i]).Error; err != nil {
+				return errors.Wrapf(err, "while importing P2P key %s", p2pKeys[i].PeerID)
+			}
+		}
+		for _, encrypted := range encryptedVRFKeys {
+			if err := tx.FirstOrCreate(encrypted).Error; err != nil {
+				return errors.Wrapf(err, "while importing VRF key %s", encrypted.PublicKey)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return KeyBackupSummary{}, err
+	}
+
+	// The database is the source of truth for keys, and the on disk ETH
+	// keystore is rebuilt from it on boot, so failing to load a saved key here
+	// only means it is not usable until the node restarts.
+	for i, keyJSON := range ethKeyJSONs {
+		if _, err := s.KeyStore.Import(keyJSON, nodePassword, nodePassword); err != nil {
+			return summary, errors.Wrapf(err, "while loading imported ETH key %s", ethKeys[i].Address.Hex())
+		}
+	}
+	for _, key := range vrfKeys {
+		s.VRFKeyStore.remember(key)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,141 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	strpkg "github.com/smartcontractkit/chainlink/core/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_KeyBackup(t *testing.T) {
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	const backupPassword = "backup password"
+
+	account, err := store.KeyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	require.NoError(t, store.SyncDiskKeyStoreToDB())
+	require.NoError(t, store.OCRKeyStore.Unlock(cltest.Password))
+	_, ocrKey, err := store.OCRKeyStore.GenerateEncryptedOCRKeyBundle()
+	require.NoError(t, err)
+	_, p2pKey, err := store.OCRKeyStore.GenerateEncryptedP2PKey()
+	require.NoError(t, err)
+	vrfKey, err := store.VRFKeyStore.CreateKey(cltest.Password)
+	require.NoError(t, err)
+
+	backup, err := store.ExportKeyBackup(cltest.Password, backupPassword)
+	require.NoError(t, err)
+	assert.Equal(t, strpkg.KeyBackupVersion, backup.Version)
+	require.Len(t, backup.ETHKeys, len(store.KeyStore.Accounts()))
+	var exported bool
+	for _, ethKey := range backup.ETHKeys {
+		exported = exported || ethKey.Address == account.Address
+	}
+	assert.True(t, exported)
+	require.Len(t, backup.OCRKeyBundles, 1)
+	require.Len(t, backup.P2PKeys, 1)
+	require.Len(t, backup.VRFKeys, 1)
+	assert.Equal(t, ocrKey.ID, backup.OCRKeyBundles[0].ID)
+	assert.Equal(t, p2pKey.PeerID, backup.P2PKeys[0].PeerID)
+	assert.Equal(t, vrfKey, backup.VRFKeys[0].PublicKey)
+
+	t.Run("fails on the wrong password without importing anything", func(t *testing.T) {
+		_, err := store.ImportKeyBackup(backup, "wrong password", cltest.Password, false)
+		require.Error(t, err)
+	})
+
+	t.Run("fails on an unsupported version", func(t *testing.T) {
+		future := *backup
+		future.Version = strpkg.KeyBackupVersion + 1
+		_, err := store.ImportKeyBackup(&future, backupPassword, cltest.Password, true)
+		require.Error(t, err)
+	})
+
+	t.Run("skips keys the node already has", func(t *testing.T) {
+		summary, err := store.ImportKeyBackup(backup, backupPassword, cltest.Password, false)
+		require.NoError(t, err)
+		assert.Equal(t, strpkg.KeyBackupCount{Skipped: len(backup.ETHKeys)}, summary.ETHKeys)
+		assert.Equal(t, strpkg.KeyBackupCount{Skipped: 1}, summary.OCRKeyBundles)
+		assert.Equal(t, strpkg.KeyBackupCount{Skipped: 1}, summary.P2PKeys)
+		assert.Equal(t, strpkg.KeyBackupCount{Skipped: 1}, summary.VRFKeys)
+	})
+
+	require.NoError(t, store.OCRKeyStore.DeleteEncryptedOCRKeyBundle(&ocrKey))
+	require.NoError(t, store.OCRKeyStore.DeleteEncryptedP2PKey(&p2pKey))
+	require.NoError(t, store.VRFKeyStore.Delete(vrfKey))
+
+	t.Run("dry run imports nothing", func(t *testing.T) {
+		summary, err := store.ImportKeyBackup(backup, backupPassword, cltest.Password, true)
+		require.NoError(t, err)
+		assert.Equal(t, strpkg.KeyBackupCount{Skipped: len(backup.ETHKeys)}, summary.ETHKeys)
+		assert.Equal(t, 1, summary.OCRKeyBundles.Imported)
+		assert.Equal(t, 1, summary.P2PKeys.Imported)
+		assert.Equal(t, 1, summary.VRFKeys.Imported)
+
+		ocrKeys, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		assert.Len(t, ocrKeys, 0)
+	})
+
+	t.Run("imports keys encrypted with the node password", func(t *testing.T) {
+		summary, err := store.ImportKeyBackup(backup, backupPassword, cltest.Password, false)
+		require.NoError(t, err)
+		assert.Equal(t, strpkg.KeyBackupCount{Skipped: len(backup.ETHKeys)}, summary.ETHKeys)
+		assert.Equal(t, 1, summary.OCRKeyBundles.Imported)
+		assert.Equal(t, 1, summary.P2PKeys.Imported)
+		assert.Equal(t, 1, summary.VRFKeys.Imported)
+
+		ocrKeys, err := store.OCRKeyStore.FindEncryptedOCRKeyBundles()
+		require.NoError(t, err)
+		require.Len(t, ocrKeys, 1)
+		_, err = ocrKeys[0].Decrypt(cltest.Password)
+		require.NoError(t, err)
+
+		p2pKeys, err := store.OCRKeyStore.FindEncryptedP2PKeys()
+		require.NoError(t, err)
+		require.Len(t, p2pKeys, 1)
+		_, err = p2pKeys[0].Decrypt(cltest.Password)
+		require.NoError(t, err)
+
+		vrfKeys, err := store.VRFKeyStore.Get(vrfKey)
+		require.NoError(t, err)
+		require.Len(t, vrfKeys, 1)
+		_, err = vrfKeys[0].Decrypt(cltest.Password)
+		require.NoError(t, err)
+	})
+}
+
+func TestStore_ImportKeyBackup_FundingKey(t *testing.T) {
+	backedUp, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+	const backupPassword = "backup password"
+
+	account, err := backedUp.KeyStore.NewAccount(cltest.Password)
+	require.NoError(t, err)
+	require.NoError(t, backedUp.SyncDiskKeyStoreToDB())
+	require.NoError(t, backedUp.DB.Exec(`UPDATE keys SET is_funding = TRUE WHERE address = ?`, account.Address).Error)
+	backup, err := backedUp.ExportKeyBackup(cltest.Password, backupPassword)
+	require.NoError(t, err)
+
+	// The node already has a funding key of its own
+	fundingKey := cltest.MustInsertRandomKey(t, store)
+	require.NoError(t, store.DB.Exec(`UPDATE keys SET is_funding = TRUE WHERE address = ?`, fundingKey.Address).Error)
+
+	for _, dryRun := range []bool{true, false} {
+		summary, err := store.ImportKeyBackup(backup, backupPassword, cltest.Password, dryRun)
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.ETHKeys.Imported)
+	}
+
+	imported, err := store.KeyByAddress(account.Address)
+	require.NoError(t, err)
+	assert.False(t, imported.IsFunding)
+	existing, err := store.KeyByAddress(fundingKey.Address.Address())
+	require.NoError(t, err)
+	assert.True(t, existing.IsFunding)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/store/models"
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/multierr"
 )
 
@@ -36,18 +38,43 @@ type KeyStoreInterface interface {
 	GetAccountByAddress(common.Address) (accounts.Account, error)
 
 	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignDynamicFeeTx(account accounts.Account, tx models.DynamicFeeTx) ([]byte, common.Hash, error)
 }
 
-// KeyStore manages a key storage directory on disk.
+// KeyStore manages a key storage directory on disk, or the accounts of a
+// remote signer.
 type KeyStore struct {
 	*keystore.KeyStore
 	scryptParams utils.ScryptParams
+	signer       Signer
+
+	// remote is set if keys are held by a remote signer instead of on disk,
+	// in which case its accounts are cached in remoteAccounts
+	remote            bool
+	remoteAccounts    []accounts.Account
+	remoteAccountsMtx sync.RWMutex
+
+	// hdSeed is set once the node's BIP-39 seed is unlocked, after which new
+	// accounts are derived from it, starting at hdNextIndex
+	hdSeed      []byte
+	hdNextIndex uint32
+	hdIndexes   map[common.Address]uint32
+	hdMtx       sync.Mutex
 }
 
 // NewKeyStore creates a keystore for the given directory.
 func NewKeyStore(keyDir string, scryptParams utils.ScryptParams) *KeyStore {
 	ks := keystore.NewKeyStore(keyDir, scryptParams.N, scryptParams.P)
-	return &KeyStore{ks, scryptParams}
+	return &KeyStore{KeyStore: ks, scryptParams: scryptParams, signer: localSigner{ks}}
+}
+
+// NewRemoteSignerKeyStore creates a keystore whose keys are held by the given
+// signer, which signs with them, instead of being stored on disk.
+func NewRemoteSignerKeyStore(keyDir string, scryptParams utils.ScryptParams, signer Signer) *KeyStore {
+	ks := NewKeyStore(keyDir, scryptParams)
+	ks.signer = signer
+	ks.remote = true
+	return ks
 }
 
 // NewInsecureKeyStore creates an *INSECURE* keystore for the given directory.
@@ -56,6 +83,41 @@ func NewInsecureKeyStore(keyDir string) *KeyStore {
 	return NewKeyStore(keyDir, utils.FastScryptParams)
 }
 
+// IsRemote returns true if keys are held by a remote signer
+func (ks *KeyStore) IsRemote() bool {
+	return ks.remote
+}
+
+// Accounts returns the accounts on disk, or those of the remote signer
+func (ks *KeyStore) Accounts() []accounts.Account {
+	if !ks.remote {
+		return ks.KeyStore.Accounts()
+	}
+	ks.remoteAccountsMtx.RLock()
+	accts := ks.remoteAccounts
+	ks.remoteAccountsMtx.RUnlock()
+	if accts != nil {
+		return accts
+	}
+	if err := ks.refreshRemoteAccounts(); err != nil {
+		logger.Errorw("Could not list accounts of remote signer", "error", err)
+	}
+	ks.remoteAccountsMtx.RLock()
+	defer ks.remoteAccountsMtx.RUnlock()
+	return ks.remoteAccounts
+}
+
+func (ks *KeyStore) refreshRemoteAccounts() error {
+	accts, err := ks.signer.Accounts()
+	if err != nil {
+		return err
+	}
+	ks.remoteAccountsMtx.Lock()
+	defer ks.remoteAccountsMtx.Unlock()
+	ks.remoteAccounts = accts
+	return nil
+}
+
 // HasAccounts returns true if there are accounts located at the keystore
 // directory.
 func (ks *KeyStore) HasAccounts() bool {
@@ -63,8 +125,18 @@ func (ks *KeyStore) HasAccounts() bool {
 }
 
 // Unlock uses the given password to try to unlock accounts located in the
-// keystore directory.
+// keystore directory. With a remote signer there is nothing to unlock, and
+// the accounts of the signer are listed again instead.
 func (ks *KeyStore) Unlock(phrase string) error {
+	if ks.remote {
+		if err := ks.refreshRemoteAccounts(); err != nil {
+			return err
+		}
+		for _, account := range ks.Accounts() {
+			logger.Infow(fmt.Sprint("Using remote signer account ", account.Address.Hex()), "address", account.Address.Hex())
+		}
+		return nil
+	}
 	var merr error
 	for _, account := range ks.Accounts() {
 		err := ks.KeyStore.Unlock(account, phrase)
@@ -77,9 +149,16 @@ func (ks *KeyStore) Unlock(phrase string) error {
 	return merr
 }
 
+// ErrRemoteSigner is returned when keys are held by a remote signer, and
+// must be managed there
+var ErrRemoteSigner = errors.New("keys are held by the remote signer, and must be managed there")
+
 // NewAccount adds an account to the keystore
 func (ks *KeyStore) NewAccount(passphrase string) (accounts.Account, error) {
-	account, err := ks.KeyStore.NewAccount(passphrase)
+	if ks.remote {
+		return accounts.Account{}, ErrRemoteSigner
+	}
+	account, err := ks.newAccount(passphrase)
 	if err != nil {
 		return accounts.Account{}, err
 	}
@@ -92,39 +171,126 @@ func (ks *KeyStore) NewAccount(passphrase string) (accounts.Account, error) {
 	return account, nil
 }
 
+// newAccount derives the next account from the node's seed if it has one,
+// or generates a random one otherwise
+func (ks *KeyStore) newAccount(passphrase string) (accounts.Account, error) {
+	ks.hdMtx.Lock()
+	defer ks.hdMtx.Unlock()
+	if ks.hdSeed == nil {
+		return ks.KeyStore.NewAccount(passphrase)
+	}
+	for {
+		index := ks.hdNextIndex
+		privateKey, err := deriveHDKey(ks.hdSeed, index)
+		if err != nil {
+			return accounts.Account{}, err
+		}
+		ks.hdNextIndex++
+		address := crypto.PubkeyToAddress(privateKey.PublicKey)
+		ks.hdIndexes[address] = index
+		// A key restored from a backup may already be on disk, in which case
+		// the next index is derived instead
+		if ks.KeyStore.HasAddress(address) {
+			continue
+		}
+		return ks.KeyStore.ImportECDSA(privateKey, passphrase)
+	}
+}
+
+// useHDSeed derives new accounts from the given BIP-39 seed, starting at
+// nextIndex on the derivation path
+func (ks *KeyStore) useHDSeed(seed []byte, nextIndex uint32) {
+	ks.hdMtx.Lock()
+	defer ks.hdMtx.Unlock()
+	ks.hdSeed = seed
+	ks.hdNextIndex = nextIndex
+	if ks.hdIndexes == nil {
+		ks.hdIndexes = make(map[common.Address]uint32)
+	}
+}
+
+// HDIndex returns the index on the derivation path of an account derived
+// from the node's seed
+func (ks *KeyStore) HDIndex(address common.Address) (uint32, bool) {
+	ks.hdMtx.Lock()
+	defer ks.hdMtx.Unlock()
+	index, ok := ks.hdIndexes[address]
+	return index, ok
+}
+
+// Import adds the given key to the keystore
+func (ks *KeyStore) Import(keyJSON []byte, passphrase, newPassphrase string) (accounts.Account, error) {
+	if ks.remote {
+		return accounts.Account{}, ErrRemoteSigner
+	}
+	return ks.KeyStore.Import(keyJSON, passphrase, newPassphrase)
+}
+
+// Export returns the given key encrypted with newPassphrase
+func (ks *KeyStore) Export(a accounts.Account, passphrase, newPassphrase string) ([]byte, error) {
+	if ks.remote {
+		return nil, ErrRemoteSigner
+	}
+	return ks.KeyStore.Export(a, passphrase, newPassphrase)
+}
+
 // SignTx uses the unlocked account to sign the given transaction.
 func (ks *KeyStore) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
-	return ks.KeyStore.SignTx(account, tx, chainID)
+	return ks.signer.SignTx(account, tx, chainID)
+}
+
+// SignDynamicFeeTx uses the unlocked account to sign the given EIP-1559
+// transaction, and returns the raw signed transaction and its hash
+func (ks *KeyStore) SignDynamicFeeTx(account accounts.Account, tx models.DynamicFeeTx) ([]byte, common.Hash, error) {
+	return ks.signer.SignDynamicFeeTx(account, tx)
 }
 
 // SignHash signs a precomputed digest, using the first account's private key
 // This method adds an ethereum message prefix to the message before signing it,
 // invalidating any would-be valid Ethereum transactions
 func (ks *KeyStore) SignHash(hash common.Hash) (models.Signature, error) {
-	prefixedMessageBytes, err := utils.Keccak256(append([]byte(EthereumMessageHashPrefix), hash.Bytes()...))
+	account, err := ks.GetFirstAccount()
 	if err != nil {
 		return models.Signature{}, err
 	}
+	return ks.signer.SignMessageHash(account, hash)
+}
+
+// localSigner signs with the unlocked keys of the keystore on disk
+type localSigner struct {
+	ks *keystore.KeyStore
+}
+
+func (ls localSigner) Accounts() ([]accounts.Account, error) {
+	return ls.ks.Accounts(), nil
+}
+
+func (ls localSigner) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return ls.ks.SignTx(account, tx, chainID)
+}
+
+func (ls localSigner) SignDynamicFeeTx(account accounts.Account, tx models.DynamicFeeTx) ([]byte, common.Hash, error) {
+	sig, err := ls.ks.SignHash(account, tx.SigningHash().Bytes())
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	return tx.EncodeSigned(sig)
+}
 
-	signature, err := ks.unsafeSignHash(common.BytesToHash(prefixedMessageBytes))
+func (ls localSigner) SignMessageHash(account accounts.Account, hash common.Hash) (models.Signature, error) {
+	prefixedMessageBytes, err := utils.Keccak256(append([]byte(EthereumMessageHashPrefix), hash.Bytes()...))
 	if err != nil {
 		return models.Signature{}, err
 	}
-
-	return signature, nil
+	return ls.unsafeSignHash(account, common.BytesToHash(prefixedMessageBytes))
 }
 
-// unsafeSignHash signs a precomputed digest, using the first account's private
-// key
+// unsafeSignHash signs a precomputed digest
 // NOTE: Do not use this method to sign arbitrary message hashes, it may be an
-// Ethereum transaction in disguise! Use SignHashSafe instead unless this is
+// Ethereum transaction in disguise! Use SignMessageHash instead unless this is
 // strictly needed
-func (ks *KeyStore) unsafeSignHash(hash common.Hash) (models.Signature, error) {
-	account, err := ks.GetFirstAccount()
-	if err != nil {
-		return models.Signature{}, err
-	}
-	output, err := ks.KeyStore.SignHash(account, hash.Bytes())
+func (ls localSigner) unsafeSignHash(account accounts.Account, hash common.Hash) (models.Signature, error) {
+	output, err := ls.ks.SignHash(account, hash.Bytes())
 	if err != nil {
 		return models.Signature{}, err
 	}
@@ -82,6 +82,20 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1604003825"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1604437959"
 	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1604674426"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1605213161"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1605630295"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1606141477"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1606303568"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1606749860"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1606910307"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607025446"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607113528"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607204732"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607290327"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607378495"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607452286"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607539142"
+	"github.com/smartcontractkit/chainlink/core/store/migrations/migration1607541833"
 	gormigrate "gopkg.in/gormigrate.v1"
 )
 
@@ -399,6 +413,62 @@ func init() {
 			ID:      "1604674426",
 			Migrate: migration1604674426.Migrate,
 		},
+		{
+			ID:      "1605213161",
+			Migrate: migration1605213161.Migrate,
+		},
+		{
+			ID:      "1605630295",
+			Migrate: migration1605630295.Migrate,
+		},
+		{
+			ID:      "1606141477",
+			Migrate: migration1606141477.Migrate,
+		},
+		{
+			ID:      "1606303568",
+			Migrate: migration1606303568.Migrate,
+		},
+		{
+			ID:      "1606749860",
+			Migrate: migration1606749860.Migrate,
+		},
+		{
+			ID:      "1606910307",
+			Migrate: migration1606910307.Migrate,
+		},
+		{
+			ID:      "1607025446",
+			Migrate: migration1607025446.Migrate,
+		},
+		{
+			ID:      "1607113528",
+			Migrate: migration1607113528.Migrate,
+		},
+		{
+			ID:      "1607204732",
+			Migrate: migration1607204732.Migrate,
+		},
+		{
+			ID:      "1607290327",
+			Migrate: migration1607290327.Migrate,
+		},
+		{
+			ID:      "1607378495",
+			Migrate: migration1607378495.Migrate,
+		},
+		{
+			ID:      "1607452286",
+			Migrate: migration1607452286.Migrate,
+		},
+		{
+			ID:      "1607539142",
+			Migrate: migration1607539142.Migrate,
+		},
+		{
+			ID:      "1607541833",
+			Migrate: migration1607541833.Migrate,
+		},
 	}
 }
 
@@ -0,0 +1,14 @@
+package migration1605213161
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE pipeline_task_runs ADD COLUMN pending_until timestamptz;
+CREATE INDEX idx_pipeline_task_runs_pending ON pipeline_task_runs (pending_until) WHERE pending_until IS NOT NULL AND finished_at IS NULL;
+`
+
+// Migrate adds the pending_until column which allows asynchronous bridge task
+// runs to be suspended until the external adapter calls back
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
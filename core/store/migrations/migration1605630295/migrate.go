@@ -0,0 +1,21 @@
+package migration1605630295
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE encrypted_secrets (
+	id BIGSERIAL PRIMARY KEY,
+	name text NOT NULL CHECK (name != ''),
+	encrypted_value jsonb NOT NULL,
+	created_at timestamptz NOT NULL,
+	updated_at timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_encrypted_secrets_name ON encrypted_secrets (name);
+`
+
+// Migrate creates the encrypted_secrets table, which holds secrets (e.g. API
+// keys) that are referenced by name from job specs
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
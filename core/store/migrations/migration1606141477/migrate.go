@@ -0,0 +1,19 @@
+package migration1606141477
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE log_cursors (
+	name text PRIMARY KEY CHECK (name != ''),
+	initialized boolean NOT NULL DEFAULT true,
+	block_index bigint NOT NULL DEFAULT 0,
+	log_index bigint NOT NULL DEFAULT 0
+);
+`
+
+// Migrate recreates the log_cursors table, which holds the block up to which
+// each log listener has been sent every log, so that the LogBroadcaster can
+// backfill from there after a restart
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,22 @@
+package migration1606303568
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE eth_tx_attempts
+	ADD COLUMN tx_type smallint NOT NULL DEFAULT 0,
+	ADD COLUMN gas_tip_cap numeric(78,0),
+	ADD COLUMN gas_fee_cap numeric(78,0);
+
+ALTER TABLE eth_tx_attempts ADD CONSTRAINT chk_tx_type_fees CHECK (
+	(tx_type = 0 AND gas_tip_cap IS NULL AND gas_fee_cap IS NULL) OR
+	(tx_type = 2 AND gas_tip_cap IS NOT NULL AND gas_fee_cap = gas_price AND gas_tip_cap <= gas_fee_cap)
+);
+`
+
+// Migrate adds the EIP-1559 tip cap and fee cap to eth_tx_attempts.  Dynamic
+// fee attempts store their fee cap in gas_price too, so that attempts of either
+// type can be ordered by the most they can pay per unit of gas.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
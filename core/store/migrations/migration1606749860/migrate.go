@@ -0,0 +1,34 @@
+package migration1606749860
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE eth_txes
+	ADD COLUMN max_gas_price_wei numeric(78,0),
+	ADD COLUMN gas_bump_percent smallint,
+	ADD COLUMN gas_bump_threshold bigint,
+	ADD COLUMN drop_after_blocks bigint,
+	ADD COLUMN priority integer NOT NULL DEFAULT 0,
+	ADD COLUMN cancelled_at timestamptz;
+
+ALTER TABLE eth_txes ADD CONSTRAINT chk_gas_strategy_is_sane CHECK (
+	(max_gas_price_wei IS NULL OR max_gas_price_wei > 0) AND
+	(gas_bump_percent IS NULL OR gas_bump_percent >= 10) AND
+	(gas_bump_threshold IS NULL OR gas_bump_threshold > 0) AND
+	(drop_after_blocks IS NULL OR drop_after_blocks > 0)
+);
+
+ALTER TABLE eth_txes ADD CONSTRAINT chk_only_broadcast_txes_are_cancelled CHECK (
+	cancelled_at IS NULL OR state IN ('unconfirmed', 'confirmed_missing_receipt', 'confirmed')
+);
+
+CREATE INDEX idx_eth_txes_unstarted_by_priority ON eth_txes (from_address, priority DESC, id) WHERE state = 'unstarted';
+`
+
+// Migrate adds a per transaction gas strategy to eth_txes.  Each column
+// overrides the matching ETH_* setting, and unstarted transactions are sent
+// in order of priority.  Geth will not replace a transaction for less than a
+// 10% bump, so lower values of gas_bump_percent are refused.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1606910307
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE eth_txes ADD COLUMN simulate boolean NOT NULL DEFAULT false;
+`
+
+// Migrate lets transactions opt in to being simulated with eth_call before
+// they are broadcast for the first time
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
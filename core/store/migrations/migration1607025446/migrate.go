@@ -0,0 +1,20 @@
+package migration1607025446
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE keys
+	ADD COLUMN group_name text,
+	ADD COLUMN eth_balance numeric(78,0);
+
+ALTER TABLE keys ADD CONSTRAINT chk_group_name_not_empty CHECK (
+	group_name IS NULL OR group_name <> ''
+);
+`
+
+// Migrate lets keys be put into named groups for sending transactions, and
+// records the last known balance of each key so that unfunded keys can be
+// skipped when picking one
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
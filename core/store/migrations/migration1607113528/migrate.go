@@ -0,0 +1,32 @@
+package migration1607113528
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE keys
+	ADD COLUMN eth_low_watermark numeric(78,0),
+	ADD COLUMN eth_high_watermark numeric(78,0);
+
+ALTER TABLE keys ADD CONSTRAINT chk_eth_watermarks CHECK (
+	eth_low_watermark IS NULL OR eth_high_watermark IS NULL OR eth_high_watermark >= eth_low_watermark
+);
+
+CREATE TABLE treasury_transfers (
+	id BIGSERIAL PRIMARY KEY,
+	eth_tx_id bigint REFERENCES eth_txes (id) ON DELETE SET NULL,
+	from_address bytea NOT NULL,
+	to_address bytea NOT NULL,
+	amount numeric(78,0) NOT NULL,
+	to_balance numeric(78,0) NOT NULL,
+	created_at timestamptz NOT NULL
+);
+
+CREATE INDEX idx_treasury_transfers_created_at ON treasury_transfers (created_at);
+CREATE INDEX idx_treasury_transfers_to_address ON treasury_transfers (to_address);
+`
+
+// Migrate adds per-key ETH watermarks for topping up keys from the treasury
+// key, and an audit log of every such top up
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1607204732
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE keys ADD COLUMN is_remote boolean NOT NULL DEFAULT false;
+`
+
+// Migrate marks keys that are held by a remote signer, which are not written
+// to the keystore on disk
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,15 @@
+package migration1607290327
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE offchainreporting_oracle_specs
+	ADD COLUMN next_encrypted_ocr_key_bundle_id bytea REFERENCES encrypted_ocr_key_bundles (id),
+	ADD COLUMN next_p2p_peer_id text REFERENCES encrypted_p2p_keys (peer_id);
+`
+
+// Migrate adds the keys that OCR jobs rotate to once the on-chain config
+// lists them
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
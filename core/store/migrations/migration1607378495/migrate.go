@@ -0,0 +1,23 @@
+package migration1607378495
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+CREATE TABLE encrypted_eth_hd_seeds (
+	id BIGSERIAL PRIMARY KEY,
+	encrypted_seed jsonb NOT NULL,
+	created_at timestamptz NOT NULL
+);
+
+-- A node has at most one seed
+CREATE UNIQUE INDEX idx_encrypted_eth_hd_seeds_singleton ON encrypted_eth_hd_seeds ((true));
+
+ALTER TABLE keys ADD COLUMN hd_index integer CHECK (hd_index >= 0);
+CREATE UNIQUE INDEX idx_keys_unique_hd_index ON keys (hd_index);
+`
+
+// Migrate adds the encrypted BIP-39 seed that Ethereum keys are derived from,
+// and the derivation index of each key
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -0,0 +1,13 @@
+package migration1607452286
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE pipeline_task_runs ADD COLUMN resume_token_hash text;
+`
+
+// Migrate adds the hash of the token that an external adapter must present
+// to resume a pending async bridge task run
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
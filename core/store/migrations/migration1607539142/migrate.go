@@ -0,0 +1,14 @@
+package migration1607539142
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+ALTER TABLE eth_tx_attempts ADD COLUMN cancellation boolean NOT NULL DEFAULT false;
+`
+
+// Migrate marks the attempts that replace a cancelled transaction with an
+// empty one, so that a cancelled transaction whose original payload was mined
+// anyway can be told apart from one that was actually cancelled
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
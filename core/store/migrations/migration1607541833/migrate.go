@@ -0,0 +1,15 @@
+package migration1607541833
+
+import "github.com/jinzhu/gorm"
+
+const up = `
+DROP INDEX idx_only_one_in_progress_tx_per_account;
+CREATE INDEX idx_eth_txes_in_progress_by_nonce ON eth_txes (from_address, nonce) WHERE state = 'in_progress';
+`
+
+// Migrate allows more than one in_progress transaction per key, so that the
+// EthBroadcaster can assign nonces to several transactions and send them
+// together
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(up).Error
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/robfig/cron/v3"
 	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -602,11 +603,27 @@ type SendEtherRequest struct {
 	Amount             assets.Eth     `json:"amount"`
 }
 
+// EthTxPatchRequest represents a request to cancel an eth_tx, or to send it
+// again at a higher gas price.
+type EthTxPatchRequest struct {
+	Cancel      bool       `json:"cancel"`
+	GasPriceWei *utils.Big `json:"gasPriceWei"`
+}
+
 // CreateKeyRequest represents a request to add an ethereum key.
 type CreateKeyRequest struct {
 	CurrentPassword string `json:"current_password"`
 }
 
+// UpdateKeyRequest represents a request to change the group or the watermarks
+// of an ethereum key. Only the fields that are set are changed, and
+// watermarks of zero fall back to the node's configuration.
+type UpdateKeyRequest struct {
+	Group         *string     `json:"group"`
+	LowWatermark  *assets.Eth `json:"lowWatermark"`
+	HighWatermark *assets.Eth `json:"highWatermark"`
+}
+
 // CreateOCRJobSpecRequest represents a request to create and start and OCR job spec.
 type CreateOCRJobSpecRequest struct {
 	TOML string `json:"toml"`
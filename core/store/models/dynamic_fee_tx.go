@@ -0,0 +1,89 @@
+package models
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// LegacyTxType is the type of transactions that pay a single gas price
+	LegacyTxType = 0
+	// DynamicFeeTxType is the EIP-2718 type of EIP-1559 dynamic fee
+	// transactions, which pay the block's base fee plus a tip
+	DynamicFeeTxType = 2
+)
+
+// DynamicFeeTx is an EIP-1559 dynamic fee transaction.
+//
+// The version of go-ethereum we use predates typed transactions, so its
+// types.Transaction cannot represent these.  Instead they are signed and
+// encoded here, and broadcast as raw bytes.
+type DynamicFeeTx struct {
+	ChainID   *big.Int
+	Nonce     uint64
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	Gas       uint64
+	To        common.Address
+	Value     *big.Int
+	Data      []byte
+}
+
+// SigningHash returns the hash that the sender signs, which commits to every
+// field of the transaction and to its type
+func (tx DynamicFeeTx) SigningHash() common.Hash {
+	return prefixedRLPHash(DynamicFeeTxType, tx.fields())
+}
+
+// EncodeSigned returns the transaction as accepted by eth_sendRawTransaction,
+// along with its hash.  The signature must be in the 65 byte [R || S || V]
+// format returned by crypto.Sign, where V is 0 or 1.
+func (tx DynamicFeeTx) EncodeSigned(sig []byte) ([]byte, common.Hash, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, common.Hash{}, errors.Errorf("wrong size for signature: got %d, want %d", len(sig), crypto.SignatureLength)
+	}
+	if sig[64] > 1 {
+		return nil, common.Hash{}, errors.Errorf("invalid signature recovery id %d", sig[64])
+	}
+	v := uint64(sig[64])
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+
+	payload, err := rlp.EncodeToBytes(append(tx.fields(), v, r, s))
+	if err != nil {
+		return nil, common.Hash{}, errors.Wrap(err, "could not encode dynamic fee transaction")
+	}
+	raw := append([]byte{DynamicFeeTxType}, payload...)
+	return raw, crypto.Keccak256Hash(raw), nil
+}
+
+// fields returns the unsigned fields of the transaction in the order they are
+// encoded.  The access list is always empty.
+func (tx DynamicFeeTx) fields() []interface{} {
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		tx.GasTipCap,
+		tx.GasFeeCap,
+		tx.Gas,
+		tx.To,
+		tx.Value,
+		tx.Data,
+		[]interface{}{},
+	}
+}
+
+func prefixedRLPHash(prefix byte, x interface{}) common.Hash {
+	payload, err := rlp.EncodeToBytes(x)
+	if err != nil {
+		// Only unencodable types can fail, and the fields above are all
+		// encodable
+		panic(err)
+	}
+	return crypto.Keccak256Hash(append([]byte{prefix}, payload...))
+}
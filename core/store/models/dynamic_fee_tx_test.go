@@ -0,0 +1,62 @@
+package models_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicFeeTx_EncodeSigned(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	tx := models.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     42,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(100000000000),
+		Gas:       21000,
+		To:        common.HexToAddress("0x6b175474e89094c44da98b954eedeac495271d0f"),
+		Value:     big.NewInt(1),
+		Data:      []byte{0xca, 0xfe},
+	}
+	sig, err := crypto.Sign(tx.SigningHash().Bytes(), key)
+	require.NoError(t, err)
+
+	raw, hash, err := tx.EncodeSigned(sig)
+	require.NoError(t, err)
+
+	assert.Equal(t, byte(models.DynamicFeeTxType), raw[0])
+	assert.Equal(t, crypto.Keccak256Hash(raw), hash)
+
+	var fields []rlp.RawValue
+	require.NoError(t, rlp.DecodeBytes(raw[1:], &fields))
+	// chainId, nonce, tip cap, fee cap, gas, to, value, data, access list, v, r, s
+	require.Len(t, fields, 12)
+	var to common.Address
+	require.NoError(t, rlp.DecodeBytes(fields[5], &to))
+	assert.Equal(t, tx.To, to)
+
+	pub, err := crypto.SigToPub(tx.SigningHash().Bytes(), sig)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+
+	t.Run("rejects malformed signatures", func(t *testing.T) {
+		_, _, err := tx.EncodeSigned(sig[:64])
+		assert.Error(t, err)
+
+		badV := append([]byte{}, sig...)
+		badV[64] = 27
+		_, _, err = tx.EncodeSigned(badV)
+		assert.Error(t, err)
+	})
+}
@@ -56,7 +56,57 @@ type EthTx struct {
 	BroadcastAt    *time.Time
 	CreatedAt      time.Time
 	State          EthTxState
-	EthTxAttempts  []EthTxAttempt `gorm:"association_autoupdate:false;association_autocreate:false"`
+	EthTxStrategy
+	// CancelledAt is set once the transaction has been replaced by an empty
+	// transaction to its own FromAddress, to free up its nonce
+	CancelledAt   *time.Time
+	EthTxAttempts []EthTxAttempt `gorm:"association_autoupdate:false;association_autocreate:false"`
+}
+
+const (
+	// EthTxPriorityDefault is the priority of transactions that do not ask
+	// for one
+	EthTxPriorityDefault = 0
+	// EthTxPriorityHigh is for transactions that lose their value if they are
+	// held up behind others, like OCR transmissions
+	EthTxPriorityHigh = 100
+)
+
+// EthTxStrategy is how the caller wants its EthTx to be priced and sent.
+// Each unset field falls back to the node's configuration.
+type EthTxStrategy struct {
+	// MaxGasPriceWei lowers ETH_MAX_GAS_PRICE_WEI for this transaction
+	MaxGasPriceWei *utils.Big `json:"maxGasPriceWei,omitempty"`
+	// GasBumpPercent overrides ETH_GAS_BUMP_PERCENT. It may not be less than 10.
+	GasBumpPercent *uint16 `json:"gasBumpPercent,omitempty"`
+	// GasBumpThreshold overrides ETH_GAS_BUMP_THRESHOLD
+	GasBumpThreshold *int64 `json:"gasBumpThreshold,omitempty"`
+	// DropAfterBlocks cancels the transaction if it has not been mined this
+	// many blocks after it was first broadcast
+	DropAfterBlocks *int64 `json:"dropAfterBlocks,omitempty"`
+	// Priority orders unstarted transactions from the same address, highest
+	// first
+	Priority int32 `json:"priority,omitempty"`
+	// Simulate runs the transaction with eth_call before it is first
+	// broadcast, and fails it without sending if it would revert
+	Simulate bool `json:"simulate,omitempty"`
+}
+
+// Validate returns an error if the strategy would be refused by the database
+func (s EthTxStrategy) Validate() error {
+	if s.MaxGasPriceWei != nil && s.MaxGasPriceWei.ToInt().Sign() <= 0 {
+		return errors.New("maxGasPriceWei must be greater than 0")
+	}
+	if s.GasBumpPercent != nil && *s.GasBumpPercent < 10 {
+		return fmt.Errorf("gasBumpPercent of %v may not be less than Geth's minimum of 10", *s.GasBumpPercent)
+	}
+	if s.GasBumpThreshold != nil && *s.GasBumpThreshold <= 0 {
+		return errors.New("gasBumpThreshold must be greater than 0")
+	}
+	if s.DropAfterBlocks != nil && *s.DropAfterBlocks <= 0 {
+		return errors.New("dropAfterBlocks must be greater than 0")
+	}
+	return nil
 }
 
 func (e EthTx) GetError() error {
@@ -72,16 +122,26 @@ func (e EthTx) GetID() string {
 }
 
 type EthTxAttempt struct {
-	ID                      int64
-	EthTxID                 int64
-	EthTx                   EthTx
-	GasPrice                utils.Big
+	ID      int64
+	EthTxID int64
+	EthTx   EthTx
+	// GasPrice is the most the attempt can pay per unit of gas.  For dynamic
+	// fee attempts this is the same as GasFeeCap.
+	GasPrice utils.Big
+	// TxType is DynamicFeeTxType for EIP-1559 attempts, which also set
+	// GasTipCap and GasFeeCap, and LegacyTxType otherwise
+	TxType                  int
+	GasTipCap               *utils.Big
+	GasFeeCap               *utils.Big
 	SignedRawTx             []byte
 	Hash                    common.Hash
 	CreatedAt               time.Time
 	BroadcastBeforeBlockNum *int64
 	State                   EthTxAttemptState
-	EthReceipts             []EthReceipt `gorm:"foreignkey:TxHash;association_foreignkey:Hash;association_autoupdate:false;association_autocreate:false"`
+	// Cancellation is set on attempts that replace a cancelled transaction
+	// with an empty one, instead of carrying its payload
+	Cancellation bool
+	EthReceipts  []EthReceipt `gorm:"foreignkey:TxHash;association_foreignkey:Hash;association_autoupdate:false;association_autocreate:false"`
 }
 
 type EthReceipt struct {
@@ -94,8 +154,12 @@ type EthReceipt struct {
 	CreatedAt        time.Time
 }
 
-// GetSignedTx decodes the SignedRawTx into a types.Transaction struct
+// GetSignedTx decodes the SignedRawTx into a types.Transaction struct. This
+// fails for dynamic fee attempts, which types.Transaction cannot represent.
 func (a EthTxAttempt) GetSignedTx() (*types.Transaction, error) {
+	if a.TxType == DynamicFeeTxType {
+		return nil, errors.New("cannot decode dynamic fee transaction")
+	}
 	s := rlp.NewStream(bytes.NewReader(a.SignedRawTx), 0)
 	signedTx := new(types.Transaction)
 	if err := signedTx.DecodeRLP(s); err != nil {
@@ -353,6 +417,19 @@ func (h *Head) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonHead)
 }
 
+// Reorg describes a switch to a longest chain that does not extend the
+// previous one
+type Reorg struct {
+	// Depth is the number of blocks of the previous longest chain that are
+	// no longer in the longest chain
+	Depth int64
+	// Removed are the hashes of those blocks, from the highest down
+	Removed []common.Hash
+	// Added are the hashes of the blocks that replaced them in the new longest
+	// chain, from the highest down
+	Added []common.Hash
+}
+
 // WeiPerEth is amount of Wei currency units in one Eth.
 var WeiPerEth = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
 
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// EncryptedHDSeed holds the BIP-39 seed that the node derives Ethereum keys
+// from, encrypted like the keys themselves
+type EncryptedHDSeed struct {
+	ID            int64
+	EncryptedSeed JSON
+	CreatedAt     time.Time
+}
+
+// TableName returns the name of the table for EncryptedHDSeed
+func (EncryptedHDSeed) TableName() string {
+	return "encrypted_eth_hd_seeds"
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultHMACSignatureHeader is the header the HMAC signature is sent in
+	// if none is specified
+	DefaultHMACSignatureHeader = "X-Signature"
+)
+
+// HTTPAuth describes how an outgoing HTTP request is authenticated using
+// secrets from the node's encrypted secrets store.  Secrets are referenced by
+// name and are only resolved at the time the request is made.
+type HTTPAuth struct {
+	// Headers are templates for request headers.  Secrets are referenced as
+	// {{secret "name"}}, e.g. "Bearer {{secret \"cmc_api_key\"}}".
+	Headers   map[string]string `json:"headers,omitempty"`
+	BasicAuth *HTTPBasicAuth    `json:"basicAuth,omitempty"`
+	HMAC      *HTTPHMACAuth     `json:"hmac,omitempty"`
+}
+
+// HTTPBasicAuth sets the request's Authorization header using HTTP basic
+// authentication, where the password is a named secret
+type HTTPBasicAuth struct {
+	Username       string `json:"username"`
+	PasswordSecret string `json:"passwordSecret"`
+}
+
+// HTTPHMACAuth signs the request with an HMAC keyed by a named secret.  The
+// signed message is the concatenation of the timestamp (if TimestampHeader is
+// set), the request method, the request URI (path and query) and the body.
+// The hex-encoded signature is sent in Header.
+type HTTPHMACAuth struct {
+	Secret          string `json:"secret"`
+	Algorithm       string `json:"algorithm,omitempty"`
+	Header          string `json:"header,omitempty"`
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+}
+
+// Apply adds the configured authentication to request.  body must be the
+// exact bytes that will be sent as the request body, since they are included
+// in the HMAC signature.
+func (a HTTPAuth) Apply(request *http.Request, body []byte, secrets SecretResolver) error {
+	if secrets == nil {
+		return errors.New("cannot authenticate http request: no secrets store available")
+	}
+
+	for name, tmpl := range a.Headers {
+		value, err := renderSecretTemplate(tmpl, secrets)
+		if err != nil {
+			return errors.Wrapf(err, "while rendering header %s", name)
+		}
+		request.Header.Set(name, value)
+	}
+
+	if a.BasicAuth != nil {
+		password, err := secrets.Secret(a.BasicAuth.PasswordSecret)
+		if err != nil {
+			return errors.Wrap(err, "while resolving basic auth password")
+		}
+		request.SetBasicAuth(a.BasicAuth.Username, password)
+	}
+
+	if a.HMAC != nil {
+		if err := a.HMAC.sign(request, body, secrets, time.Now()); err != nil {
+			return errors.Wrap(err, "while signing request")
+		}
+	}
+	return nil
+}
+
+// Identity returns a hash of the credentials that Apply authenticates with,
+// i.e. the auth config with its secrets resolved.  Requests made with
+// different credentials have different identities, without the identity
+// revealing the credentials.
+func (a HTTPAuth) Identity(secrets SecretResolver) (string, error) {
+	if secrets == nil {
+		return "", errors.New("cannot identify http auth: no secrets store available")
+	}
+
+	var resolved struct {
+		Headers   map[string]string `json:"headers"`
+		BasicAuth []string          `json:"basicAuth"`
+		HMAC      []string          `json:"hmac"`
+	}
+	resolved.Headers = make(map[string]string)
+	for name, tmpl := range a.Headers {
+		value, err := renderSecretTemplate(tmpl, secrets)
+		if err != nil {
+			return "", errors.Wrapf(err, "while rendering header %s", name)
+		}
+		resolved.Headers[name] = value
+	}
+	if a.BasicAuth != nil {
+		password, err := secrets.Secret(a.BasicAuth.PasswordSecret)
+		if err != nil {
+			return "", errors.Wrap(err, "while resolving basic auth password")
+		}
+		resolved.BasicAuth = []string{a.BasicAuth.Username, password}
+	}
+	if a.HMAC != nil {
+		key, err := secrets.Secret(a.HMAC.Secret)
+		if err != nil {
+			return "", errors.Wrap(err, "while resolving HMAC secret")
+		}
+		resolved.HMAC = []string{key, a.HMAC.Algorithm, a.HMAC.Header, a.HMAC.TimestampHeader}
+	}
+
+	resolvedBytes, err := json.Marshal(resolved)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(resolvedBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (h HTTPHMACAuth) sign(request *http.Request, body []byte, secrets SecretResolver, now time.Time) error {
+	key, err := secrets.Secret(h.Secret)
+	if err != nil {
+		return err
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(h.Algorithm) {
+	case "", "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return errors.Errorf("unsupported HMAC algorithm %s", h.Algorithm)
+	}
+
+	var timestamp string
+	if h.TimestampHeader != "" {
+		timestamp = fmt.Sprintf("%d", now.Unix())
+		request.Header.Set(h.TimestampHeader, timestamp)
+	}
+
+	mac := hmac.New(newHash, []byte(key))
+	mac.Write([]byte(timestamp + request.Method + request.URL.RequestURI()))
+	mac.Write(body)
+
+	header := h.Header
+	if header == "" {
+		header = DefaultHMACSignatureHeader
+	}
+	request.Header.Set(header, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+func renderSecretTemplate(tmpl string, secrets SecretResolver) (string, error) {
+	t, err := template.New("header").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"secret": secrets.Secret}).
+		Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, nil); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,150 @@
+package models_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSecrets map[string]string
+
+func (fs fakeSecrets) Secret(name string) (string, error) {
+	value, exists := fs[name]
+	if !exists {
+		return "", errors.Errorf("secret %s does not exist", name)
+	}
+	return value, nil
+}
+
+func TestHTTPAuth_Apply_Headers(t *testing.T) {
+	secrets := fakeSecrets{"api_key": "s3cr3t"}
+	auth := models.HTTPAuth{
+		Headers: map[string]string{
+			"Authorization": `Bearer {{secret "api_key"}}`,
+			"X-Static":      "static",
+		},
+	}
+
+	request, err := http.NewRequest("GET", "https://example.com/price", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.Apply(request, nil, secrets))
+
+	assert.Equal(t, "Bearer s3cr3t", request.Header.Get("Authorization"))
+	assert.Equal(t, "static", request.Header.Get("X-Static"))
+}
+
+func TestHTTPAuth_Apply_BasicAuth(t *testing.T) {
+	secrets := fakeSecrets{"password": "hunter2"}
+	auth := models.HTTPAuth{
+		BasicAuth: &models.HTTPBasicAuth{Username: "chainlink", PasswordSecret: "password"},
+	}
+
+	request, err := http.NewRequest("GET", "https://example.com/price", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.Apply(request, nil, secrets))
+
+	username, password, ok := request.BasicAuth()
+	require.True(t, ok)
+	assert.Equal(t, "chainlink", username)
+	assert.Equal(t, "hunter2", password)
+}
+
+func TestHTTPAuth_Apply_HMAC(t *testing.T) {
+	secrets := fakeSecrets{"hmac_key": "key"}
+	body := []byte(`{"hi":"there"}`)
+
+	t.Run("default header and algorithm", func(t *testing.T) {
+		auth := models.HTTPAuth{HMAC: &models.HTTPHMACAuth{Secret: "hmac_key"}}
+		request, err := http.NewRequest("POST", "https://example.com/price?coin=ETH", bytes.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, auth.Apply(request, body, secrets))
+
+		mac := hmac.New(sha256.New, []byte("key"))
+		mac.Write([]byte("POST/price?coin=ETH"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), request.Header.Get(models.DefaultHMACSignatureHeader))
+	})
+
+	t.Run("with timestamp", func(t *testing.T) {
+		auth := models.HTTPAuth{HMAC: &models.HTTPHMACAuth{
+			Secret:          "hmac_key",
+			Header:          "X-Sig",
+			TimestampHeader: "X-Timestamp",
+		}}
+		request, err := http.NewRequest("POST", "https://example.com/price", bytes.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, auth.Apply(request, body, secrets))
+
+		timestamp := request.Header.Get("X-Timestamp")
+		require.NotEmpty(t, timestamp)
+		mac := hmac.New(sha256.New, []byte("key"))
+		mac.Write([]byte(timestamp + "POST/price"))
+		mac.Write(body)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), request.Header.Get("X-Sig"))
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		auth := models.HTTPAuth{HMAC: &models.HTTPHMACAuth{Secret: "hmac_key", Algorithm: "md5"}}
+		request, err := http.NewRequest("POST", "https://example.com/price", bytes.NewReader(body))
+		require.NoError(t, err)
+		assert.Error(t, auth.Apply(request, body, secrets))
+	})
+}
+
+func TestHTTPAuth_Apply_Errors(t *testing.T) {
+	request, err := http.NewRequest("GET", "https://example.com/price", nil)
+	require.NoError(t, err)
+
+	auth := models.HTTPAuth{Headers: map[string]string{"Authorization": `{{secret "missing"}}`}}
+	assert.Error(t, auth.Apply(request, nil, fakeSecrets{}))
+	assert.Error(t, auth.Apply(request, nil, nil))
+
+	auth = models.HTTPAuth{BasicAuth: &models.HTTPBasicAuth{Username: "chainlink", PasswordSecret: "missing"}}
+	assert.Error(t, auth.Apply(request, nil, fakeSecrets{}))
+}
+
+func TestHTTPAuth_Identity(t *testing.T) {
+	bearer := models.HTTPAuth{Headers: map[string]string{"Authorization": `Bearer {{secret "api_key"}}`}}
+
+	id1, err := bearer.Identity(fakeSecrets{"api_key": "one"})
+	require.NoError(t, err)
+	id2, err := bearer.Identity(fakeSecrets{"api_key": "one"})
+	require.NoError(t, err)
+	assert.Equal(t, id1, id2)
+	assert.NotContains(t, id1, "one")
+
+	// The same config with another secret value is another identity
+	id3, err := bearer.Identity(fakeSecrets{"api_key": "two"})
+	require.NoError(t, err)
+	assert.NotEqual(t, id1, id3)
+
+	basic := models.HTTPAuth{BasicAuth: &models.HTTPBasicAuth{Username: "alice", PasswordSecret: "password"}}
+	id4, err := basic.Identity(fakeSecrets{"password": "one"})
+	require.NoError(t, err)
+	assert.NotEqual(t, id1, id4)
+	basic.BasicAuth.Username = "bob"
+	id5, err := basic.Identity(fakeSecrets{"password": "one"})
+	require.NoError(t, err)
+	assert.NotEqual(t, id4, id5)
+
+	hmacAuth := models.HTTPAuth{HMAC: &models.HTTPHMACAuth{Secret: "hmac_key"}}
+	id6, err := hmacAuth.Identity(fakeSecrets{"hmac_key": "one"})
+	require.NoError(t, err)
+	id7, err := hmacAuth.Identity(fakeSecrets{"hmac_key": "two"})
+	require.NoError(t, err)
+	assert.NotEqual(t, id6, id7)
+
+	_, err = bearer.Identity(fakeSecrets{})
+	assert.Error(t, err)
+	_, err = bearer.Identity(nil)
+	assert.Error(t, err)
+}
@@ -41,6 +41,8 @@ type (
 		P2PBootstrapPeers                      pq.StringArray `json:"p2pBootstrapPeers" toml:"p2pBootstrapPeers" gorm:"column:p2p_bootstrap_peers;type:text[]"`
 		IsBootstrapPeer                        bool           `json:"isBootstrapPeer" toml:"isBootstrapPeer"`
 		EncryptedOCRKeyBundleID                Sha256Hash     `json:"keyBundleID" toml:"keyBundleID"                 gorm:"type:bytea"`
+		NextP2PPeerID                          *PeerID        `json:"nextP2PPeerID,omitempty" toml:"-" gorm:"column:next_p2p_peer_id"`
+		NextEncryptedOCRKeyBundleID            *Sha256Hash    `json:"nextKeyBundleID,omitempty" toml:"-" gorm:"type:bytea"`
 		MonitoringEndpoint                     string         `json:"monitoringEndpoint" toml:"monitoringEndpoint"`
 		TransmitterAddress                     EIP55Address   `json:"transmitterAddress" toml:"transmitterAddress"`
 		ObservationTimeout                     Interval       `json:"observationTimeout" toml:"observationTimeout" gorm:"type:bigint"`
@@ -2,7 +2,9 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -10,6 +12,7 @@ import (
 	"go.uber.org/multierr"
 	"gopkg.in/guregu/null.v3"
 
+	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/utils"
 )
 
@@ -33,6 +36,20 @@ type Key struct {
 	// IsFunding marks the address as being used for rescuing the  node and the pending transactions
 	// Only one key can be IsFunding=true at a time.
 	IsFunding bool
+	// GroupName lets transactions be sent from any key in a named group
+	GroupName null.String
+	// EthBalance is the balance last seen by the BalanceMonitor, if any
+	EthBalance *assets.Eth
+	// EthLowWatermark and EthHighWatermark override ETH_KEY_LOW_WATERMARK_WEI
+	// and ETH_KEY_HIGH_WATERMARK_WEI for this key
+	EthLowWatermark  *assets.Eth
+	EthHighWatermark *assets.Eth
+	// IsRemote marks keys held by a remote signer, which have no encrypted
+	// JSON to write to disk
+	IsRemote bool
+	// HDIndex is the index of the key on the derivation path, if it was
+	// derived from the node's BIP-39 seed
+	HDIndex null.Int
 }
 
 // NewKeyFromFile creates an instance in memory from a key file on disk.
@@ -41,7 +58,12 @@ func NewKeyFromFile(path string) (Key, error) {
 	if err != nil {
 		return Key{}, err
 	}
+	return NewKeyFromJSON(dat)
+}
 
+// NewKeyFromJSON creates an instance in memory from the JSON of an encrypted
+// key.
+func NewKeyFromJSON(dat []byte) (Key, error) {
 	js := gjson.ParseBytes(dat)
 	address, err := NewEIP55Address(common.HexToAddress(js.Get("address").String()).Hex())
 	if err != nil {
@@ -51,6 +73,17 @@ func NewKeyFromFile(path string) (Key, error) {
 	return Key{Address: address, JSON: JSON{Result: js}}, nil
 }
 
+// NewRemoteKey creates an instance in memory for a key held by a remote
+// signer.
+func NewRemoteKey(address common.Address) (Key, error) {
+	eip55, err := NewEIP55Address(address.Hex())
+	if err != nil {
+		return Key{}, err
+	}
+	js := gjson.Parse(fmt.Sprintf(`{"address":"%s"}`, strings.ToLower(address.Hex()[2:])))
+	return Key{Address: eip55, JSON: JSON{Result: js}, IsRemote: true}, nil
+}
+
 // WriteToDisk writes this key to disk at the passed path.
 func (k *Key) WriteToDisk(path string) error {
 	return utils.WriteFileWithMaxPerms(path, []byte(k.JSON.String()), 0600)
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// EncryptedSecret is a named secret (e.g. a data provider API key) that is
+// stored encrypted with the node's password.  Job specs reference secrets by
+// name so that their values never appear in plaintext.
+type EncryptedSecret struct {
+	ID             int64     `json:"-" gorm:"primary_key"`
+	Name           string    `json:"name"`
+	EncryptedValue []byte    `json:"-" gorm:"type:jsonb"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// TableName returns the table name for EncryptedSecret
+func (EncryptedSecret) TableName() string {
+	return "encrypted_secrets"
+}
+
+// GetID returns the jsonapi ID, which is the secret's name
+func (s EncryptedSecret) GetID() string {
+	return s.Name
+}
+
+// SetID is used to set the jsonapi ID
+func (s *EncryptedSecret) SetID(value string) error {
+	s.Name = value
+	return nil
+}
+
+// SecretResolver looks up the plaintext value of a named secret
+type SecretResolver interface {
+	Secret(name string) (string, error)
+}
+
+// type is added to the beginning of the passwords for secrets, so that they
+// can't accidentally be mis-used in the wrong place
+func adulteratedSecretPassword(auth string) string {
+	return "secret" + auth
+}
+
+// NewEncryptedSecret encrypts value with auth
+func NewEncryptedSecret(name, value, auth string, scryptParams utils.ScryptParams) (EncryptedSecret, error) {
+	if name == "" {
+		return EncryptedSecret{}, errors.New("secret name must not be empty")
+	}
+	cryptoJSON, err := keystore.EncryptDataV3([]byte(value), []byte(adulteratedSecretPassword(auth)), scryptParams.N, scryptParams.P)
+	if err != nil {
+		return EncryptedSecret{}, errors.Wrapf(err, "could not encrypt secret %s", name)
+	}
+	marshalledCryptoJSON, err := json.Marshal(&cryptoJSON)
+	if err != nil {
+		return EncryptedSecret{}, errors.Wrapf(err, "could not encode cryptoJSON")
+	}
+	return EncryptedSecret{Name: name, EncryptedValue: marshalledCryptoJSON}, nil
+}
+
+// Decrypt returns the plaintext value of the secret, decrypted via auth
+func (s EncryptedSecret) Decrypt(auth string) (string, error) {
+	var cryptoJSON keystore.CryptoJSON
+	err := json.Unmarshal(s.EncryptedValue, &cryptoJSON)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid JSON for secret %s", s.Name)
+	}
+	value, err := keystore.DecryptDataV3(cryptoJSON, adulteratedSecretPassword(auth))
+	if err != nil {
+		return "", errors.Wrapf(err, "could not decrypt secret %s", s.Name)
+	}
+	return string(value), nil
+}
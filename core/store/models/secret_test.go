@@ -0,0 +1,28 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedSecret_EncryptDecrypt(t *testing.T) {
+	es, err := models.NewEncryptedSecret("api_key", "s3cr3t", "password", utils.FastScryptParams)
+	require.NoError(t, err)
+	assert.Equal(t, "api_key", es.Name)
+	assert.NotContains(t, string(es.EncryptedValue), "s3cr3t")
+
+	value, err := es.Decrypt("password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = es.Decrypt("wrong password")
+	assert.Error(t, err)
+
+	_, err = models.NewEncryptedSecret("", "s3cr3t", "password", utils.FastScryptParams)
+	assert.Error(t, err)
+}
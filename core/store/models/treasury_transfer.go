@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink/core/assets"
+)
+
+// A TreasuryTransfer records that the treasury key automatically sent ETH to
+// a key whose balance had fallen below its low watermark
+type TreasuryTransfer struct {
+	ID          int64
+	EthTxID     *int64
+	FromAddress common.Address
+	ToAddress   common.Address
+	Amount      assets.Eth
+	// ToBalance is the balance of the topped up key when the transfer was made
+	ToBalance assets.Eth
+	CreatedAt time.Time
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/smartcontractkit/chainlink/core/assets"
@@ -97,11 +98,64 @@ func (c *Config) Validate() error {
 	if c.EthGasBumpWei().Cmp(big.NewInt(5000000000)) < 0 {
 		return errors.Errorf("ETH_GAS_BUMP_WEI of %s Wei may not be less than the minimum allowed value of 5 GWei", c.EthGasBumpWei().String())
 	}
+	if c.EthEIP1559DynamicFees() && c.EthGasTipCapDefault().Cmp(c.EthMaxGasPriceWei()) > 0 {
+		return errors.Errorf("ETH_GAS_TIP_CAP_DEFAULT of %s Wei may not be greater than ETH_MAX_GAS_PRICE_WEI of %s Wei", c.EthGasTipCapDefault().String(), c.EthMaxGasPriceWei().String())
+	}
+
+	switch c.GasEstimatorMode() {
+	case "BlockHistory":
+		if c.GasUpdaterMinGasPriceWei().Cmp(c.GasUpdaterMaxGasPriceWei()) > 0 {
+			return errors.Errorf("GAS_UPDATER_MIN_GAS_PRICE_WEI of %s Wei may not be greater than GAS_UPDATER_MAX_GAS_PRICE_WEI of %s Wei", c.GasUpdaterMinGasPriceWei().String(), c.GasUpdaterMaxGasPriceWei().String())
+		}
+	case "Fixed":
+		if c.GasFixedMinGasPriceWei().Cmp(c.GasFixedMaxGasPriceWei()) > 0 {
+			return errors.Errorf("GAS_FIXED_MIN_GAS_PRICE_WEI of %s Wei may not be greater than GAS_FIXED_MAX_GAS_PRICE_WEI of %s Wei", c.GasFixedMinGasPriceWei().String(), c.GasFixedMaxGasPriceWei().String())
+		}
+	case "Oracle":
+		if c.GasOracleBridgeName() == "" {
+			return errors.New("GAS_ORACLE_BRIDGE_NAME must be set if GAS_ESTIMATOR_MODE is Oracle")
+		}
+		if c.GasOracleMinGasPriceWei().Cmp(c.GasOracleMaxGasPriceWei()) > 0 {
+			return errors.Errorf("GAS_ORACLE_MIN_GAS_PRICE_WEI of %s Wei may not be greater than GAS_ORACLE_MAX_GAS_PRICE_WEI of %s Wei", c.GasOracleMinGasPriceWei().String(), c.GasOracleMaxGasPriceWei().String())
+		}
+	default:
+		return errors.Errorf("GAS_ESTIMATOR_MODE of %s is not one of BlockHistory, Fixed or Oracle", c.GasEstimatorMode())
+	}
+
+	if signerURL := c.EthRemoteSignerURL(); signerURL != "" {
+		if u, err := url.Parse(signerURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.Errorf("ETH_REMOTE_SIGNER_URL of %s must be an http(s) URL", signerURL)
+		}
+	}
+
+	if c.EthKeyLowWatermarkWei().Cmp(c.EthKeyHighWatermarkWei()) > 0 {
+		return errors.Errorf("ETH_KEY_LOW_WATERMARK_WEI of %s Wei may not be greater than ETH_KEY_HIGH_WATERMARK_WEI of %s Wei", c.EthKeyLowWatermarkWei().String(), c.EthKeyHighWatermarkWei().String())
+	}
+
+	switch c.EthKeySelectionPolicy() {
+	case "RoundRobin", "LeastPending", "BalanceWeighted":
+	default:
+		return errors.Errorf("ETH_KEY_SELECTION_POLICY of %s is not one of RoundRobin, LeastPending or BalanceWeighted", c.EthKeySelectionPolicy())
+	}
+
+	if c.EthNoncePipelineDepth() == 0 {
+		return errors.New("ETH_NONCE_PIPELINE_DEPTH must be at least 1")
+	}
 
 	if c.EthHeadTrackerHistoryDepth() < c.EthFinalityDepth() {
 		return errors.New("ETH_HEAD_TRACKER_HISTORY_DEPTH must be equal to or greater than ETH_FINALITY_DEPTH")
 	}
 
+	switch c.EthFinalityMode() {
+	case "Depth", "Tag":
+	default:
+		return errors.Errorf("ETH_FINALITY_MODE of %s is not one of Depth or Tag", c.EthFinalityMode())
+	}
+
+	if c.EthSafeDepth() > c.EthFinalityDepth() {
+		return errors.New("ETH_SAFE_DEPTH may not be greater than ETH_FINALITY_DEPTH")
+	}
+
 	if c.P2PAnnouncePort() != 0 && c.P2PAnnounceIP() == nil {
 		return errors.Errorf("OCR_ANNOUNCE_PORT was given as %v but OCR_ANNOUNCE_IP was unset. You must also set OCR_ANNOUNCE_IP if OCR_ANNOUNCE_PORT is set", c.P2PAnnouncePort())
 	}
@@ -164,6 +218,13 @@ func (c Config) BlockBackfillDepth() uint64 {
 	return c.viper.GetUint64(EnvVarName("BlockBackfillDepth"))
 }
 
+// BlockBackfillBatchSize is the largest range of blocks that the log
+// broadcaster will request logs for in a single eth_getLogs call while
+// backfilling. Zero means no limit.
+func (c Config) BlockBackfillBatchSize() uint64 {
+	return c.viper.GetUint64(EnvVarName("BlockBackfillBatchSize"))
+}
+
 // BridgeResponseURL represents the URL for bridges to send a response to.
 func (c Config) BridgeResponseURL() *url.URL {
 	return c.getWithFallback("BridgeResponseURL", parseURL).(*url.URL)
@@ -342,6 +403,61 @@ func (c Config) SetEthGasPriceDefault(value *big.Int) error {
 	return c.runtimeStore.SetConfigValue("EthGasPriceDefault", value)
 }
 
+// EthEIP1559DynamicFees enables EIP-1559 dynamic fee transactions, which pay
+// the block's base fee plus a tip instead of a single gas price.  Only enable
+// this on chains that have activated EIP-1559.
+func (c Config) EthEIP1559DynamicFees() bool {
+	return c.viper.GetBool(EnvVarName("EthEIP1559DynamicFees"))
+}
+
+// EthGasTipCapDefault is the default tip paid to miners per unit of gas by
+// dynamic fee transactions
+func (c Config) EthGasTipCapDefault() *big.Int {
+	if c.runtimeStore != nil {
+		var value big.Int
+		if err := c.runtimeStore.GetConfigValue("EthGasTipCapDefault", &value); err != nil && errors.Cause(err) != ErrorNotFound {
+			logger.Warnw("Error while trying to fetch EthGasTipCapDefault.", "error", err)
+		} else if err == nil {
+			return &value
+		}
+	}
+	return c.getWithFallback("EthGasTipCapDefault", parseBigInt).(*big.Int)
+}
+
+// SetEthGasTipCapDefault saves a runtime value for the default tip cap for
+// dynamic fee transactions
+func (c Config) SetEthGasTipCapDefault(value *big.Int) error {
+	if c.runtimeStore == nil {
+		return errors.New("No runtime store installed")
+	}
+	return c.runtimeStore.SetConfigValue("EthGasTipCapDefault", value)
+}
+
+// EthGasFeeCapDefault is the default for the most that dynamic fee
+// transactions pay per unit of gas, including the base fee.  It is estimated
+// by the gas updater from recent base fees.  Until then the default gas price
+// is used.
+func (c Config) EthGasFeeCapDefault() *big.Int {
+	if c.runtimeStore != nil {
+		var value big.Int
+		if err := c.runtimeStore.GetConfigValue("EthGasFeeCapDefault", &value); err != nil && errors.Cause(err) != ErrorNotFound {
+			logger.Warnw("Error while trying to fetch EthGasFeeCapDefault.", "error", err)
+		} else if err == nil {
+			return &value
+		}
+	}
+	return c.EthGasPriceDefault()
+}
+
+// SetEthGasFeeCapDefault saves a runtime value for the default fee cap for
+// dynamic fee transactions
+func (c Config) SetEthGasFeeCapDefault(value *big.Int) error {
+	if c.runtimeStore == nil {
+		return errors.New("No runtime store installed")
+	}
+	return c.runtimeStore.SetConfigValue("EthGasFeeCapDefault", value)
+}
+
 // EthFinalityDepth is the number of blocks after which an ethereum transaction is considered "final"
 // BlocksConsideredFinal determines how deeply we look back to ensure that transactions are confirmed onto the longest chain
 // There is not a large performance penalty to setting this relatively high (on the order of hundreds)
@@ -352,6 +468,21 @@ func (c Config) EthFinalityDepth() uint {
 	return c.viper.GetUint(EnvVarName("EthFinalityDepth"))
 }
 
+// EthFinalityMode chooses how the HeadTracker decides which blocks are final.
+// Depth considers blocks EthFinalityDepth below the latest head final, and
+// EthSafeDepth below it safe. Tag asks the ethereum node for its "finalized"
+// and "safe" blocks, which requires a node that supports those block tags.
+func (c Config) EthFinalityMode() string {
+	return c.viper.GetString(EnvVarName("EthFinalityMode"))
+}
+
+// EthSafeDepth is the number of blocks below the latest head at which a block
+// is considered "safe", i.e. unlikely but not impossible to be reorged out,
+// when EthFinalityMode is Depth. It may not be greater than EthFinalityDepth.
+func (c Config) EthSafeDepth() uint {
+	return c.viper.GetUint(EnvVarName("EthSafeDepth"))
+}
+
 // EthHeadTrackerHistoryDepth is the number of heads to keep in the `heads` database table.
 // This number should be at least as large as `EthFinalityDepth`.
 // There may be a small performance penalty to setting this to something very large (10,000+)
@@ -367,6 +498,47 @@ func (c Config) EthHeadTrackerMaxBufferSize() uint {
 	return c.viper.GetUint(EnvVarName("EthHeadTrackerMaxBufferSize"))
 }
 
+// EthKeyHighWatermarkWei is the balance that the treasury key tops keys up to
+// once they fall below EthKeyLowWatermarkWei
+func (c Config) EthKeyHighWatermarkWei() *big.Int {
+	return c.getWithFallback("EthKeyHighWatermarkWei", parseBigInt).(*big.Int)
+}
+
+// EthKeyLowWatermarkWei is the balance below which a key is topped up from
+// the treasury key. Keys are never topped up while it is zero, unless they
+// have their own watermarks.
+func (c Config) EthKeyLowWatermarkWei() *big.Int {
+	return c.getWithFallback("EthKeyLowWatermarkWei", parseBigInt).(*big.Int)
+}
+
+// EthKeySelectionPolicy decides which key sends a transaction when more than
+// one may be used. RoundRobin (the default) picks the least recently used key,
+// LeastPending picks the key with the fewest transactions waiting to be
+// confirmed, and BalanceWeighted picks at random, in proportion to each key's
+// ETH balance. Keys known to have no ETH are never picked.
+func (c Config) EthKeySelectionPolicy() string {
+	return c.viper.GetString(EnvVarName("EthKeySelectionPolicy"))
+}
+
+// EthTreasuryAddress is the key that tops up other keys when their balances
+// fall below their low watermarks. Keys are never topped up if it is unset.
+func (c Config) EthTreasuryAddress() common.Address {
+	if c.viper.GetString(EnvVarName("EthTreasuryAddress")) == "" {
+		return common.Address{}
+	}
+	address, ok := c.getWithFallback("EthTreasuryAddress", parseAddress).(*common.Address)
+	if !ok {
+		return common.Address{}
+	}
+	return *address
+}
+
+// EthTreasuryDailyLimitWei is the most ETH the treasury key may send to top
+// up other keys in any 24 hour period
+func (c Config) EthTreasuryDailyLimitWei() *big.Int {
+	return c.getWithFallback("EthTreasuryDailyLimitWei", parseBigInt).(*big.Int)
+}
+
 // EthereumURL represents the URL of the Ethereum node to connect Chainlink to.
 func (c Config) EthereumURL() string {
 	return c.viper.GetString(EnvVarName("EthereumURL"))
@@ -379,6 +551,78 @@ func (c Config) EthereumSecondaryURL() string {
 	return c.viper.GetString(EnvVarName("EthereumSecondaryURL"))
 }
 
+// EthereumPrimaryURLs returns the websocket or http(s) URLs of all of the
+// primary Ethereum nodes, starting with EthereumURL followed by the comma
+// separated ETH_PRIMARY_URLS.  Subscriptions and reads are served by one
+// healthy primary node at a time.
+func (c Config) EthereumPrimaryURLs() []string {
+	return joinURLs(c.EthereumURL(), c.viper.GetString(EnvVarName("EthereumPrimaryURLs")))
+}
+
+// EthereumSecondaryURLs returns the http(s) URLs of all of the send-only
+// Ethereum nodes, starting with EthereumSecondaryURL followed by the comma
+// separated ETH_SECONDARY_URLS.  Transactions are also broadcast to these
+// nodes.
+func (c Config) EthereumSecondaryURLs() []string {
+	return joinURLs(c.EthereumSecondaryURL(), c.viper.GetString(EnvVarName("EthereumSecondaryURLs")))
+}
+
+func joinURLs(url string, commaSeparatedURLs string) []string {
+	var urls []string
+	seen := make(map[string]struct{})
+	for _, u := range append([]string{url}, strings.Split(commaSeparatedURLs, ",")...) {
+		u = strings.TrimSpace(u)
+		if _, exists := seen[u]; u == "" || exists {
+			continue
+		}
+		seen[u] = struct{}{}
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+// EthNodeHealthCheckInterval is how often each Ethereum node's liveness is
+// checked
+func (c Config) EthNodeHealthCheckInterval() time.Duration {
+	return c.viper.GetDuration(EnvVarName("EthNodeHealthCheckInterval"))
+}
+
+// EthNodeMaxHeadAge is the maximum age of a primary Ethereum node's latest
+// block before the node is considered out of sync. Zero disables the check.
+func (c Config) EthNodeMaxHeadAge() time.Duration {
+	return c.viper.GetDuration(EnvVarName("EthNodeMaxHeadAge"))
+}
+
+// EthNodePollingInterval is how often a primary Ethereum node that is
+// reached over http(s) is polled for new heads and logs, since it cannot
+// serve websocket subscriptions.
+func (c Config) EthNodePollingInterval() time.Duration {
+	return c.viper.GetDuration(EnvVarName("EthNodePollingInterval"))
+}
+
+// EthNoncePipelineDepth is the number of transactions from the same key that
+// the BulletproofTxManager assigns nonces to and broadcasts together, in a
+// single batch request. With the default of 1, each transaction is sent only
+// once the one before it has been accepted by the eth node.
+func (c Config) EthNoncePipelineDepth() uint {
+	return c.viper.GetUint(EnvVarName("EthNoncePipelineDepth"))
+}
+
+// EthRPCBatchSize is the maximum number of requests that are sent to the
+// Ethereum node in a single JSON-RPC batch, e.g. when fetching receipts or
+// backfilling heads
+func (c Config) EthRPCBatchSize() uint32 {
+	return c.viper.GetUint32(EnvVarName("EthRPCBatchSize"))
+}
+
+// EthRemoteSignerURL is the HTTP JSON-RPC URL of a signing service, such as
+// Clef or EthSigner, that holds the node's Ethereum keys. If set, keys are
+// listed from and transactions are signed by the service, and the keystore on
+// disk is not used.
+func (c Config) EthRemoteSignerURL() string {
+	return c.viper.GetString(EnvVarName("EthRemoteSignerURL"))
+}
+
 // EthereumDisabled shows whether Ethereum interactions are supported.
 func (c Config) EthereumDisabled() bool {
 	return c.viper.GetBool(EnvVarName("EthereumDisabled"))
@@ -389,6 +633,44 @@ func (c Config) FlagsContractAddress() string {
 	return c.viper.GetString(EnvVarName("FlagsContractAddress"))
 }
 
+// GasEstimatorMode selects how the BulletproofTxManager prices new
+// transactions. BlockHistory (the default) uses a percentile of the gas prices
+// paid in recent blocks, Fixed always uses ETH_GAS_PRICE_DEFAULT, and Oracle
+// asks the bridge named by GAS_ORACLE_BRIDGE_NAME on every head.
+func (c Config) GasEstimatorMode() string {
+	return c.viper.GetString(EnvVarName("GasEstimatorMode"))
+}
+
+// GasFixedMaxGasPriceWei is the highest gas price in Wei that the fixed
+// estimator uses, even if ETH_GAS_PRICE_DEFAULT is set higher
+func (c Config) GasFixedMaxGasPriceWei() *big.Int {
+	return c.getWithFallback("GasFixedMaxGasPriceWei", parseBigInt).(*big.Int)
+}
+
+// GasFixedMinGasPriceWei is the lowest gas price in Wei that the fixed
+// estimator uses, even if ETH_GAS_PRICE_DEFAULT is set lower
+func (c Config) GasFixedMinGasPriceWei() *big.Int {
+	return c.getWithFallback("GasFixedMinGasPriceWei", parseBigInt).(*big.Int)
+}
+
+// GasOracleBridgeName is the name of the bridge that is asked for the gas
+// price when GAS_ESTIMATOR_MODE is Oracle
+func (c Config) GasOracleBridgeName() string {
+	return c.viper.GetString(EnvVarName("GasOracleBridgeName"))
+}
+
+// GasOracleMaxGasPriceWei is the highest gas price in Wei that will be taken
+// from the gas oracle. Higher answers are reduced to this.
+func (c Config) GasOracleMaxGasPriceWei() *big.Int {
+	return c.getWithFallback("GasOracleMaxGasPriceWei", parseBigInt).(*big.Int)
+}
+
+// GasOracleMinGasPriceWei is the lowest gas price in Wei that will be taken
+// from the gas oracle. Lower answers are raised to this.
+func (c Config) GasOracleMinGasPriceWei() *big.Int {
+	return c.getWithFallback("GasOracleMinGasPriceWei", parseBigInt).(*big.Int)
+}
+
 // GasUpdaterBlockDelay is the number of blocks that the gas updater trails behind head.
 // E.g. if this is set to 3, and we receive block 10, gas updater will
 // fetch block 7.
@@ -406,6 +688,18 @@ func (c Config) GasUpdaterBlockHistorySize() uint16 {
 	return c.getWithFallback("GasUpdaterBlockHistorySize", parseUint16).(uint16)
 }
 
+// GasUpdaterMaxGasPriceWei is the highest gas price in Wei that the block
+// history estimator uses. Higher percentiles are reduced to this.
+func (c Config) GasUpdaterMaxGasPriceWei() *big.Int {
+	return c.getWithFallback("GasUpdaterMaxGasPriceWei", parseBigInt).(*big.Int)
+}
+
+// GasUpdaterMinGasPriceWei is the lowest gas price in Wei that the block
+// history estimator uses. Lower percentiles are raised to this.
+func (c Config) GasUpdaterMinGasPriceWei() *big.Int {
+	return c.getWithFallback("GasUpdaterMinGasPriceWei", parseBigInt).(*big.Int)
+}
+
 // GasUpdaterTransactionPercentile is the percentile gas price to choose. E.g.
 // if the past transaction history contains four transactions with gas prices:
 // [100, 200, 300, 400], picking 25 for this number will give a value of 200
@@ -425,6 +719,13 @@ func (c Config) InsecureFastScrypt() bool {
 	return c.viper.GetBool(EnvVarName("InsecureFastScrypt"))
 }
 
+// JobPipelineBridgeAsyncTimeout is the default amount of time that an async
+// bridge task will wait for the external adapter to call back before it is
+// marked as errored
+func (c Config) JobPipelineBridgeAsyncTimeout() time.Duration {
+	return c.viper.GetDuration(EnvVarName("JobPipelineBridgeAsyncTimeout"))
+}
+
 func (c Config) JobPipelineDBPollInterval() time.Duration {
 	return c.viper.GetDuration(EnvVarName("JobPipelineDBPollInterval"))
 }
@@ -529,6 +830,19 @@ func (c Config) OperatorContractAddress() common.Address {
 	return *address
 }
 
+// OutboundRequestsMaxInFlight is the maximum number of concurrent outbound
+// data source requests (HTTP tasks, bridge tasks and flux monitor price
+// fetches) to any one host or bridge. Zero means unlimited.
+func (c Config) OutboundRequestsMaxInFlight() int {
+	return c.viper.GetInt(EnvVarName("OutboundRequestsMaxInFlight"))
+}
+
+// OutboundRequestsPerSecond is the maximum rate of outbound data source
+// requests to any one host or bridge. Zero means unlimited.
+func (c Config) OutboundRequestsPerSecond() float64 {
+	return c.viper.GetFloat64(EnvVarName("OutboundRequestsPerSecond"))
+}
+
 // LogLevel represents the maximum level of log messages to output.
 func (c Config) LogLevel() LogLevel {
 	return c.getWithFallback("LogLevel", parseLogLevel).(LogLevel)
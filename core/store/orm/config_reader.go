@@ -17,6 +17,7 @@ import (
 type ConfigReader interface {
 	AllowOrigins() string
 	BlockBackfillDepth() uint64
+	BlockBackfillBatchSize() uint64
 	BridgeResponseURL() *url.URL
 	ChainID() *big.Int
 	ClientNodeURL() string
@@ -44,13 +45,36 @@ type ConfigReader interface {
 	EthGasPriceDefault() *big.Int
 	EthMaxGasPriceWei() *big.Int
 	EthFinalityDepth() uint
+	EthFinalityMode() string
+	EthSafeDepth() uint
+	EthRPCBatchSize() uint32
+	EthRemoteSignerURL() string
 	EthHeadTrackerHistoryDepth() uint
 	EthHeadTrackerMaxBufferSize() uint
+	EthKeyHighWatermarkWei() *big.Int
+	EthKeyLowWatermarkWei() *big.Int
+	EthKeySelectionPolicy() string
+	EthNoncePipelineDepth() uint
+	EthTreasuryAddress() common.Address
+	EthTreasuryDailyLimitWei() *big.Int
 	SetEthGasPriceDefault(value *big.Int) error
+	EthEIP1559DynamicFees() bool
+	EthGasTipCapDefault() *big.Int
+	EthGasFeeCapDefault() *big.Int
+	SetEthGasTipCapDefault(value *big.Int) error
+	SetEthGasFeeCapDefault(value *big.Int) error
 	EthereumURL() string
 	EthereumSecondaryURL() string
+	GasEstimatorMode() string
+	GasFixedMaxGasPriceWei() *big.Int
+	GasFixedMinGasPriceWei() *big.Int
+	GasOracleBridgeName() string
+	GasOracleMaxGasPriceWei() *big.Int
+	GasOracleMinGasPriceWei() *big.Int
 	GasUpdaterBlockDelay() uint16
 	GasUpdaterBlockHistorySize() uint16
+	GasUpdaterMaxGasPriceWei() *big.Int
+	GasUpdaterMinGasPriceWei() *big.Int
 	GasUpdaterTransactionPercentile() uint16
 	JSONConsole() bool
 	LinkContractAddress() string
@@ -618,7 +618,7 @@ func (orm *ORM) AnyJobWithType(taskTypeName string) (bool, error) {
 
 // IdempotentInsertEthTaskRunTx creates both eth_task_run_transaction and eth_tx in one hit
 // It can be called multiple times without error as long as the outcome would have resulted in the same database state
-func (orm *ORM) IdempotentInsertEthTaskRunTx(taskRunID models.ID, fromAddress common.Address, toAddress common.Address, encodedPayload []byte, gasLimit uint64) error {
+func (orm *ORM) IdempotentInsertEthTaskRunTx(taskRunID models.ID, fromAddress common.Address, toAddress common.Address, encodedPayload []byte, gasLimit uint64, strategy models.EthTxStrategy) error {
 	etx := models.EthTx{
 		FromAddress:    fromAddress,
 		ToAddress:      toAddress,
@@ -626,6 +626,7 @@ func (orm *ORM) IdempotentInsertEthTaskRunTx(taskRunID models.ID, fromAddress co
 		Value:          assets.NewEthValue(0),
 		GasLimit:       gasLimit,
 		State:          models.EthTxUnstarted,
+		EthTxStrategy:  strategy,
 	}
 	ethTaskRunTransaction := models.EthTaskRunTx{
 		TaskRunID: taskRunID.UUID(),
@@ -1290,6 +1291,22 @@ func (orm *ORM) Chain(hash common.Hash, lookback uint) (models.Head, error) {
 	return *firstHead, nil
 }
 
+// LastHeads returns the heads of the top n block numbers, as kept by
+// TrimOldHeads
+func (orm *ORM) LastHeads(n uint) ([]models.Head, error) {
+	var heads []models.Head
+	err := orm.DB.Raw(`
+	SELECT * FROM heads WHERE number >= (
+		SELECT min(number) FROM (
+			SELECT number
+			FROM heads
+			ORDER BY number DESC
+			LIMIT ?
+		) numbers
+	) ORDER BY number DESC`, n).Scan(&heads).Error
+	return heads, err
+}
+
 // HeadByHash fetches the head with the given hash from the db, returns nil if none exists
 func (orm *ORM) HeadByHash(hash common.Hash) (*models.Head, error) {
 	head := &models.Head{}
@@ -1300,6 +1317,16 @@ func (orm *ORM) HeadByHash(hash common.Hash) (*models.Head, error) {
 	return head, err
 }
 
+// HeadNumbers returns the distinct numbers of the heads in the db between
+// from and to inclusive
+func (orm *ORM) HeadNumbers(from, to int64) ([]int64, error) {
+	var numbers []int64
+	err := orm.DB.Model(&models.Head{}).
+		Where("number BETWEEN ? AND ?", from, to).
+		Pluck("DISTINCT number", &numbers).Error
+	return numbers, err
+}
+
 // LastHead returns the head with the highest number. In the case of ties (e.g.
 // due to re-org) it returns the most recently seen head entry.
 func (orm *ORM) LastHead() (*models.Head, error) {
@@ -1464,6 +1491,156 @@ func (orm *ORM) GetRoundRobinAddress(addresses ...common.Address) (address commo
 	return address, nil
 }
 
+// PickKeyAddress returns the address of a key to send a transaction from,
+// using the given ETH_KEY_SELECTION_POLICY. Only keys in the given group, or
+// in the given addresses, are considered if either is set. When choosing from
+// the whole pool or a group, keys known to have no ETH are skipped; explicitly
+// requested addresses are always eligible.
+func (orm *ORM) PickKeyAddress(policy string, groupName string, addresses ...common.Address) (address common.Address, err error) {
+	var order string
+	switch policy {
+	case "RoundRobin":
+		order = "last_used ASC NULLS FIRST, id ASC"
+	case "LeastPending":
+		order = `(SELECT COUNT(*) FROM eth_txes WHERE eth_txes.from_address = keys.address AND eth_txes.state IN ('unstarted', 'in_progress', 'unconfirmed')) ASC, last_used ASC NULLS FIRST, id ASC`
+	case "BalanceWeighted":
+		// Weighted random sampling: each key's chance of sorting first is
+		// proportional to its balance. Keys with no known balance, or none at
+		// all, come last.
+		order = "-LN(1 - RANDOM()) / NULLIF(eth_balance, 0)::float8 ASC NULLS LAST, last_used ASC NULLS FIRST, id ASC"
+	default:
+		return address, errors.Errorf("unknown key selection policy %s", policy)
+	}
+
+	err = orm.Transaction(func(tx *gorm.DB) error {
+		q := tx.Set("gorm:query_option", "FOR UPDATE").Order(order)
+		q = q.Where("is_funding = FALSE")
+		if groupName != "" {
+			q = q.Where("group_name = ?", groupName)
+		}
+		if len(addresses) > 0 {
+			q = q.Where("address in (?)", addresses)
+		} else {
+			q = q.Where("eth_balance IS NULL OR eth_balance > 0")
+		}
+		var key models.Key
+		err = q.First(&key).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return errors.New("no keys available")
+		} else if err != nil {
+			return err
+		}
+		address = key.Address.Address()
+		return tx.Model(&key).Update("last_used", time.Now()).Error
+	})
+	return address, err
+}
+
+// SetKeyGroup puts the key with the given address into the named group, or
+// removes it from its group if groupName is empty
+func (orm *ORM) SetKeyGroup(address common.Address, groupName string) error {
+	res := orm.DB.Exec(`UPDATE keys SET group_name = NULLIF(?, '') WHERE address = ? AND deleted_at IS NULL`, groupName, address)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// UpdateKeyEthBalance records the latest known balance of the key with the
+// given address
+func (orm *ORM) UpdateKeyEthBalance(address common.Address, balance assets.Eth) error {
+	return orm.DB.Exec(`UPDATE keys SET eth_balance = ? WHERE address = ?`, balance, address).Error
+}
+
+// SetKeyWatermarks sets the balances below which, and up to which, the key
+// with the given address is topped up from the treasury key. Nil watermarks
+// fall back to the node's configuration.
+func (orm *ORM) SetKeyWatermarks(address common.Address, low, high *assets.Eth) error {
+	res := orm.DB.Exec(`UPDATE keys SET eth_low_watermark = ?, eth_high_watermark = ? WHERE address = ? AND deleted_at IS NULL`, low, high, address)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrorNotFound
+	}
+	return nil
+}
+
+// CreateEncryptedHDSeed saves the seed that Ethereum keys are derived from.
+// A node has at most one seed.
+func (orm *ORM) CreateEncryptedHDSeed(seed *models.EncryptedHDSeed) error {
+	return orm.DB.Create(seed).Error
+}
+
+// FindEncryptedHDSeed returns the seed that Ethereum keys are derived from,
+// or nil if the node has none
+func (orm *ORM) FindEncryptedHDSeed() (*models.EncryptedHDSeed, error) {
+	var seed models.EncryptedHDSeed
+	err := orm.DB.First(&seed).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	return &seed, err
+}
+
+// NextHDIndex returns the index on the derivation path of the next Ethereum
+// key to derive from the node's seed
+func (orm *ORM) NextHDIndex() (uint32, error) {
+	var next struct{ Index uint32 }
+	err := orm.DB.Raw(`SELECT COALESCE(MAX(hd_index) + 1, 0) AS index FROM keys`).Scan(&next).Error
+	return next.Index, err
+}
+
+// SetKeyHDIndex records the index on the derivation path of a key derived
+// from the node's seed
+func (orm *ORM) SetKeyHDIndex(address models.EIP55Address, index uint32) error {
+	return orm.DB.Model(&models.Key{}).Where("address = ?", address).Update("hd_index", index).Error
+}
+
+// CreateTreasuryTransfer saves the transaction of a top up sent by the
+// treasury key along with its record, so that no top up is ever sent without
+// counting towards the daily limit
+func (orm *ORM) CreateTreasuryTransfer(etx *models.EthTx, transfer *models.TreasuryTransfer) error {
+	return orm.Transaction(func(dbtx *gorm.DB) error {
+		if err := dbtx.Create(etx).Error; err != nil {
+			return errors.Wrap(err, "failed to insert eth_tx")
+		}
+		transfer.EthTxID = &etx.ID
+		return errors.Wrap(dbtx.Create(transfer).Error, "failed to insert treasury_transfer")
+	})
+}
+
+// TreasurySpentSince returns the total ETH sent by the treasury key to top up
+// other keys since the given time
+func (orm *ORM) TreasurySpentSince(since time.Time) (*assets.Eth, error) {
+	var spent struct{ Sum assets.Eth }
+	err := orm.DB.Raw(`SELECT COALESCE(SUM(amount), 0) AS sum FROM treasury_transfers WHERE created_at >= ?`, since).Scan(&spent).Error
+	return &spent.Sum, err
+}
+
+// HasPendingTreasuryTransfer reports whether a top up sent to the given
+// address has not been confirmed yet
+func (orm *ORM) HasPendingTreasuryTransfer(address common.Address) (bool, error) {
+	var count int
+	err := orm.DB.Table("treasury_transfers").
+		Joins("INNER JOIN eth_txes ON eth_txes.id = treasury_transfers.eth_tx_id").
+		Where("treasury_transfers.to_address = ? AND eth_txes.state IN ('unstarted', 'in_progress', 'unconfirmed')", address).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountPendingEthTxs returns the number of transactions from the given address
+// that have not been confirmed yet
+func (orm *ORM) CountPendingEthTxs(address common.Address) (count int, err error) {
+	err = orm.DB.Model(&models.EthTx{}).
+		Where("from_address = ? AND state IN ('unstarted', 'in_progress', 'unconfirmed')", address).
+		Count(&count).Error
+	return count, err
+}
+
 // HasConsumedLog reports whether the given consumer had already consumed the given log
 func (orm *ORM) HasConsumedLog(blockHash common.Hash, logIndex uint, jobID *models.ID) (bool, error) {
 	query := "SELECT exists (" +
@@ -1516,6 +1693,26 @@ func (orm *ORM) MarkLogConsumedV2(blockHash common.Hash, logIndex uint, jobID in
 	return orm.DB.Create(&lc).Error
 }
 
+// FindLogCursors returns the log cursors with the given names.  Names without
+// a cursor are omitted.
+func (orm *ORM) FindLogCursors(names []string) ([]models.LogCursor, error) {
+	var cursors []models.LogCursor
+	err := orm.DB.Where("name IN (?)", names).Find(&cursors).Error
+	return cursors, err
+}
+
+// AdvanceLogCursors moves the named log cursors forward to blockIndex,
+// creating them if necessary.  Cursors never move backwards.
+func (orm *ORM) AdvanceLogCursors(names []string, blockIndex int64) error {
+	orm.MustEnsureAdvisoryLock()
+	return orm.DB.Exec(`
+        INSERT INTO log_cursors (name, initialized, block_index, log_index)
+        SELECT unnest(?::text[]), true, ?, 0
+        ON CONFLICT (name) DO UPDATE
+        SET block_index = GREATEST(log_cursors.block_index, EXCLUDED.block_index)
+    `, pq.Array(names), blockIndex).Error
+}
+
 // FindOrCreateFluxMonitorRoundStats find the round stats record for a given oracle on a given round, or creates
 // it if no record exists
 func (orm *ORM) FindOrCreateFluxMonitorRoundStats(aggregator common.Address, roundID uint32) (models.FluxMonitorRoundStats, error) {
@@ -1582,6 +1779,9 @@ func (orm *ORM) ClobberDiskKeyStoreWithDBKeys(keysDir string) error {
 
 	var merr error
 	for _, k := range keys {
+		if k.IsRemote {
+			continue
+		}
 		merr = multierr.Append(
 			k.WriteToDisk(filepath.Join(keysDir, keyFileName(k.Address, k.CreatedAt))),
 			merr)
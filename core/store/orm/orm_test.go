@@ -15,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/internal/mocks"
 	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/services/bulletprooftxmanager"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
@@ -1801,7 +1802,7 @@ func TestORM_EthTaskRunTx(t *testing.T) {
 		encodedPayload := []byte{0, 1, 2}
 		gasLimit := uint64(42)
 
-		err := store.IdempotentInsertEthTaskRunTx(sharedTaskRunID, fromAddress, toAddress, encodedPayload, gasLimit)
+		err := store.IdempotentInsertEthTaskRunTx(sharedTaskRunID, fromAddress, toAddress, encodedPayload, gasLimit, models.EthTxStrategy{})
 		require.NoError(t, err)
 
 		etrt, err := store.FindEthTaskRunTxByTaskRunID(sharedTaskRunID.UUID())
@@ -1817,7 +1818,7 @@ func TestORM_EthTaskRunTx(t *testing.T) {
 		assert.Equal(t, models.EthTxUnstarted, etrt.EthTx.State)
 
 		// Do it again to test idempotence
-		err = store.IdempotentInsertEthTaskRunTx(sharedTaskRunID, fromAddress, toAddress, encodedPayload, gasLimit)
+		err = store.IdempotentInsertEthTaskRunTx(sharedTaskRunID, fromAddress, toAddress, encodedPayload, gasLimit, models.EthTxStrategy{})
 		require.NoError(t, err)
 
 		// Ensure it didn't leave a stray EthTx hanging around
@@ -1834,7 +1835,7 @@ func TestORM_EthTaskRunTx(t *testing.T) {
 		encodedPayload := []byte{3, 2, 1}
 		gasLimit := uint64(24)
 
-		err := store.IdempotentInsertEthTaskRunTx(sharedTaskRunID, fromAddress, toAddress, encodedPayload, gasLimit)
+		err := store.IdempotentInsertEthTaskRunTx(sharedTaskRunID, fromAddress, toAddress, encodedPayload, gasLimit, models.EthTxStrategy{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "transaction already exists for task run ID")
 	})
@@ -1846,13 +1847,13 @@ func TestORM_EthTaskRunTx(t *testing.T) {
 		firstGasLimit := uint64(42)
 
 		// First insert
-		err := store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, firstGasLimit)
+		err := store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, firstGasLimit, models.EthTxStrategy{})
 		require.NoError(t, err)
 
 		secondGasLimit := uint64(99)
 
 		// Second insert
-		err = store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, secondGasLimit)
+		err = store.IdempotentInsertEthTaskRunTx(taskRunID, fromAddress, toAddress, encodedPayload, secondGasLimit, models.EthTxStrategy{})
 		require.NoError(t, err)
 
 		etrt, err := store.FindEthTaskRunTxByTaskRunID(taskRunID.UUID())
@@ -2154,6 +2155,138 @@ func TestORM_GetRoundRobinAddress(t *testing.T) {
 	})
 }
 
+func TestORM_PickKeyAddress(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	fundingKey := models.Key{Address: models.EIP55Address(cltest.NewAddress().Hex()), JSON: cltest.JSONFromString(t, `{"key": 2}`), IsFunding: true}
+	k0Address := common.HexToAddress(cltest.DefaultKey)
+	k1 := models.Key{Address: models.EIP55Address(cltest.NewAddress().Hex()), JSON: cltest.JSONFromString(t, `{"key": 1}`)}
+	k2 := models.Key{Address: models.EIP55Address(cltest.NewAddress().Hex()), JSON: cltest.JSONFromString(t, `{"key": 2}`)}
+
+	require.NoError(t, store.CreateKeyIfNotExists(fundingKey))
+	require.NoError(t, store.CreateKeyIfNotExists(k1))
+	require.NoError(t, store.CreateKeyIfNotExists(k2))
+
+	t.Run("with unknown policy", func(t *testing.T) {
+		_, err := store.PickKeyAddress("Random", "")
+		require.Error(t, err)
+		assert.Equal(t, "unknown key selection policy Random", err.Error())
+	})
+
+	t.Run("with group filter, only picks keys in the group", func(t *testing.T) {
+		require.NoError(t, store.SetKeyGroup(k1.Address.Address(), "oracle"))
+		require.NoError(t, store.SetKeyGroup(k2.Address.Address(), "oracle"))
+
+		address, err := store.PickKeyAddress("RoundRobin", "oracle")
+		require.NoError(t, err)
+		assert.Equal(t, k1.Address.Hex(), address.Hex())
+
+		address, err = store.PickKeyAddress("RoundRobin", "oracle")
+		require.NoError(t, err)
+		assert.Equal(t, k2.Address.Hex(), address.Hex())
+
+		_, err = store.PickKeyAddress("RoundRobin", "keeper")
+		require.Error(t, err)
+		assert.Equal(t, "no keys available", err.Error())
+
+		require.NoError(t, store.SetKeyGroup(k1.Address.Address(), ""))
+		require.NoError(t, store.SetKeyGroup(k2.Address.Address(), ""))
+	})
+
+	t.Run("skips keys with no ETH", func(t *testing.T) {
+		require.NoError(t, store.UpdateKeyEthBalance(k0Address, *assets.NewEth(0)))
+		require.NoError(t, store.UpdateKeyEthBalance(k1.Address.Address(), *assets.NewEth(0)))
+		require.NoError(t, store.UpdateKeyEthBalance(k2.Address.Address(), *assets.NewEth(1)))
+
+		for i := 0; i < 3; i++ {
+			address, err := store.PickKeyAddress("RoundRobin", "")
+			require.NoError(t, err)
+			assert.Equal(t, k2.Address.Hex(), address.Hex())
+
+			address, err = store.PickKeyAddress("BalanceWeighted", "")
+			require.NoError(t, err)
+			assert.Equal(t, k2.Address.Hex(), address.Hex())
+		}
+
+		// Explicitly requested keys are picked whatever their balance
+		address, err := store.PickKeyAddress("RoundRobin", "", k1.Address.Address())
+		require.NoError(t, err)
+		assert.Equal(t, k1.Address.Hex(), address.Hex())
+
+		address, err = store.PickKeyAddress("BalanceWeighted", "", k1.Address.Address())
+		require.NoError(t, err)
+		assert.Equal(t, k1.Address.Hex(), address.Hex())
+
+		// Listed keys with ETH are still preferred by BalanceWeighted
+		for i := 0; i < 3; i++ {
+			address, err = store.PickKeyAddress("BalanceWeighted", "", k1.Address.Address(), k2.Address.Address())
+			require.NoError(t, err)
+			assert.Equal(t, k2.Address.Hex(), address.Hex())
+		}
+
+		require.NoError(t, store.UpdateKeyEthBalance(k0Address, *assets.NewEth(1)))
+		require.NoError(t, store.UpdateKeyEthBalance(k1.Address.Address(), *assets.NewEth(1)))
+	})
+
+	t.Run("with LeastPending policy, picks the key with the fewest pending transactions", func(t *testing.T) {
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, k0Address)
+		cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, store, 0, k2.Address.Address())
+		cltest.MustInsertConfirmedEthTxWithAttempt(t, store, 0, 1, k1.Address.Address())
+
+		count, err := store.CountPendingEthTxs(k1.Address.Address())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		for i := 0; i < 3; i++ {
+			address, err := store.PickKeyAddress("LeastPending", "")
+			require.NoError(t, err)
+			assert.Equal(t, k1.Address.Hex(), address.Hex())
+		}
+	})
+}
+
+func TestORM_CreateTreasuryTransfer(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	treasury := cltest.MustInsertRandomKey(t, store).Address.Address()
+	to := cltest.NewAddress()
+
+	newTransfer := func() (models.EthTx, models.TreasuryTransfer) {
+		etx, err := bulletprooftxmanager.NewSendEtherTx(store.Config, treasury, to, *assets.NewEth(100))
+		require.NoError(t, err)
+		return etx, models.TreasuryTransfer{FromAddress: treasury, ToAddress: to, Amount: *assets.NewEth(100), ToBalance: *assets.NewEth(1)}
+	}
+
+	etx, transfer := newTransfer()
+	require.NoError(t, store.CreateTreasuryTransfer(&etx, &transfer))
+	require.NotNil(t, transfer.EthTxID)
+	assert.Equal(t, etx.ID, *transfer.EthTxID)
+
+	// If the transfer cannot be recorded, the transaction is not saved either
+	failedEtx, failedTransfer := newTransfer()
+	failedTransfer.ID = transfer.ID
+	require.Error(t, store.CreateTreasuryTransfer(&failedEtx, &failedTransfer))
+
+	var count int
+	require.NoError(t, store.DB.Model(&models.EthTx{}).Count(&count).Error)
+	assert.Equal(t, 1, count)
+	require.NoError(t, store.DB.Model(&models.TreasuryTransfer{}).Count(&count).Error)
+	assert.Equal(t, 1, count)
+}
+
+func TestORM_SetKeyGroup_NotFound(t *testing.T) {
+	t.Parallel()
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	err := store.SetKeyGroup(cltest.NewAddress(), "oracle")
+	assert.Equal(t, orm.ErrorNotFound, err)
+}
+
 func TestORM_MarkLogConsumed(t *testing.T) {
 	t.Parallel()
 	store, cleanup := cltest.NewStore(t)
@@ -19,6 +19,7 @@ type ConfigSchema struct {
 	AllowOrigins                              string          `env:"ALLOW_ORIGINS" default:"http://localhost:3000,http://localhost:6688"`
 	BalanceMonitorEnabled                     bool            `env:"BALANCE_MONITOR_ENABLED" default:"true"`
 	BlockBackfillDepth                        string          `env:"BLOCK_BACKFILL_DEPTH" default:"10"`
+	BlockBackfillBatchSize                    uint64          `env:"BLOCK_BACKFILL_BATCH_SIZE" default:"1000"`
 	BridgeResponseURL                         url.URL         `env:"BRIDGE_RESPONSE_URL"`
 	ChainID                                   big.Int         `env:"ETH_CHAIN_ID" default:"1"`
 	ClientNodeURL                             string          `env:"CLIENT_NODE_URL" default:"http://localhost:6688"`
@@ -44,20 +45,46 @@ type ConfigSchema struct {
 	EthGasBumpTxDepth                         uint16          `env:"ETH_GAS_BUMP_TX_DEPTH" default:"10"`
 	EthGasLimitDefault                        uint64          `env:"ETH_GAS_LIMIT_DEFAULT" default:"500000"`
 	EthGasPriceDefault                        big.Int         `env:"ETH_GAS_PRICE_DEFAULT" default:"20000000000"`
+	EthGasTipCapDefault                       big.Int         `env:"ETH_GAS_TIP_CAP_DEFAULT" default:"1000000000"`
+	EthEIP1559DynamicFees                     bool            `env:"ETH_EIP1559_DYNAMIC_FEES" default:"false"`
 	EthMaxGasPriceWei                         uint64          `env:"ETH_MAX_GAS_PRICE_WEI" default:"1500000000000"`
 	EthFinalityDepth                          uint            `env:"ETH_FINALITY_DEPTH" default:"50"`
+	EthFinalityMode                           string          `env:"ETH_FINALITY_MODE" default:"Depth"`
+	EthSafeDepth                              uint            `env:"ETH_SAFE_DEPTH" default:"12"`
 	EthHeadTrackerHistoryDepth                uint            `env:"ETH_HEAD_TRACKER_HISTORY_DEPTH" default:"100"`
 	EthHeadTrackerMaxBufferSize               uint            `env:"ETH_HEAD_TRACKER_MAX_BUFFER_SIZE" default:"3"`
+	EthKeyHighWatermarkWei                    big.Int         `env:"ETH_KEY_HIGH_WATERMARK_WEI" default:"0"`
+	EthKeyLowWatermarkWei                     big.Int         `env:"ETH_KEY_LOW_WATERMARK_WEI" default:"0"`
+	EthKeySelectionPolicy                     string          `env:"ETH_KEY_SELECTION_POLICY" default:"RoundRobin"`
+	EthTreasuryAddress                        common.Address  `env:"ETH_TREASURY_ADDRESS"`
+	EthTreasuryDailyLimitWei                  big.Int         `env:"ETH_TREASURY_DAILY_LIMIT_WEI" default:"1000000000000000000"`
 	EthBalanceMonitorBlockDelay               uint16          `env:"ETH_BALANCE_MONITOR_BLOCK_DELAY" default:"1"`
+	EthNodeHealthCheckInterval                time.Duration   `env:"ETH_NODE_HEALTH_CHECK_INTERVAL" default:"15s"`
+	EthNodeMaxHeadAge                         time.Duration   `env:"ETH_NODE_MAX_HEAD_AGE" default:"3m"`
+	EthNodePollingInterval                    time.Duration   `env:"ETH_NODE_POLLING_INTERVAL" default:"5s"`
+	EthNoncePipelineDepth                     uint            `env:"ETH_NONCE_PIPELINE_DEPTH" default:"1"`
+	EthRPCBatchSize                           uint32          `env:"ETH_RPC_BATCH_SIZE" default:"100"`
+	EthRemoteSignerURL                        string          `env:"ETH_REMOTE_SIGNER_URL" default:""`
 	EthereumURL                               string          `env:"ETH_URL" default:"ws://localhost:8546"`
+	EthereumPrimaryURLs                       string          `env:"ETH_PRIMARY_URLS" default:""`
 	EthereumSecondaryURL                      string          `env:"ETH_SECONDARY_URL" default:""`
+	EthereumSecondaryURLs                     string          `env:"ETH_SECONDARY_URLS" default:""`
 	EthereumDisabled                          bool            `env:"ETH_DISABLED" default:"false"`
 	FlagsContractAddress                      string          `env:"FLAGS_CONTRACT_ADDRESS"`
+	GasEstimatorMode                          string          `env:"GAS_ESTIMATOR_MODE" default:"BlockHistory"`
+	GasFixedMaxGasPriceWei                    uint64          `env:"GAS_FIXED_MAX_GAS_PRICE_WEI" default:"1500000000000"`
+	GasFixedMinGasPriceWei                    uint64          `env:"GAS_FIXED_MIN_GAS_PRICE_WEI" default:"0"`
+	GasOracleBridgeName                       string          `env:"GAS_ORACLE_BRIDGE_NAME" default:""`
+	GasOracleMaxGasPriceWei                   uint64          `env:"GAS_ORACLE_MAX_GAS_PRICE_WEI" default:"1500000000000"`
+	GasOracleMinGasPriceWei                   uint64          `env:"GAS_ORACLE_MIN_GAS_PRICE_WEI" default:"1000000000"`
 	GasUpdaterBlockDelay                      uint16          `env:"GAS_UPDATER_BLOCK_DELAY" default:"3"`
 	GasUpdaterBlockHistorySize                uint16          `env:"GAS_UPDATER_BLOCK_HISTORY_SIZE" default:"24"`
+	GasUpdaterMaxGasPriceWei                  uint64          `env:"GAS_UPDATER_MAX_GAS_PRICE_WEI" default:"1500000000000"`
+	GasUpdaterMinGasPriceWei                  uint64          `env:"GAS_UPDATER_MIN_GAS_PRICE_WEI" default:"0"`
 	GasUpdaterTransactionPercentile           uint16          `env:"GAS_UPDATER_TRANSACTION_PERCENTILE" default:"60"`
 	GasUpdaterEnabled                         bool            `env:"GAS_UPDATER_ENABLED" default:"true"`
 	InsecureFastScrypt                        bool            `env:"INSECURE_FAST_SCRYPT" default:"false"`
+	JobPipelineBridgeAsyncTimeout             time.Duration   `env:"JOB_PIPELINE_BRIDGE_ASYNC_TIMEOUT" default:"1h"`
 	JobPipelineDBPollInterval                 time.Duration   `env:"JOB_PIPELINE_DB_POLL_INTERVAL" default:"10s"`
 	JobPipelineMaxTaskDuration                time.Duration   `env:"JOB_PIPELINE_MAX_TASK_DURATION" default:"10m"`
 	JobPipelineParallelism                    uint8           `env:"JOB_PIPELINE_PARALLELISM" default:"4"`
@@ -87,6 +114,8 @@ type ConfigSchema struct {
 	OCRDHTLookupInterval                      int             `env:"OCR_DHT_LOOKUP_INTERVAL" default:"10"`
 	OCRTraceLogging                           bool            `env:"OCR_TRACE_LOGGING" default:"false"`
 	OperatorContractAddress                   common.Address  `env:"OPERATOR_CONTRACT_ADDRESS"`
+	OutboundRequestsMaxInFlight               int             `env:"OUTBOUND_REQUESTS_MAX_IN_FLIGHT" default:"0"`
+	OutboundRequestsPerSecond                 float64         `env:"OUTBOUND_REQUESTS_PER_SECOND" default:"0"`
 	P2PAnnounceIP                             net.IP          `env:"P2P_ANNOUNCE_IP"`
 	P2PAnnouncePort                           uint16          `env:"P2P_ANNOUNCE_PORT"`
 	P2PListenIP                               net.IP          `env:"P2P_LISTEN_IP" default:"0.0.0.0"`
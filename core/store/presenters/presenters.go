@@ -17,9 +17,12 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/auth"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
@@ -111,9 +114,14 @@ type ETHKey struct {
 	NextNonce   *int64       `json:"nextNonce"`
 	LastUsed    *time.Time   `json:"lastUsed"`
 	IsFunding   bool         `json:"isFunding"`
-	CreatedAt   time.Time    `json:"createdAt"`
-	UpdatedAt   time.Time    `json:"updatedAt"`
-	DeletedAt   null.Time    `json:"deletedAt"`
+	Group       string       `json:"group"`
+	// LowWatermark and HighWatermark are nil unless set for this key
+	LowWatermark  *assets.Eth `json:"lowWatermark"`
+	HighWatermark *assets.Eth `json:"highWatermark"`
+	PendingTxs    int         `json:"pendingTxs"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+	DeletedAt     null.Time   `json:"deletedAt"`
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -127,6 +135,20 @@ func (k *ETHKey) SetID(value string) error {
 	return nil
 }
 
+// OCRKeyBundleRotation holds the successor of a rotated OCR key bundle, and
+// the jobs that will switch to it
+type OCRKeyBundleRotation struct {
+	ocrkey.EncryptedKeyBundle
+	Jobs []offchainreporting.KeyRotationJob `json:"jobs"`
+}
+
+// P2PKeyRotation holds the successor of a rotated P2P key, and the jobs that
+// will switch to it
+type P2PKeyRotation struct {
+	p2pkey.EncryptedP2PKey
+	Jobs []offchainreporting.KeyRotationJob `json:"jobs"`
+}
+
 // ConfigPrinter are the non-secret values of the node
 //
 // If you add an entry here, you should update NewConfigPrinter and
@@ -141,6 +163,7 @@ type EnvPrinter struct {
 	AllowOrigins                          string          `json:"allowOrigins"`
 	BalanceMonitorEnabled                 bool            `json:"balanceMonitorEnabled"`
 	BlockBackfillDepth                    uint64          `json:"blockBackfillDepth"`
+	BlockBackfillBatchSize                uint64          `json:"blockBackfillBatchSize"`
 	BridgeResponseURL                     string          `json:"bridgeResponseURL,omitempty"`
 	ChainID                               *big.Int        `json:"ethChainId"`
 	ClientNodeURL                         string          `json:"clientNodeUrl"`
@@ -154,26 +177,52 @@ type EnvPrinter struct {
 	EthBalanceMonitorBlockDelay           uint16          `json:"ethBalanceMonitorBlockDelay"`
 	EthereumDisabled                      bool            `json:"ethereumDisabled"`
 	EthFinalityDepth                      uint            `json:"ethFinalityDepth"`
+	EthFinalityMode                       string          `json:"ethFinalityMode"`
 	EthGasBumpThreshold                   uint64          `json:"ethGasBumpThreshold"`
 	EthGasBumpTxDepth                     uint16          `json:"ethGasBumpTxDepth"`
 	EthGasBumpWei                         *big.Int        `json:"ethGasBumpWei"`
 	EthGasLimitDefault                    uint64          `json:"ethGasLimitDefault"`
 	EthGasPriceDefault                    *big.Int        `json:"ethGasPriceDefault"`
+	EthGasTipCapDefault                   *big.Int        `json:"ethGasTipCapDefault"`
+	EthEIP1559DynamicFees                 bool            `json:"ethEIP1559DynamicFees"`
 	EthHeadTrackerHistoryDepth            uint            `json:"ethHeadTrackerHistoryDepth"`
 	EthHeadTrackerMaxBufferSize           uint            `json:"ethHeadTrackerMaxBufferSize"`
+	EthKeyHighWatermarkWei                *big.Int        `json:"ethKeyHighWatermarkWei"`
+	EthKeyLowWatermarkWei                 *big.Int        `json:"ethKeyLowWatermarkWei"`
+	EthKeySelectionPolicy                 string          `json:"ethKeySelectionPolicy"`
+	EthTreasuryAddress                    common.Address  `json:"ethTreasuryAddress"`
+	EthTreasuryDailyLimitWei              *big.Int        `json:"ethTreasuryDailyLimitWei"`
 	EthMaxGasPriceWei                     *big.Int        `json:"ethMaxGasPriceWei"`
+	EthNodeHealthCheckInterval            time.Duration   `json:"ethNodeHealthCheckInterval"`
+	EthNodeMaxHeadAge                     time.Duration   `json:"ethNodeMaxHeadAge"`
+	EthNodePollingInterval                time.Duration   `json:"ethNodePollingInterval"`
+	EthNoncePipelineDepth                 uint            `json:"ethNoncePipelineDepth"`
+	EthRPCBatchSize                       uint32          `json:"ethRPCBatchSize"`
+	EthRemoteSignerURL                    string          `json:"ethRemoteSignerURL"`
+	EthSafeDepth                          uint            `json:"ethSafeDepth"`
 	EthereumURL                           string          `json:"ethUrl"`
 	EthereumSecondaryURL                  string          `json:"ethSecondaryURL"`
+	EthereumPrimaryURLs                   []string        `json:"ethPrimaryURLs"`
+	EthereumSecondaryURLs                 []string        `json:"ethSecondaryURLs"`
 	ExplorerURL                           string          `json:"explorerUrl"`
 	FeatureExternalInitiators             bool            `json:"featureExternalInitiators"`
 	FeatureFluxMonitor                    bool            `json:"featureFluxMonitor"`
 	FeatureOffchainReporting              bool            `json:"featureOffchainReporting"`
 	FlagsContractAddress                  string          `json:"flagsContractAddress"`
+	GasEstimatorMode                      string          `json:"gasEstimatorMode"`
+	GasFixedMaxGasPriceWei                *big.Int        `json:"gasFixedMaxGasPriceWei"`
+	GasFixedMinGasPriceWei                *big.Int        `json:"gasFixedMinGasPriceWei"`
+	GasOracleBridgeName                   string          `json:"gasOracleBridgeName"`
+	GasOracleMaxGasPriceWei               *big.Int        `json:"gasOracleMaxGasPriceWei"`
+	GasOracleMinGasPriceWei               *big.Int        `json:"gasOracleMinGasPriceWei"`
 	GasUpdaterBlockDelay                  uint16          `json:"gasUpdaterBlockDelay"`
 	GasUpdaterBlockHistorySize            uint16          `json:"gasUpdaterBlockHistorySize"`
+	GasUpdaterMaxGasPriceWei              *big.Int        `json:"gasUpdaterMaxGasPriceWei"`
+	GasUpdaterMinGasPriceWei              *big.Int        `json:"gasUpdaterMinGasPriceWei"`
 	GasUpdaterEnabled                     bool            `json:"gasUpdaterEnabled"`
 	GasUpdaterTransactionPercentile       uint16          `json:"gasUpdaterTransactionPercentile"`
 	InsecureFastScrypt                    bool            `json:"insecureFastScrypt"`
+	JobPipelineBridgeAsyncTimeout         time.Duration   `json:"jobPipelineBridgeAsyncTimeout"`
 	JobPipelineDBPollInterval             time.Duration   `json:"jobPipelineDBPollInterval"`
 	JobPipelineMaxTaskDuration            time.Duration   `json:"jobPipelineMaxTaskDuration"`
 	JobPipelineParallelism                uint8           `json:"jobPipelineParallelism"`
@@ -202,6 +251,8 @@ type EnvPrinter struct {
 	OCRDHTLookupInterval                  int             `json:"ocrDHTLookupInterval"`
 	OCRTraceLogging                       bool            `json:"ocrTraceLogging"`
 	OperatorContractAddress               common.Address  `json:"oracleContractAddress"`
+	OutboundRequestsMaxInFlight           int             `json:"outboundRequestsMaxInFlight"`
+	OutboundRequestsPerSecond             float64         `json:"outboundRequestsPerSecond"`
 	Port                                  uint16          `json:"chainlinkPort"`
 	ReaperExpiration                      models.Duration `json:"reaperExpiration"`
 	ReplayFromBlock                       int64           `json:"replayFromBlock"`
@@ -232,6 +283,7 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			AllowOrigins:                          config.AllowOrigins(),
 			BalanceMonitorEnabled:                 config.BalanceMonitorEnabled(),
 			BlockBackfillDepth:                    config.BlockBackfillDepth(),
+			BlockBackfillBatchSize:                config.BlockBackfillBatchSize(),
 			BridgeResponseURL:                     config.BridgeResponseURL().String(),
 			ChainID:                               config.ChainID(),
 			ClientNodeURL:                         config.ClientNodeURL(),
@@ -245,26 +297,52 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			EthBalanceMonitorBlockDelay:           config.EthBalanceMonitorBlockDelay(),
 			EthereumDisabled:                      config.EthereumDisabled(),
 			EthFinalityDepth:                      config.EthFinalityDepth(),
+			EthFinalityMode:                       config.EthFinalityMode(),
 			EthGasBumpThreshold:                   config.EthGasBumpThreshold(),
 			EthGasBumpTxDepth:                     config.EthGasBumpTxDepth(),
 			EthGasBumpWei:                         config.EthGasBumpWei(),
 			EthGasLimitDefault:                    config.EthGasLimitDefault(),
 			EthGasPriceDefault:                    config.EthGasPriceDefault(),
+			EthGasTipCapDefault:                   config.EthGasTipCapDefault(),
+			EthEIP1559DynamicFees:                 config.EthEIP1559DynamicFees(),
 			EthHeadTrackerHistoryDepth:            config.EthHeadTrackerHistoryDepth(),
 			EthHeadTrackerMaxBufferSize:           config.EthHeadTrackerMaxBufferSize(),
+			EthKeyHighWatermarkWei:                config.EthKeyHighWatermarkWei(),
+			EthKeyLowWatermarkWei:                 config.EthKeyLowWatermarkWei(),
+			EthKeySelectionPolicy:                 config.EthKeySelectionPolicy(),
+			EthTreasuryAddress:                    config.EthTreasuryAddress(),
+			EthTreasuryDailyLimitWei:              config.EthTreasuryDailyLimitWei(),
 			EthMaxGasPriceWei:                     config.EthMaxGasPriceWei(),
+			EthNodeHealthCheckInterval:            config.EthNodeHealthCheckInterval(),
+			EthNodeMaxHeadAge:                     config.EthNodeMaxHeadAge(),
+			EthNodePollingInterval:                config.EthNodePollingInterval(),
+			EthNoncePipelineDepth:                 config.EthNoncePipelineDepth(),
+			EthRPCBatchSize:                       config.EthRPCBatchSize(),
+			EthRemoteSignerURL:                    config.EthRemoteSignerURL(),
+			EthSafeDepth:                          config.EthSafeDepth(),
 			EthereumURL:                           config.EthereumURL(),
 			EthereumSecondaryURL:                  config.EthereumSecondaryURL(),
+			EthereumPrimaryURLs:                   config.EthereumPrimaryURLs(),
+			EthereumSecondaryURLs:                 config.EthereumSecondaryURLs(),
 			ExplorerURL:                           explorerURL,
 			FeatureExternalInitiators:             config.FeatureExternalInitiators(),
 			FeatureFluxMonitor:                    config.FeatureFluxMonitor(),
 			FeatureOffchainReporting:              config.FeatureOffchainReporting(),
 			FlagsContractAddress:                  config.FlagsContractAddress(),
+			GasEstimatorMode:                      config.GasEstimatorMode(),
+			GasFixedMaxGasPriceWei:                config.GasFixedMaxGasPriceWei(),
+			GasFixedMinGasPriceWei:                config.GasFixedMinGasPriceWei(),
+			GasOracleBridgeName:                   config.GasOracleBridgeName(),
+			GasOracleMaxGasPriceWei:               config.GasOracleMaxGasPriceWei(),
+			GasOracleMinGasPriceWei:               config.GasOracleMinGasPriceWei(),
 			GasUpdaterBlockDelay:                  config.GasUpdaterBlockDelay(),
 			GasUpdaterBlockHistorySize:            config.GasUpdaterBlockHistorySize(),
+			GasUpdaterMaxGasPriceWei:              config.GasUpdaterMaxGasPriceWei(),
+			GasUpdaterMinGasPriceWei:              config.GasUpdaterMinGasPriceWei(),
 			GasUpdaterEnabled:                     config.GasUpdaterEnabled(),
 			GasUpdaterTransactionPercentile:       config.GasUpdaterTransactionPercentile(),
 			InsecureFastScrypt:                    config.InsecureFastScrypt(),
+			JobPipelineBridgeAsyncTimeout:         config.JobPipelineBridgeAsyncTimeout(),
 			JobPipelineDBPollInterval:             config.JobPipelineDBPollInterval(),
 			JobPipelineMaxTaskDuration:            config.JobPipelineMaxTaskDuration(),
 			JobPipelineParallelism:                config.JobPipelineParallelism(),
@@ -293,6 +371,8 @@ func NewConfigPrinter(store *store.Store) (ConfigPrinter, error) {
 			OCRDHTLookupInterval:                  config.OCRDHTLookupInterval(),
 			OCRTraceLogging:                       config.OCRTraceLogging(),
 			OperatorContractAddress:               config.OperatorContractAddress(),
+			OutboundRequestsMaxInFlight:           config.OutboundRequestsMaxInFlight(),
+			OutboundRequestsPerSecond:             config.OutboundRequestsPerSecond(),
 			Port:                                  config.Port(),
 			ReaperExpiration:                      config.ReaperExpiration(),
 			ReplayFromBlock:                       config.ReplayFromBlock(),
@@ -0,0 +1,259 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// Signer holds the private keys of Ethereum accounts, and signs with them on
+// behalf of the KeyStore
+type Signer interface {
+	// Accounts lists the accounts the signer holds keys for
+	Accounts() ([]accounts.Account, error)
+	// SignTx signs a legacy transaction, using EIP-155 replay protection
+	SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignDynamicFeeTx signs an EIP-1559 transaction, and returns the raw
+	// signed transaction and its hash
+	SignDynamicFeeTx(account accounts.Account, tx models.DynamicFeeTx) ([]byte, common.Hash, error)
+	// SignMessageHash signs the hash with the Ethereum signed message prefix
+	// added, like eth_sign
+	SignMessageHash(account accounts.Account, hash common.Hash) (models.Signature, error)
+}
+
+// remoteSignerTimeout is how long the remote signer has to answer a request
+const remoteSignerTimeout = 30 * time.Second
+
+// RemoteSigner is a Signer that asks a separate signing service, such as
+// Clef or EthSigner, to sign over HTTP JSON-RPC, so that private keys never
+// have to be held by the node. Every signature it returns is checked against
+// the requested account and transaction before it is used.
+type RemoteSigner struct {
+	url    string
+	client *rpc.Client
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// NewRemoteSigner returns a RemoteSigner for the signing service at the
+// given HTTP URL
+func NewRemoteSigner(url string) (*RemoteSigner, error) {
+	client, err := rpc.DialHTTP(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not connect to remote signer at %s", url)
+	}
+	return &RemoteSigner{url: url, client: client}, nil
+}
+
+func (rs *RemoteSigner) call(result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer cancel()
+	if err := rs.client.CallContext(ctx, result, method, args...); err != nil {
+		return errors.Wrapf(err, "remote signer %s failed", method)
+	}
+	return nil
+}
+
+// Accounts lists the accounts the signer holds keys for, using eth_accounts
+func (rs *RemoteSigner) Accounts() ([]accounts.Account, error) {
+	var addresses []common.Address
+	if err := rs.call(&addresses, "eth_accounts"); err != nil {
+		return nil, err
+	}
+	accts := make([]accounts.Account, len(addresses))
+	for i, address := range addresses {
+		accts[i] = accounts.Account{Address: address, URL: accounts.URL{Scheme: "remote", Path: rs.url}}
+	}
+	return accts, nil
+}
+
+// signTransactionArgs are the arguments of eth_signTransaction
+type signTransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   common.Address  `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Data                 hexutil.Bytes   `json:"data"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+	Type                 *hexutil.Uint64 `json:"type,omitempty"`
+}
+
+// signTransaction calls eth_signTransaction and returns the raw signed
+// transaction. Signers answer either with the raw transaction itself, or
+// like geth and Clef with an object holding it.
+func (rs *RemoteSigner) signTransaction(args signTransactionArgs) ([]byte, error) {
+	var result json.RawMessage
+	if err := rs.call(&result, "eth_signTransaction", args); err != nil {
+		return nil, err
+	}
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var signed struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &signed); err != nil || len(signed.Raw) == 0 {
+		return nil, errors.Errorf("remote signer eth_signTransaction returned unexpected result %s", string(result))
+	}
+	return signed.Raw, nil
+}
+
+// SignTx signs a legacy transaction with eth_signTransaction
+func (rs *RemoteSigner) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if tx.To() == nil {
+		return nil, errors.New("remote signer cannot sign contract creation transactions")
+	}
+	raw, err := rs.signTransaction(signTransactionArgs{
+		From:     account.Address,
+		To:       *tx.To(),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Value:    (*hexutil.Big)(tx.Value()),
+		Data:     tx.Data(),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		ChainID:  (*hexutil.Big)(chainID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx := new(types.Transaction)
+	if err := rlp.DecodeBytes(raw, signedTx); err != nil {
+		return nil, errors.Wrap(err, "could not decode transaction from remote signer")
+	}
+	signer := types.NewEIP155Signer(chainID)
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, errors.New("remote signer signed a different transaction than was requested")
+	}
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not recover sender of transaction from remote signer")
+	}
+	if sender != account.Address {
+		return nil, errors.Errorf("remote signer signed transaction with %s instead of %s", sender.Hex(), account.Address.Hex())
+	}
+	return signedTx, nil
+}
+
+// signedDynamicFeeTx is the RLP encoding of a signed EIP-1559 transaction,
+// after its type byte
+type signedDynamicFeeTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         common.Address
+	Value      *big.Int
+	Data       []byte
+	AccessList []struct {
+		Address     common.Address
+		StorageKeys []common.Hash
+	}
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// SignDynamicFeeTx signs an EIP-1559 transaction with eth_signTransaction
+func (rs *RemoteSigner) SignDynamicFeeTx(account accounts.Account, tx models.DynamicFeeTx) ([]byte, common.Hash, error) {
+	txType := hexutil.Uint64(models.DynamicFeeTxType)
+	raw, err := rs.signTransaction(signTransactionArgs{
+		From:                 account.Address,
+		To:                   tx.To,
+		Gas:                  hexutil.Uint64(tx.Gas),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap),
+		Value:                (*hexutil.Big)(tx.Value),
+		Data:                 tx.Data,
+		Nonce:                hexutil.Uint64(tx.Nonce),
+		ChainID:              (*hexutil.Big)(tx.ChainID),
+		Type:                 &txType,
+	})
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if len(raw) == 0 || raw[0] != models.DynamicFeeTxType {
+		return nil, common.Hash{}, errors.New("remote signer did not return a dynamic fee transaction")
+	}
+
+	var decoded signedDynamicFeeTx
+	if err := rlp.DecodeBytes(raw[1:], &decoded); err != nil {
+		return nil, common.Hash{}, errors.Wrap(err, "could not decode transaction from remote signer")
+	}
+	if !decoded.V.IsUint64() || decoded.V.Uint64() > 1 {
+		return nil, common.Hash{}, errors.Errorf("invalid signature recovery id %s from remote signer", decoded.V)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	decoded.R.FillBytes(sig[:32])
+	decoded.S.FillBytes(sig[32:64])
+	sig[64] = byte(decoded.V.Uint64())
+
+	// Encoding the requested transaction with the returned signature only
+	// reproduces the raw transaction if the signer signed it unchanged
+	encoded, hash, err := tx.EncodeSigned(sig)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if !bytes.Equal(encoded, raw) {
+		return nil, common.Hash{}, errors.New("remote signer signed a different transaction than was requested")
+	}
+	if err := checkSigner(account, tx.SigningHash(), sig); err != nil {
+		return nil, common.Hash{}, err
+	}
+	return raw, hash, nil
+}
+
+// SignMessageHash signs the hash with eth_sign, which adds the Ethereum
+// signed message prefix
+func (rs *RemoteSigner) SignMessageHash(account accounts.Account, hash common.Hash) (models.Signature, error) {
+	var sig hexutil.Bytes
+	if err := rs.call(&sig, "eth_sign", account.Address, hexutil.Bytes(hash.Bytes())); err != nil {
+		return models.Signature{}, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return models.Signature{}, errors.Errorf("remote signer returned signature of %d bytes, want %d", len(sig), crypto.SignatureLength)
+	}
+	// eth_sign returns V as 27 or 28, but the KeyStore signs with 0 or 1
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	prefixedHash := crypto.Keccak256Hash([]byte(EthereumMessageHashPrefix), hash.Bytes())
+	if err := checkSigner(account, prefixedHash, sig); err != nil {
+		return models.Signature{}, err
+	}
+	var signature models.Signature
+	signature.SetBytes(sig)
+	return signature, nil
+}
+
+// checkSigner returns an error unless sig is the account's signature of hash
+func checkSigner(account accounts.Account, hash common.Hash, sig []byte) error {
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return errors.Wrap(err, "could not recover signer from remote signer signature")
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != account.Address {
+		return errors.Errorf("remote signer signed with %s instead of %s", signer.Hex(), account.Address.Hex())
+	}
+	return nil
+}
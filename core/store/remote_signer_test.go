@@ -0,0 +1,205 @@
+package store_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSigner serves eth_accounts, eth_signTransaction and eth_sign for a
+// single key, like Clef or EthSigner
+type fakeSigner struct {
+	key *ecdsa.PrivateKey
+	// tamper makes the signer sign a different transaction than requested
+	tamper bool
+}
+
+type fakeSignTransactionArgs struct {
+	To                   common.Address  `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Data                 hexutil.Bytes   `json:"data"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+	Type                 *hexutil.Uint64 `json:"type"`
+}
+
+type fakeSignTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+func (s *fakeSigner) Accounts() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+// SignTransaction returns a bare raw transaction for dynamic fee
+// transactions, like EthSigner, and an object holding it otherwise, like Clef
+func (s *fakeSigner) SignTransaction(args fakeSignTransactionArgs) (interface{}, error) {
+	nonce := uint64(args.Nonce)
+	if s.tamper {
+		nonce++
+	}
+	if args.Type != nil {
+		tx := models.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     nonce,
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas:       uint64(args.Gas),
+			To:        args.To,
+			Value:     args.Value.ToInt(),
+			Data:      args.Data,
+		}
+		sig, err := crypto.Sign(tx.SigningHash().Bytes(), s.key)
+		if err != nil {
+			return nil, err
+		}
+		raw, _, err := tx.EncodeSigned(sig)
+		return hexutil.Bytes(raw), err
+	}
+	tx := types.NewTransaction(nonce, args.To, args.Value.ToInt(), uint64(args.Gas), args.GasPrice.ToInt(), args.Data)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rlp.EncodeToBytes(signed)
+	return fakeSignTransactionResult{Raw: raw}, err
+}
+
+func (s *fakeSigner) Sign(address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	hash := crypto.Keccak256([]byte(store.EthereumMessageHashPrefix), data)
+	sig, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+func newRemoteSigner(t *testing.T, fake *fakeSigner) (*store.RemoteSigner, func()) {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", fake))
+	httpServer := httptest.NewServer(server)
+	signer, err := store.NewRemoteSigner(httpServer.URL)
+	require.NoError(t, err)
+	return signer, func() {
+		httpServer.Close()
+		server.Stop()
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	account := accounts.Account{Address: address}
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x6e5d0e5e6b1cb0e7e6a1c5dcf4c3ed4fa3a0c3a9")
+
+	fake := &fakeSigner{key: key}
+	signer, cleanup := newRemoteSigner(t, fake)
+	defer cleanup()
+
+	t.Run("lists accounts", func(t *testing.T) {
+		accts, err := signer.Accounts()
+		require.NoError(t, err)
+		require.Len(t, accts, 1)
+		assert.Equal(t, address, accts[0].Address)
+	})
+
+	t.Run("signs legacy transactions", func(t *testing.T) {
+		tx := types.NewTransaction(7, to, big.NewInt(42), 21000, big.NewInt(20000000000), []byte{1, 2, 3})
+		signed, err := signer.SignTx(account, tx, chainID)
+		require.NoError(t, err)
+
+		expected, err := types.SignTx(tx, types.NewEIP155Signer(chainID), key)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Hash(), signed.Hash())
+
+		_, err = signer.SignTx(accounts.Account{Address: to}, tx, chainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "instead of")
+	})
+
+	t.Run("signs dynamic fee transactions", func(t *testing.T) {
+		tx := models.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     7,
+			GasTipCap: big.NewInt(1000000000),
+			GasFeeCap: big.NewInt(50000000000),
+			Gas:       21000,
+			To:        to,
+			Value:     big.NewInt(42),
+			Data:      []byte{1, 2, 3},
+		}
+		raw, hash, err := signer.SignDynamicFeeTx(account, tx)
+		require.NoError(t, err)
+
+		sig, err := crypto.Sign(tx.SigningHash().Bytes(), key)
+		require.NoError(t, err)
+		expectedRaw, expectedHash, err := tx.EncodeSigned(sig)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(expectedRaw, raw))
+		assert.Equal(t, expectedHash, hash)
+	})
+
+	t.Run("signs message hashes like the local keystore", func(t *testing.T) {
+		hash := common.HexToHash("0x3cd3f7f5c4ba8a0e7e2e0b2d6d3e95b2a0f7d4dc5b0f6e0ee0dc0f8f4bd1a5b2")
+		signature, err := signer.SignMessageHash(account, hash)
+		require.NoError(t, err)
+
+		expected, err := crypto.Sign(crypto.Keccak256([]byte(store.EthereumMessageHashPrefix), hash.Bytes()), key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, signature.Bytes())
+	})
+}
+
+func TestRemoteSigner_RejectsTamperedTransactions(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account := accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey)}
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x6e5d0e5e6b1cb0e7e6a1c5dcf4c3ed4fa3a0c3a9")
+
+	signer, cleanup := newRemoteSigner(t, &fakeSigner{key: key, tamper: true})
+	defer cleanup()
+
+	tx := types.NewTransaction(7, to, big.NewInt(42), 21000, big.NewInt(20000000000), nil)
+	_, err = signer.SignTx(account, tx, chainID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signed a different transaction")
+
+	_, _, err = signer.SignDynamicFeeTx(account, models.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(50000000000),
+		Gas:       21000,
+		To:        to,
+		Value:     big.NewInt(42),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "signed a different transaction")
+}
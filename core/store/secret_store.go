@@ -0,0 +1,116 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// SecretStore manages named secrets (e.g. data provider API keys) that are
+// encrypted with the node's password and stored in the database.  Secrets are
+// decrypted into memory when the store is unlocked, so that tasks can inject
+// them into outgoing requests without prompting for the password.
+type SecretStore struct {
+	db           *gorm.DB
+	password     string
+	secrets      map[string]string
+	scryptParams utils.ScryptParams
+	mu           *sync.RWMutex
+}
+
+var _ models.SecretResolver = (*SecretStore)(nil)
+
+// NewSecretStore returns a locked SecretStore
+func NewSecretStore(db *gorm.DB, scryptParams utils.ScryptParams) *SecretStore {
+	return &SecretStore{
+		db:           db,
+		secrets:      make(map[string]string),
+		scryptParams: scryptParams,
+		mu:           new(sync.RWMutex),
+	}
+}
+
+// Unlock decrypts all of the secrets in the database with password
+func (ss *SecretStore) Unlock(password string) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	encryptedSecrets, err := ss.FindEncryptedSecrets()
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, es := range encryptedSecrets {
+		value, err := es.Decrypt(password)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		ss.secrets[es.Name] = value
+		logger.Debugw("Unlocked secret", "name", es.Name)
+	}
+	ss.password = password
+	return errs
+}
+
+// Secret returns the decrypted value of the named secret
+func (ss *SecretStore) Secret(name string) (string, error) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	value, exists := ss.secrets[name]
+	if !exists {
+		return "", errors.Errorf("secret %s does not exist or the secret store is locked", name)
+	}
+	return value, nil
+}
+
+// UpsertSecret encrypts value with the node's password and stores it under
+// name, replacing any existing secret with the same name
+func (ss *SecretStore) UpsertSecret(name, value string) (models.EncryptedSecret, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.password == "" {
+		return models.EncryptedSecret{}, errors.New("the secret store must be unlocked before secrets can be added")
+	}
+	es, err := models.NewEncryptedSecret(name, value, ss.password, ss.scryptParams)
+	if err != nil {
+		return models.EncryptedSecret{}, err
+	}
+	err = ss.db.
+		Set("gorm:insert_option", "ON CONFLICT (name) DO UPDATE SET encrypted_value=EXCLUDED.encrypted_value, updated_at=NOW()").
+		Create(&es).
+		Error
+	if err != nil {
+		return models.EncryptedSecret{}, errors.Wrapf(err, "while inserting secret %s", name)
+	}
+	ss.secrets[name] = value
+	return es, nil
+}
+
+// FindEncryptedSecrets returns all of the encrypted secrets, ordered by name
+func (ss *SecretStore) FindEncryptedSecrets() (secrets []models.EncryptedSecret, err error) {
+	return secrets, ss.db.Order("name asc").Find(&secrets).Error
+}
+
+// DeleteSecret removes the named secret
+func (ss *SecretStore) DeleteSecret(name string) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	result := ss.db.Where("name = ?", name).Delete(&models.EncryptedSecret{})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	delete(ss.secrets, name)
+	return nil
+}
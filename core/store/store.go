@@ -16,9 +16,11 @@ import (
 	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v3"
 )
 
 const (
@@ -41,8 +43,10 @@ type Store struct {
 	KeyStore       KeyStoreInterface
 	VRFKeyStore    *VRFKeyStore
 	OCRKeyStore    *offchainreporting.KeyStore
+	SecretStore    *SecretStore
 	TxManager      TxManager
 	EthClient      eth.Client
+	HeadChain      *eth.HeadChain
 	NotifyNewEthTx NotifyNewEthTx
 	AdvisoryLocker postgres.AdvisoryLocker
 	closeOnce      *sync.Once
@@ -52,6 +56,13 @@ type Store struct {
 func NewStore(config *orm.Config, ethClient eth.Client, advisoryLock postgres.AdvisoryLocker, shutdownSignal gracefulpanic.Signal) *Store {
 	keyStore := func() *KeyStore {
 		scryptParams := utils.GetScryptParams(config)
+		if url := config.EthRemoteSignerURL(); url != "" {
+			signer, err := NewRemoteSigner(url)
+			if err != nil {
+				logger.Fatal(fmt.Sprintf("Unable to use remote signer: %+v", err))
+			}
+			return NewRemoteSignerKeyStore(config.KeysDir(), scryptParams, signer)
+		}
 		return NewKeyStore(config.KeysDir(), scryptParams)
 	}
 	return newStoreWithKeyStore(config, ethClient, advisoryLock, keyStore, shutdownSignal)
@@ -93,9 +104,11 @@ func newStoreWithKeyStore(
 		Config:         config,
 		KeyStore:       keyStore,
 		OCRKeyStore:    offchainreporting.NewKeyStore(orm.DB, scryptParams),
+		SecretStore:    NewSecretStore(orm.DB, scryptParams),
 		ORM:            orm,
 		TxManager:      txManager,
 		EthClient:      ethClient,
+		HeadChain:      eth.NewHeadChain(config.EthHeadTrackerHistoryDepth()),
 		closeOnce:      &sync.Once{},
 	}
 	store.VRFKeyStore = NewVRFKeyStore(store)
@@ -149,8 +162,11 @@ func (s *Store) AuthorizedUserWithSession(sessionID string) (models.User, error)
 }
 
 // SyncDiskKeyStoreToDB writes all keys in the keys directory to the underlying
-// orm.
+// orm, or the accounts of the remote signer if one is used.
 func (s *Store) SyncDiskKeyStoreToDB() error {
+	if s.Config.EthRemoteSignerURL() != "" {
+		return s.syncRemoteSignerKeysToDB()
+	}
 	files, err := utils.FilesInDir(s.Config.KeysDir())
 	if err != nil {
 		return multierr.Append(errors.New("unable to sync disk keystore to db"), err)
@@ -167,7 +183,37 @@ func (s *Store) SyncDiskKeyStoreToDB() error {
 		err = s.CreateKeyIfNotExists(key)
 		if err != nil {
 			merr = multierr.Append(err, merr)
+			continue
+		}
+
+		if index := s.KeyHDIndex(key.Address.Address()); index.Valid {
+			merr = multierr.Append(s.SetKeyHDIndex(key.Address, uint32(index.Int64)), merr)
+		}
+	}
+	return merr
+}
+
+// KeyHDIndex returns the index on the derivation path of a key derived from
+// the node's seed since it started, or null otherwise
+func (s *Store) KeyHDIndex(address common.Address) null.Int {
+	if ks, ok := s.KeyStore.(*KeyStore); ok {
+		if index, derived := ks.HDIndex(address); derived {
+			return null.IntFrom(int64(index))
+		}
+	}
+	return null.Int{}
+}
+
+// syncRemoteSignerKeysToDB adds a key for every account of the remote signer
+func (s *Store) syncRemoteSignerKeysToDB() error {
+	var merr error
+	for _, account := range s.KeyStore.Accounts() {
+		key, err := models.NewRemoteKey(account.Address)
+		if err != nil {
+			merr = multierr.Append(err, merr)
+			continue
 		}
+		merr = multierr.Append(s.CreateKeyIfNotExists(key), merr)
 	}
 	return merr
 }
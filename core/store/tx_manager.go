@@ -694,7 +694,31 @@ func (txm *EthTxManager) BumpGasByIncrement(originalGasPrice *big.Int) *big.Int
 // - A configured fixed amount of Wei (ETH_GAS_PRICE_WEI) on top of the baseline price.
 // The baseline price is the maximum of the previous gas price attempt and the node's current gas price.
 func BumpGas(config orm.ConfigReader, originalGasPrice *big.Int) (*big.Int, error) {
-	baselinePrice := max(originalGasPrice, config.EthGasPriceDefault())
+	return bumpPrice(config, "gas price", originalGasPrice, config.EthGasPriceDefault())
+}
+
+// BumpDynamicFee computes the next tip cap and fee cap to attempt for an
+// EIP-1559 dynamic fee transaction.  Eth nodes only accept a replacement that
+// raises both, so each is bumped by the same rules as BumpGas, with the
+// node's current default tip cap and fee cap as baselines.  The tip cap is
+// never more than the fee cap.
+func BumpDynamicFee(config orm.ConfigReader, originalTipCap, originalFeeCap *big.Int) (tipCap, feeCap *big.Int, err error) {
+	feeCap, err = bumpPrice(config, "fee cap", originalFeeCap, config.EthGasFeeCapDefault())
+	if err != nil {
+		return tipCap, feeCap, err
+	}
+	tipCap, err = bumpPrice(config, "tip cap", originalTipCap, config.EthGasTipCapDefault())
+	if err != nil {
+		return tipCap, feeCap, err
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = feeCap
+	}
+	return tipCap, feeCap, nil
+}
+
+func bumpPrice(config orm.ConfigReader, name string, originalPrice, defaultPrice *big.Int) (*big.Int, error) {
+	baselinePrice := max(originalPrice, defaultPrice)
 
 	var priceByPercentage = new(big.Int)
 	priceByPercentage.Mul(baselinePrice, big.NewInt(int64(100+config.EthGasBumpPercent())))
@@ -703,19 +727,19 @@ func BumpGas(config orm.ConfigReader, originalGasPrice *big.Int) (*big.Int, erro
 	var priceByIncrement = new(big.Int)
 	priceByIncrement.Add(baselinePrice, config.EthGasBumpWei())
 
-	bumpedGasPrice := max(priceByPercentage, priceByIncrement)
-	if bumpedGasPrice.Cmp(config.EthMaxGasPriceWei()) > 0 {
-		return config.EthMaxGasPriceWei(), errors.Errorf("bumped gas price of %s would exceed configured max gas price of %s (original price was %s)",
-			bumpedGasPrice.String(), config.EthMaxGasPriceWei(), originalGasPrice.String())
-	} else if bumpedGasPrice.Cmp(originalGasPrice) == 0 {
+	bumpedPrice := max(priceByPercentage, priceByIncrement)
+	if bumpedPrice.Cmp(config.EthMaxGasPriceWei()) > 0 {
+		return config.EthMaxGasPriceWei(), errors.Errorf("bumped %s of %s would exceed configured max gas price of %s (original price was %s)",
+			name, bumpedPrice.String(), config.EthMaxGasPriceWei(), originalPrice.String())
+	} else if bumpedPrice.Cmp(originalPrice) == 0 {
 		// NOTE: This really shouldn't happen since we enforce minimums for
 		// ETH_GAS_BUMP_PERCENT and ETH_GAS_BUMP_WEI in the config validation,
 		// but it's here anyway for a "belts and braces" approach
-		return bumpedGasPrice, errors.Errorf("bumped gas price of %s is equal to original gas price of %s."+
+		return bumpedPrice, errors.Errorf("bumped %s of %s is equal to original %s of %s."+
 			" ACTION REQUIRED: This is a configuration error, you must increase either "+
-			"ETH_GAS_BUMP_PERCENT or ETH_GAS_BUMP_WEI", bumpedGasPrice.String(), originalGasPrice.String())
+			"ETH_GAS_BUMP_PERCENT or ETH_GAS_BUMP_WEI", name, bumpedPrice.String(), name, originalPrice.String())
 	}
-	return bumpedGasPrice, nil
+	return bumpedPrice, nil
 }
 
 func max(a, b *big.Int) *big.Int {
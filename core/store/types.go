@@ -14,3 +14,10 @@ type HeadTrackable interface {
 	Disconnect()
 	OnNewLongestChain(ctx context.Context, head models.Head)
 }
+
+// ReorgTrackable is optionally implemented by a HeadTrackable that wishes to
+// be told which blocks were removed from the longest chain. OnReorg is called
+// before OnNewLongestChain with the head of the new longest chain.
+type ReorgTrackable interface {
+	OnReorg(ctx context.Context, reorg models.Reorg)
+}
@@ -50,6 +50,14 @@ func (ks *VRFKeyStore) Store(key *vrfkey.PrivateKey, phrase string, scryptParams
 	return nil
 }
 
+// remember unlocks key in the in-memory store, for a key already saved to the
+// DB
+func (ks *VRFKeyStore) remember(key *vrfkey.PrivateKey) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.keys[key.PublicKey] = *key
+}
+
 // StoreInMemoryXXXTestingOnly memorizes key, only in in-memory store.
 func (ks *VRFKeyStore) StoreInMemoryXXXTestingOnly(key *vrfkey.PrivateKey) {
 	ks.lock.Lock()
@@ -0,0 +1,24 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// EthNodesController reports the health of the node's Ethereum connections
+type EthNodesController struct {
+	App chainlink.Application
+}
+
+// Index lists every configured Ethereum node with its latest health check.
+// The list is empty if Ethereum is disabled.
+// Example:
+// "GET <application>/eth_nodes"
+func (enc *EthNodesController) Index(c *gin.Context) {
+	statuses := []eth.NodeStatus{}
+	if reporter, ok := enc.App.GetStore().EthClient.(eth.NodeStatusReporter); ok {
+		statuses = append(statuses, reporter.NodeStatuses()...)
+	}
+	jsonAPIResponse(c, statuses, "ethNodes")
+}
@@ -0,0 +1,27 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthNodesController_Index_NotAPool(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/eth_nodes")
+	defer cleanup()
+	body := cltest.ParseResponseBody(t, resp)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.JSONEq(t, `{"data":[]}`, string(body))
+}
@@ -0,0 +1,75 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// EthTxesController lets the node operator cancel or reprice transactions
+// sent by the BulletproofTxManager.
+type EthTxesController struct {
+	App chainlink.Application
+}
+
+// Update cancels a transaction, or sends it again at a higher gas price. The
+// transaction is identified by its ID or the hash of any of its attempts.
+// Example:
+//  "<application>/eth_txes/:ID"
+func (etc *EthTxesController) Update(c *gin.Context) {
+	var request models.EthTxPatchRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if request.Cancel == (request.GasPriceWei != nil) {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("must set exactly one of cancel or gasPriceWei"))
+		return
+	}
+
+	etxID, err := etc.findEthTxID(c.Param("ID"))
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Transaction not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	ec := etc.App.GetEthConfirmer()
+	var etx models.EthTx
+	if request.Cancel {
+		etx, err = ec.CancelEthTx(c.Request.Context(), etxID)
+	} else {
+		etx, err = ec.RepriceEthTx(c.Request.Context(), etxID, request.GasPriceWei.ToInt())
+	}
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Transaction not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jsonAPIResponse(c, etx, "eth_tx")
+}
+
+func (etc *EthTxesController) findEthTxID(param string) (int64, error) {
+	if strings.HasPrefix(param, "0x") {
+		attempt, err := etc.App.GetStore().FindEthTxAttempt(common.HexToHash(param))
+		if err != nil {
+			return 0, err
+		}
+		return attempt.EthTxID, nil
+	}
+	id, err := strconv.ParseInt(param, 10, 64)
+	return id, errors.Wrap(err, "invalid transaction ID")
+}
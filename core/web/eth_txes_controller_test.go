@@ -0,0 +1,143 @@
+package web_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthTxesController_Update(t *testing.T) {
+	t.Parallel()
+
+	config, _ := cltest.NewConfig(t)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.StartAndConnect())
+
+	etx := cltest.MustInsertFatalErrorEthTx(t, app.GetStore())
+
+	tests := []struct {
+		name   string
+		id     string
+		body   string
+		status int
+	}{
+		{"neither cancel nor gas price", fmt.Sprintf("%v", etx.ID), `{}`, http.StatusBadRequest},
+		{"both cancel and gas price", fmt.Sprintf("%v", etx.ID), `{"cancel":true,"gasPriceWei":"1000"}`, http.StatusBadRequest},
+		{"invalid ID", "abc", `{"cancel":true}`, http.StatusUnprocessableEntity},
+		{"missing ID", "9999", `{"cancel":true}`, http.StatusNotFound},
+		{"missing hash", cltest.NewHash().Hex(), `{"cancel":true}`, http.StatusNotFound},
+		{"cancel errored transaction", fmt.Sprintf("%v", etx.ID), `{"cancel":true}`, http.StatusUnprocessableEntity},
+		{"reprice errored transaction", fmt.Sprintf("%v", etx.ID), `{"gasPriceWei":"1000"}`, http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, cleanup := client.Patch("/v2/eth_txes/"+test.id, bytes.NewBufferString(test.body))
+			defer cleanup()
+			cltest.AssertServerResponse(t, resp, test.status)
+		})
+	}
+
+	etx, err := app.GetStore().FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Nil(t, etx.CancelledAt)
+}
+
+func TestEthTxesController_Update_Cancel(t *testing.T) {
+	t.Parallel()
+
+	config, _ := cltest.NewConfig(t)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.StartAndConnect())
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, app.GetStore(), 0)
+	originalAttempt := etx.EthTxAttempts[0]
+
+	resp, cleanup := client.Patch(fmt.Sprintf("/v2/eth_txes/%v", etx.ID), bytes.NewBufferString(`{"cancel":true}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	etx, err := app.GetStore().FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+	assert.NotNil(t, etx.CancelledAt)
+	require.Len(t, etx.EthTxAttempts, 2)
+
+	attempt := newestEthTxAttempt(t, etx, originalAttempt.ID)
+	assert.Equal(t, models.EthTxAttemptBroadcast, attempt.State)
+	assert.True(t, attempt.GasPrice.ToInt().Cmp(originalAttempt.GasPrice.ToInt()) > 0)
+	tx, err := attempt.GetSignedTx()
+	require.NoError(t, err)
+	require.NotNil(t, tx.To())
+	assert.Equal(t, etx.FromAddress, *tx.To())
+	assert.Equal(t, uint64(*etx.Nonce), tx.Nonce())
+	assert.Len(t, tx.Data(), 0)
+	assert.Equal(t, int64(0), tx.Value().Int64())
+}
+
+func TestEthTxesController_Update_Reprice(t *testing.T) {
+	t.Parallel()
+
+	config, _ := cltest.NewConfig(t)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	client := app.NewHTTPClient()
+	require.NoError(t, app.StartAndConnect())
+
+	etx := cltest.MustInsertUnconfirmedEthTxWithBroadcastAttempt(t, app.GetStore(), 0)
+	originalAttempt := etx.EthTxAttempts[0]
+
+	resp, cleanup := client.Patch(fmt.Sprintf("/v2/eth_txes/%v", etx.ID), bytes.NewBufferString(`{"gasPriceWei":"1000"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	etx, err := app.GetStore().FindEthTxWithAttempts(etx.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EthTxUnconfirmed, etx.State)
+	assert.Nil(t, etx.CancelledAt)
+	require.Len(t, etx.EthTxAttempts, 2)
+
+	attempt := newestEthTxAttempt(t, etx, originalAttempt.ID)
+	assert.Equal(t, models.EthTxAttemptBroadcast, attempt.State)
+	assert.Equal(t, int64(1000), attempt.GasPrice.ToInt().Int64())
+	tx, err := attempt.GetSignedTx()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), tx.GasPrice().Int64())
+	assert.Equal(t, uint64(*etx.Nonce), tx.Nonce())
+	assert.Equal(t, etx.EncodedPayload, tx.Data())
+
+	resp, cleanup = client.Patch(fmt.Sprintf("/v2/eth_txes/%v", etx.ID), bytes.NewBufferString(`{"gasPriceWei":"1000"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func newestEthTxAttempt(t *testing.T, etx models.EthTx, originalAttemptID int64) models.EthTxAttempt {
+	t.Helper()
+	for _, attempt := range etx.EthTxAttempts {
+		if attempt.ID != originalAttemptID {
+			return attempt
+		}
+	}
+	t.Fatalf("eth_tx %v has no new attempt", etx.ID)
+	return models.EthTxAttempt{}
+}
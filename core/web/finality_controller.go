@@ -0,0 +1,22 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+)
+
+// FinalityController reports which blocks the node considers final
+type FinalityController struct {
+	App chainlink.Application
+}
+
+// Show returns the latest, safe and finalized block numbers, which are null
+// until the HeadTracker has seen a head.
+// Example:
+// "GET <application>/finality"
+func (fc *FinalityController) Show(c *gin.Context) {
+	store := fc.App.GetStore()
+	status := eth.CurrentFinality(store.HeadChain, store.Config.EthFinalityMode())
+	jsonAPIResponse(c, status, "finality")
+}
@@ -0,0 +1,36 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalityController_Show(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	app.Store.HeadChain.SetLongestChain(*cltest.Head(100))
+	app.Store.HeadChain.SetFinality(50, 88)
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/finality")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var status eth.FinalityStatus
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &status))
+	assert.Equal(t, "Depth", status.Mode)
+	assert.Equal(t, int64(100), status.LatestBlockNumber.Int64)
+	assert.Equal(t, int64(88), status.SafeBlockNumber.Int64)
+	assert.Equal(t, int64(50), status.FinalizedBlockNumber.Int64)
+}
@@ -0,0 +1,21 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+)
+
+// GasEstimatesController reports the price that new transactions will pay
+type GasEstimatesController struct {
+	App chainlink.Application
+}
+
+// Show returns the current estimate of the configured gas estimator. The tip
+// and fee caps are null unless EIP-1559 dynamic fees are enabled.
+// Example:
+// "GET <application>/gas_estimate"
+func (gec *GasEstimatesController) Show(c *gin.Context) {
+	estimate := gas.CurrentEstimate(gec.App.GetGasEstimator(), gec.App.GetStore().Config)
+	jsonAPIResponse(c, estimate, "gasEstimates")
+}
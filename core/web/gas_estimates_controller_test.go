@@ -0,0 +1,37 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/gas"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasEstimatesController_Show(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	config.Set("GAS_ESTIMATOR_MODE", "Fixed")
+	config.Set("ETH_GAS_PRICE_DEFAULT", 42000000000)
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/gas_estimate")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var estimate gas.Estimate
+	require.NoError(t, cltest.ParseJSONAPIResponse(t, resp, &estimate))
+	assert.Equal(t, "Fixed", estimate.Mode)
+	assert.Equal(t, "42000000000", estimate.GasPrice.String())
+	assert.Nil(t, estimate.TipCap)
+	assert.Nil(t, estimate.FeeCap)
+}
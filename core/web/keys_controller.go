@@ -1,13 +1,18 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/orm"
 	"github.com/smartcontractkit/chainlink/core/store/presenters"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
 // KeysController manages account keys
@@ -41,3 +46,61 @@ func (kc *KeysController) Create(c *gin.Context) {
 
 	jsonAPIResponseWithStatus(c, presenters.NewAccount{Account: &account}, "account", http.StatusCreated)
 }
+
+// Update changes the group of an account, which EthTx tasks can select with
+// fromGroup, or the watermarks used to top it up from the treasury key
+// Example:
+//  "<application>/keys/0x..."
+func (kc *KeysController) Update(c *gin.Context) {
+	hexAddress := c.Param("address")
+	if !common.IsHexAddress(hexAddress) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid address: %s", hexAddress))
+		return
+	}
+	address := common.HexToAddress(hexAddress)
+
+	request := models.UpdateKeyRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if request.Group == nil && request.LowWatermark == nil && request.HighWatermark == nil {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("must set group, or lowWatermark and highWatermark"))
+		return
+	}
+	if (request.LowWatermark == nil) != (request.HighWatermark == nil) {
+		jsonAPIError(c, http.StatusBadRequest, errors.New("lowWatermark and highWatermark must be set together"))
+		return
+	}
+
+	store := kc.App.GetStore()
+	var err error
+	if request.Group != nil {
+		err = store.SetKeyGroup(address, *request.Group)
+	}
+	if err == nil && request.LowWatermark != nil {
+		low, high := request.LowWatermark, request.HighWatermark
+		if low.Cmp(high) > 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("lowWatermark may not be greater than highWatermark"))
+			return
+		}
+		if low.IsZero() {
+			low, high = nil, nil
+		}
+		err = store.SetKeyWatermarks(address, low, high)
+	}
+	if errors.Cause(err) == orm.ErrorNotFound {
+		jsonAPIError(c, http.StatusNotFound, errors.New("key not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	key := getETHAccount(c, store, accounts.Account{Address: address})
+	if c.IsAborted() {
+		return
+	}
+	jsonAPIResponse(c, key, "keys")
+}
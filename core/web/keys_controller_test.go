@@ -3,11 +3,13 @@ package web_test
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestKeysController_CreateSuccess(t *testing.T) {
@@ -86,3 +88,28 @@ func TestKeysController_JSONBindingError(t *testing.T) {
 
 	cltest.AssertServerResponse(t, resp, 422)
 }
+
+func TestKeysController_Update_Errors(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t,
+		cltest.LenientEthMock,
+		cltest.EthMockRegisterChainID,
+		cltest.EthMockRegisterGetBalance,
+	)
+	defer cleanup()
+	require.NoError(t, app.StartAndConnect())
+
+	client := app.NewHTTPClient()
+	group := "oracle"
+	body, err := json.Marshal(&models.UpdateKeyRequest{Group: &group})
+	require.NoError(t, err)
+
+	resp, cleanup := client.Patch("/v2/keys/notanaddress", bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Patch("/v2/keys/"+cltest.NewAddress().Hex(), bytes.NewBuffer(body))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
 )
 
 // OffChainReportingKeysController manages OCR key bundles
@@ -74,3 +75,26 @@ func (ocrkbc *OffChainReportingKeysController) Delete(c *gin.Context) {
 	}
 	jsonAPIResponse(c, ekb, "offChainReportingKeyBundle")
 }
+
+// Rotate generates a successor to an OCR key bundle, which the jobs using the
+// bundle switch to once the on-chain config of their contract lists it
+// Example:
+// "POST <application>/off-chain-reporting-keys/:keyID/rotate"
+func (ocrkbc *OffChainReportingKeysController) Rotate(c *gin.Context) {
+	id, err := models.Sha256HashFromHex(c.Param("keyID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	ekb, err := ocrkbc.App.GetStore().OCRKeyStore.FindEncryptedOCRKeyBundleByID(id)
+	if err != nil {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+	next, jobs, err := ocrkbc.App.GetStore().OCRKeyStore.RotateOCRKeyBundle(ekb.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.OCRKeyBundleRotation{EncryptedKeyBundle: next, Jobs: jobs}, "offChainReportingKeyBundle")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
 	"github.com/smartcontractkit/chainlink/core/store/models/ocrkey"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
 	"github.com/smartcontractkit/chainlink/core/utils"
 	"github.com/smartcontractkit/chainlink/core/web"
 	"github.com/stretchr/testify/assert"
@@ -102,6 +103,43 @@ func TestOffChainReportingKeysController_Delete_HappyPath(t *testing.T) {
 	assert.Equal(t, initialLength, len(keys))
 }
 
+func TestOffChainReportingKeysController_Rotate(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplication(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+	client := app.NewHTTPClient()
+	store := app.GetStore()
+	OCRKeyStore := store.OCRKeyStore
+	require.NoError(t, OCRKeyStore.Unlock(cltest.Password))
+
+	spec := cltest.MustInsertOffchainreportingOracleSpec(t, store)
+	require.NoError(t, store.DB.Exec(`INSERT INTO jobs (offchainreporting_oracle_spec_id) VALUES (?)`, spec.ID).Error)
+
+	response, cleanup := client.Post("/v2/off_chain_reporting_keys/"+cltest.DefaultOCRKeyBundleID+"/rotate", nil)
+	defer cleanup()
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	rotation := presenters.OCRKeyBundleRotation{}
+	err := web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &rotation)
+	require.NoError(t, err)
+	assert.NotEqual(t, cltest.DefaultOCRKeyBundleIDSha256, rotation.ID)
+	require.Len(t, rotation.Jobs, 1)
+	assert.Equal(t, spec.ContractAddress, rotation.Jobs[0].ContractAddress)
+
+	_, exists := OCRKeyStore.DecryptedOCRKey(rotation.ID)
+	assert.True(t, exists)
+	require.NoError(t, store.DB.First(&spec, spec.ID).Error)
+	assert.Equal(t, cltest.DefaultOCRKeyBundleIDSha256, spec.EncryptedOCRKeyBundleID)
+	require.NotNil(t, spec.NextEncryptedOCRKeyBundleID)
+	assert.Equal(t, rotation.ID, *spec.NextEncryptedOCRKeyBundleID)
+
+	response, cleanup = client.Post("/v2/off_chain_reporting_keys/eb81f4a35033ac8dd68b9d33a039a713d6fd639af6852b81f47ffeda1c95de54/rotate", nil)
+	defer cleanup()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
 func setupOCRKeysControllerTests(t *testing.T) (cltest.HTTPClientCleaner, *offchainreporting.KeyStore, func()) {
 	t.Parallel()
 
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/smartcontractkit/chainlink/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/core/store/models/p2pkey"
+	"github.com/smartcontractkit/chainlink/core/store/presenters"
 )
 
 // P2PKeysController manages P2P keys
@@ -75,3 +76,27 @@ func (p2pkc *P2PKeysController) Delete(c *gin.Context) {
 	}
 	jsonAPIResponse(c, encryptedP2PKeyPointer, "offChainReportingKeyBundle")
 }
+
+// Rotate generates a successor to a P2P key, which the jobs using the key
+// switch to along with their OCR key bundle, or else when they next start
+// Example:
+// "POST <application>/p2p_keys/:keyID/rotate"
+func (p2pkc *P2PKeysController) Rotate(c *gin.Context) {
+	ep2pk := p2pkey.EncryptedP2PKey{}
+	err := ep2pk.SetID(c.Param("keyID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	encryptedP2PKeyPointer, err := p2pkc.App.GetStore().OCRKeyStore.FindEncryptedP2PKeyByID(ep2pk.ID)
+	if err != nil {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+	next, jobs, err := p2pkc.App.GetStore().OCRKeyStore.RotateP2PKey(encryptedP2PKeyPointer.PeerID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.P2PKeyRotation{EncryptedP2PKey: next, Jobs: jobs}, "p2pKey")
+}
@@ -0,0 +1,102 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+)
+
+const (
+	// runEventsWriteWait is the time allowed to write an event to the client
+	runEventsWriteWait = 10 * time.Second
+	// runEventsPingPeriod is how often the client is pinged, so that idle
+	// connections aren't closed by proxies and dead clients are detected
+	runEventsPingPeriod = 30 * time.Second
+)
+
+var runEventsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// PipelineRunEventsController streams v2 pipeline run events to clients.
+type PipelineRunEventsController struct {
+	App chainlink.Application
+}
+
+// Stream upgrades the request to a WebSocket and pushes runCreated,
+// taskCompleted and runFinished events as JSON messages until the client
+// disconnects.  Events can be limited to a single job with the jobID query
+// parameter.
+// Example:
+//  "GET <application>/pipeline/runs/events?jobID=1"
+func (prec *PipelineRunEventsController) Stream(c *gin.Context) {
+	var jobID int32
+	if jobIDStr := c.Query("jobID"); jobIDStr != "" {
+		id, err := strconv.ParseInt(jobIDStr, 10, 32)
+		if err != nil || id <= 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid jobID %s", jobIDStr))
+			return
+		}
+		jobID = int32(id)
+	}
+
+	sub, err := prec.App.SubscribeRunEventsV2(jobID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer sub.Close()
+
+	// Upgrade writes its own error response on failure
+	conn, err := runEventsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Debugw("Pipeline run events: could not upgrade connection", "error", err)
+		return
+	}
+	defer logger.ErrorIfCalling(conn.Close)
+
+	// Messages from the client are ignored, but must be read so that control
+	// frames are processed and disconnects are noticed
+	chClosed := make(chan struct{})
+	go func() {
+		defer close(chClosed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(runEventsPingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-chClosed:
+			return
+		case event, open := <-sub.Events():
+			if !open {
+				logger.Debugw("Pipeline run events: subscription closed because the client fell behind")
+				return
+			}
+			if err := conn.SetWriteDeadline(time.Now().Add(runEventsWriteWait)); err != nil {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				logger.Debugw("Pipeline run events: could not write event", "error", err)
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(runEventsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
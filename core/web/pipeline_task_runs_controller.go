@@ -0,0 +1,135 @@
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/chainlink/core/utils"
+)
+
+// PipelineTaskRunsController manages pipeline task runs that are waiting on an
+// asynchronous response from an external adapter.
+type PipelineTaskRunsController struct {
+	App chainlink.Application
+}
+
+// Update resumes a pending async bridge task run with the response from the
+// external adapter.  The request must be authenticated with the bridge's
+// incoming token, and must carry the task run's resume token, which is part of
+// the responseURL that the adapter was sent.
+// Example:
+//  "PATCH <application>/pipeline/task_runs/:ID"
+func (ptrc *PipelineTaskRunsController) Update(c *gin.Context) {
+	authToken := utils.StripBearer(c.Request.Header.Get("Authorization"))
+	store := ptrc.App.GetStore()
+
+	taskRun := pipeline.TaskRun{}
+	err := taskRun.SetID(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	err = store.DB.Preload("PipelineTaskSpec").Where("id = ?", taskRun.ID).First(&taskRun).Error
+	if postgres.IsRecordNotFound(err) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Task run not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if taskRun.PendingUntil == nil || taskRun.FinishedAt != nil {
+		jsonAPIError(c, http.StatusMethodNotAllowed, errors.New("Cannot resume a task run that isn't pending"))
+		return
+	}
+	if !validResumeToken(taskRun, c.Query(pipeline.ResumeTokenParam)) {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	task, err := pipeline.UnmarshalTaskFromMap(
+		taskRun.PipelineTaskSpec.Type,
+		taskRun.PipelineTaskSpec.JSON.Val,
+		taskRun.PipelineTaskSpec.DotID,
+		store.Config,
+		store.DB,
+		store.SecretStore,
+	)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	bridgeTask, is := task.(*pipeline.BridgeTask)
+	if !is {
+		jsonAPIError(c, http.StatusMethodNotAllowed, errors.New("Only bridge task runs can be resumed"))
+		return
+	}
+
+	bt, err := store.FindBridge(models.TaskType(bridgeTask.Name))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	ok, err := models.AuthenticateBridgeType(&bt, authToken)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	var brr models.BridgeRunResult
+	if err = json.Unmarshal(body, &brr); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	var result pipeline.Result
+	if brr.HasError() {
+		result.Error = brr.GetError()
+	} else if brr.ExternalPending {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("Cannot resume a task run with a pending response"))
+		return
+	} else {
+		result.Value = body
+	}
+
+	err = ptrc.App.ResumeJobV2(c.Request.Context(), taskRun.ID, result)
+	if errors.Cause(err) == pipeline.ErrTaskRunNotPending {
+		jsonAPIError(c, http.StatusMethodNotAllowed, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, taskRun, "pipelineTaskRun")
+}
+
+// validResumeToken returns true if the token hashes to the task run's resume
+// token hash
+func validResumeToken(taskRun pipeline.TaskRun, token string) bool {
+	if !taskRun.ResumeTokenHash.Valid || token == "" {
+		return false
+	}
+	hash, err := utils.Sha256(token)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(taskRun.ResumeTokenHash.String)) == 1
+}
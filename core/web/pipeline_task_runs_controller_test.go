@@ -0,0 +1,135 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/onsi/gomega"
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/services/offchainreporting"
+	"github.com/smartcontractkit/chainlink/core/services/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineTaskRunsController_Update(t *testing.T) {
+	t.Parallel()
+
+	config, cleanup := cltest.NewConfig(t)
+	defer cleanup()
+	app, cleanup := cltest.NewApplicationWithConfigAndKey(t, config, cltest.LenientEthMock)
+	defer cleanup()
+	config.Set("BRIDGE_RESPONSE_URL", app.Config.ClientNodeURL())
+	require.NoError(t, app.Start())
+
+	// The external adapter answers later at the responseURL it is sent
+	chResponseURL := make(chan string, 1)
+	adapter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &req))
+		chResponseURL <- req["responseURL"].(string)
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"pending": true}`))
+		require.NoError(t, err)
+	}))
+	defer adapter.Close()
+
+	bta, bt := cltest.NewBridgeType(t, "async_adapter", adapter.URL)
+	require.NoError(t, app.Store.CreateBridgeType(bt))
+
+	var ocrJobSpec offchainreporting.OracleSpec
+	_, err := toml.Decode(fmt.Sprintf(`
+	type               = "offchainreporting"
+	schemaVersion      = 1
+	contractAddress    = "%s"
+	p2pPeerID          = "%s"
+	p2pBootstrapPeers  = [
+		"/dns4/chain.link/tcp/1234/p2p/16Uiu2HAm58SP7UL8zsnpeuwHfytLocaqgnyaYKP8wu7qRdrixLju",
+	]
+	keyBundleID        = "%s"
+	transmitterAddress = "%s"
+	observationSource = """
+		ds          [type=bridge name="async_adapter" async=true];
+		ds_parse    [type=jsonparse path="data,result"];
+
+		ds -> ds_parse -> answer;
+
+		answer [type=median index=0];
+	"""
+	`, cltest.NewAddress().Hex(), cltest.DefaultP2PPeerID, cltest.DefaultOCRKeyBundleID, cltest.DefaultKey), &ocrJobSpec)
+	require.NoError(t, err)
+
+	jobID, err := app.AddJobV2(context.Background(), ocrJobSpec)
+	require.NoError(t, err)
+	runID, err := app.RunJobV2(context.Background(), jobID, nil)
+	require.NoError(t, err)
+
+	responseURL, err := url.Parse(<-chResponseURL)
+	require.NoError(t, err)
+	require.NotEmpty(t, responseURL.Query().Get(pipeline.ResumeTokenParam))
+
+	// Wait for the task run to be suspended
+	var taskRun pipeline.TaskRun
+	gomega.NewGomegaWithT(t).Eventually(func() bool {
+		require.NoError(t, app.Store.DB.Where("pipeline_run_id = ? AND pending_until IS NOT NULL", runID).Find(&taskRun).Error)
+		return taskRun.ID != 0
+	}).Should(gomega.BeTrue())
+	assert.Equal(t, responseURL.Path, fmt.Sprintf("/v2/pipeline/task_runs/%d", taskRun.ID))
+
+	body := `{"data":{"result": "42"}}`
+	authorized := map[string]string{"Authorization": "Bearer " + bta.IncomingToken}
+	withoutToken := app.Config.ClientNodeURL() + responseURL.Path
+
+	t.Run("without the resume token", func(t *testing.T) {
+		resp, cleanup := cltest.UnauthenticatedPatch(t, withoutToken, bytes.NewBufferString(body), authorized)
+		defer cleanup()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("with the wrong resume token", func(t *testing.T) {
+		resp, cleanup := cltest.UnauthenticatedPatch(t, withoutToken+"?resume_token=wrong", bytes.NewBufferString(body), authorized)
+		defer cleanup()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("with the wrong bridge token", func(t *testing.T) {
+		headers := map[string]string{"Authorization": "Bearer wrong"}
+		resp, cleanup := cltest.UnauthenticatedPatch(t, responseURL.String(), bytes.NewBufferString(body), headers)
+		defer cleanup()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("with both tokens", func(t *testing.T) {
+		resp, cleanup := cltest.UnauthenticatedPatch(t, responseURL.String(), bytes.NewBufferString(body), authorized)
+		defer cleanup()
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		require.NoError(t, app.AwaitRun(context.Background(), runID))
+		var run pipeline.Run
+		require.NoError(t, app.Store.DB.Where("id = ?", runID).First(&run).Error)
+		require.NotNil(t, run.Outputs)
+		assert.Equal(t, []interface{}{"42"}, run.Outputs.Val)
+	})
+
+	t.Run("once it has already been resumed", func(t *testing.T) {
+		resp, cleanup := cltest.UnauthenticatedPatch(t, responseURL.String(), bytes.NewBufferString(body), authorized)
+		defer cleanup()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("for a task run that does not exist", func(t *testing.T) {
+		resp, cleanup := cltest.UnauthenticatedPatch(t, app.Config.ClientNodeURL()+"/v2/pipeline/task_runs/999999", bytes.NewBufferString(body), authorized)
+		defer cleanup()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
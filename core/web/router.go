@@ -198,6 +198,9 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	jr := JobRunsController{app}
 	unauthedv2.PATCH("/runs/:RunID", jr.Update)
 
+	ptr := PipelineTaskRunsController{app}
+	unauthedv2.PATCH("/pipeline/task_runs/:ID", ptr.Update)
+
 	sa := ServiceAgreementsController{app}
 	unauthedv2.POST("/service_agreements", sa.Create)
 
@@ -243,6 +246,8 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 			kc := KeysController{app}
 			authv2.POST("/keys", kc.Create)
 		}
+		kc := KeysController{app}
+		authv2.PATCH("/keys/:address", kc.Update)
 
 		cc := ConfigController{app}
 		authv2.GET("/config", cc.Show)
@@ -255,6 +260,9 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/transactions", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/:TxHash", txs.Show)
 
+		etc := EthTxesController{app}
+		authv2.PATCH("/eth_txes/:ID", etc.Update)
+
 		bdc := BulkDeletesController{app}
 		authv2.DELETE("/bulk_delete_runs", bdc.Delete)
 
@@ -262,11 +270,30 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/off_chain_reporting_keys", ocrkc.Index)
 		authv2.POST("/off_chain_reporting_keys", ocrkc.Create)
 		authv2.DELETE("/off_chain_reporting_keys/:keyID", ocrkc.Delete)
+		authv2.POST("/off_chain_reporting_keys/:keyID/rotate", ocrkc.Rotate)
 
 		p2pkc := P2PKeysController{app}
 		authv2.GET("/p2p_keys", p2pkc.Index)
 		authv2.POST("/p2p_keys", p2pkc.Create)
 		authv2.DELETE("/p2p_keys/:keyID", p2pkc.Delete)
+		authv2.POST("/p2p_keys/:keyID/rotate", p2pkc.Rotate)
+
+		prec := PipelineRunEventsController{app}
+		authv2.GET("/pipeline/runs/events", prec.Stream)
+
+		enc := EthNodesController{app}
+		authv2.GET("/eth_nodes", enc.Index)
+
+		gec := GasEstimatesController{app}
+		authv2.GET("/gas_estimate", gec.Show)
+
+		fc := FinalityController{app}
+		authv2.GET("/finality", fc.Show)
+
+		sc := SecretsController{app}
+		authv2.GET("/secrets", sc.Index)
+		authv2.POST("/secrets", sc.Create)
+		authv2.DELETE("/secrets/:name", sc.Delete)
 
 		ocr := authv2.Group("/ocr")
 		{
@@ -450,6 +477,7 @@ var blacklist = map[string]struct{}{
 	"oldpassword":          struct{}{},
 	"current_password":     struct{}{},
 	"new_account_password": struct{}{},
+	"resume_token":         struct{}{},
 }
 
 func isBlacklisted(k string) bool {
@@ -0,0 +1,72 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/core/services/postgres"
+)
+
+// SecretsController manages the node's encrypted secrets, which can be
+// referenced by name from HTTP task authentication parameters
+type SecretsController struct {
+	App chainlink.Application
+}
+
+// SecretRequest is the request body for creating or updating a secret
+type SecretRequest struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Index lists the names of all secrets.  Secret values are never returned.
+// Example:
+// "GET <application>/secrets"
+func (sc *SecretsController) Index(c *gin.Context) {
+	secrets, err := sc.App.GetStore().SecretStore.FindEncryptedSecrets()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, secrets, "secret")
+}
+
+// Create encrypts and stores a secret, replacing any existing secret with
+// the same name
+// Example:
+// "POST <application>/secrets"
+func (sc *SecretsController) Create(c *gin.Context) {
+	var request SecretRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.Name == "" {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("secret name must not be empty"))
+		return
+	}
+
+	secret, err := sc.App.GetStore().SecretStore.UpsertSecret(request.Name, request.Value)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, secret, "secret")
+}
+
+// Delete removes a secret
+// Example:
+// "DELETE <application>/secrets/:name"
+func (sc *SecretsController) Delete(c *gin.Context) {
+	err := sc.App.GetStore().SecretStore.DeleteSecret(c.Param("name"))
+	if postgres.IsRecordNotFound(err) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("Secret not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponseWithStatus(c, nil, "secret", http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package web_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsController_Create(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/secrets", bytes.NewBufferString(`{"name":"api_key","value":"s3cr3t"}`))
+	defer cleanup()
+	body := cltest.ParseResponseBody(t, resp)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+	assert.NotContains(t, string(body), "s3cr3t")
+
+	value, err := app.GetStore().SecretStore.Secret("api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	secrets, err := app.GetStore().SecretStore.FindEncryptedSecrets()
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	decrypted, err := secrets[0].Decrypt(cltest.Password)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", decrypted)
+}
+
+func TestSecretsController_Create_Invalid(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Post("/v2/secrets", bytes.NewBufferString(`{"value":"s3cr3t"}`))
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+}
+
+func TestSecretsController_Index(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	_, err := app.GetStore().SecretStore.UpsertSecret("b_key", "b")
+	require.NoError(t, err)
+	_, err = app.GetStore().SecretStore.UpsertSecret("a_key", "a")
+	require.NoError(t, err)
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Get("/v2/secrets")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var secrets []models.EncryptedSecret
+	err = cltest.ParseJSONAPIResponse(t, resp, &secrets)
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	assert.Equal(t, "a_key", secrets[0].Name)
+	assert.Equal(t, "b_key", secrets[1].Name)
+}
+
+func TestSecretsController_Delete(t *testing.T) {
+	t.Parallel()
+
+	app, cleanup := cltest.NewApplicationWithKey(t, cltest.LenientEthMock)
+	defer cleanup()
+	require.NoError(t, app.Start())
+
+	_, err := app.GetStore().SecretStore.UpsertSecret("api_key", "s3cr3t")
+	require.NoError(t, err)
+
+	client := app.NewHTTPClient()
+
+	resp, cleanup := client.Delete("/v2/secrets/api_key")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+
+	_, err = app.GetStore().SecretStore.Secret("api_key")
+	assert.Error(t, err)
+
+	resp, cleanup = client.Delete("/v2/secrets/api_key")
+	defer cleanup()
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
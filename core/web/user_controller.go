@@ -186,15 +186,27 @@ func getETHAccount(ctx *gin.Context, store *store.Store, account accounts.Accoun
 		return presenters.ETHKey{}
 	}
 
+	pendingTxs, err := store.ORM.CountPendingEthTxs(account.Address)
+	if err != nil {
+		err = fmt.Errorf("error counting pending transactions from DB: %v", err)
+		jsonAPIError(ctx, http.StatusInternalServerError, err)
+		ctx.Abort()
+		return presenters.ETHKey{}
+	}
+
 	return presenters.ETHKey{
-		Address:     account.Address.Hex(),
-		EthBalance:  (*assets.Eth)(ethBalance),
-		LinkBalance: linkBalance,
-		NextNonce:   key.NextNonce,
-		LastUsed:    key.LastUsed,
-		IsFunding:   key.IsFunding,
-		CreatedAt:   key.CreatedAt,
-		UpdatedAt:   key.UpdatedAt,
-		DeletedAt:   key.DeletedAt,
+		Address:       account.Address.Hex(),
+		EthBalance:    (*assets.Eth)(ethBalance),
+		LinkBalance:   linkBalance,
+		NextNonce:     key.NextNonce,
+		LastUsed:      key.LastUsed,
+		IsFunding:     key.IsFunding,
+		Group:         key.GroupName.String,
+		PendingTxs:    pendingTxs,
+		LowWatermark:  key.EthLowWatermark,
+		HighWatermark: key.EthHighWatermark,
+		CreatedAt:     key.CreatedAt,
+		UpdatedAt:     key.UpdatedAt,
+		DeletedAt:     key.DeletedAt,
 	}
 }